@@ -70,6 +70,11 @@ type Agent struct {
 	// before use. If you already have a Handoff, add it to Handoffs.
 	AgentHandoffs []*Agent
 
+	// Optional function computing additional handoffs at every turn, for example depending on
+	// the run context or on the conversation so far. They are offered to the model together
+	// with Handoffs and AgentHandoffs.
+	DynamicHandoffs DynamicHandoffsFunc
+
 	// The model implementation to use when invoking the LLM.
 	Model param.Opt[AgentModel]
 
@@ -87,6 +92,10 @@ type Agent struct {
 	// Runs only if the agent produces a final output.
 	OutputGuardrails []OutputGuardrail
 
+	// A list of checks that run on the partial text output of the agent, while a model response
+	// is streamed. Only used by streamed runs.
+	StreamingOutputGuardrails []StreamingOutputGuardrail
+
 	// Optional output schema object describing the output. If not provided, the output will be a simple string.
 	OutputSchema AgentOutputSchemaInterface
 
@@ -113,6 +122,17 @@ type Agent struct {
 	// Defaults to true.
 	// This ensures that the agent doesn't enter an infinite loop of tool usage.
 	ResetToolChoice param.Opt[bool]
+
+	// Optional maximum number of times the model is asked to repair an invalid output in a
+	// run: a final output failing the validation of OutputSchema, or function tool arguments
+	// which cannot be parsed. The validation error is sent back to the model, which gets to
+	// try again. If set, it overrides RunConfig.OutputRepairRetries.
+	OutputRepairRetries param.Opt[int]
+
+	// Whether a final output which is not valid JSON for OutputSchema is tried again with
+	// the JSON value found in it by ExtractJSON, for models which wrap their JSON answers in
+	// markdown fences or prose. If set, it overrides RunConfig.LenientJSONOutput.
+	LenientJSONOutput param.Opt[bool]
 }
 
 type AgentAsToolParams struct {
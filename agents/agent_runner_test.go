@@ -586,6 +586,38 @@ func TestToolUseBehaviorFirstOutput(t *testing.T) {
 	assert.Equal(t, AgentRunnerTestFoo{Bar: "tool_one_result"}, result.FinalOutput)
 }
 
+func TestLocalShellCallIsExecuted(t *testing.T) {
+	var commands [][]string
+	shell := agents.LocalShellTool{
+		Executor: func(_ context.Context, req agents.LocalShellCommandRequest) (string, error) {
+			commands = append(commands, req.Data.Action.Command)
+			return "hi\n", nil
+		},
+	}
+
+	model := agentstesting.NewFakeModel(nil)
+	model.AddMultipleTurnOutputs([]agentstesting.FakeModelTurnOutput{
+		{Value: []agents.TResponseOutputItem{agentstesting.GetLocalShellCall("echo", "hi")}},
+		{Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("done")}},
+	})
+	agent := agents.New("test").WithModelInstance(model).WithTools(shell)
+
+	result, err := agents.Run(t.Context(), agent, "user_message")
+	require.NoError(t, err)
+	assert.Equal(t, "done", result.FinalOutput)
+	assert.Equal(t, [][]string{{"echo", "hi"}}, commands)
+
+	require.Len(t, result.NewItems, 3)
+	assert.Equal(t, "hi\n", result.NewItems[1].(agents.ToolCallOutputItem).Output)
+
+	// The output is sent back to the model in the next turn.
+	input := model.LastTurnArgs.Input.(agents.InputItems)
+	output := input[len(input)-1].OfLocalShellCallOutput
+	require.NotNil(t, output)
+	assert.Equal(t, "2", output.ID) // the call ID
+	assert.Equal(t, "hi\n", output.Output)
+}
+
 var CustomToolUseBehavior = func(_ context.Context, results []agents.FunctionToolResult) (agents.ToolsToFinalOutputResult, error) {
 	if slices.ContainsFunc(results, func(r agents.FunctionToolResult) bool { return r.Tool.ToolName() == "test_tool_one" }) {
 		return agents.ToolsToFinalOutputResult{
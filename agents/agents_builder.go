@@ -68,6 +68,12 @@ func (a *Agent) WithAgentHandoffs(agents ...*Agent) *Agent {
 	return a
 }
 
+// WithDynamicHandoffs sets the function computing additional handoffs at every turn.
+func (a *Agent) WithDynamicHandoffs(fn DynamicHandoffsFunc) *Agent {
+	a.DynamicHandoffs = fn
+	return a
+}
+
 // WithModel sets the model to use by name.
 func (a *Agent) WithModel(name string) *Agent {
 	a.Model = param.NewOpt(NewAgentModelName(name))
@@ -116,6 +122,12 @@ func (a *Agent) WithOutputGuardrails(gr []OutputGuardrail) *Agent {
 	return a
 }
 
+// WithStreamingOutputGuardrails sets the streaming output guardrails.
+func (a *Agent) WithStreamingOutputGuardrails(gr []StreamingOutputGuardrail) *Agent {
+	a.StreamingOutputGuardrails = gr
+	return a
+}
+
 // WithOutputSchema sets the output schema.
 func (a *Agent) WithOutputSchema(schema AgentOutputSchemaInterface) *Agent {
 	a.OutputSchema = schema
@@ -139,3 +151,16 @@ func (a *Agent) WithResetToolChoice(v param.Opt[bool]) *Agent {
 	a.ResetToolChoice = v
 	return a
 }
+
+// WithOutputRepairRetries sets the maximum number of invalid output repairs.
+func (a *Agent) WithOutputRepairRetries(n int) *Agent {
+	a.OutputRepairRetries = param.NewOpt(n)
+	return a
+}
+
+// WithLenientJSONOutput sets whether final outputs are leniently extracted
+// with ExtractJSON.
+func (a *Agent) WithLenientJSONOutput(v bool) *Agent {
+	a.LenientJSONOutput = param.NewOpt(v)
+	return a
+}
@@ -0,0 +1,74 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/openai/openai-go/packages/param"
+)
+
+// ContextManager can rewrite the conversation history right before it is
+// sent to the model, for example to keep it within the model's context window.
+//
+// It only affects the input of each model call: the items of the run
+// (RunResult.NewItems, RunResult.ToInputList, ...) are never modified.
+// A ContextManager is called before every model call, always with the full
+// history of the run.
+//
+// See the context_managers extension for built-in strategies.
+type ContextManager interface {
+	ManageContext(context.Context, ContextManagerParams) ([]TResponseInputItem, error)
+}
+
+// ContextManagerParams are the parameters passed to a ContextManager.
+type ContextManagerParams struct {
+	// The agent which is about to be called.
+	Agent *Agent
+
+	// The system instructions of the agent.
+	SystemInstructions param.Opt[string]
+
+	// The full conversation history.
+	Input []TResponseInputItem
+}
+
+// ContextManagerFunc is a function satisfying the ContextManager interface.
+type ContextManagerFunc func(context.Context, ContextManagerParams) ([]TResponseInputItem, error)
+
+func (fn ContextManagerFunc) ManageContext(ctx context.Context, params ContextManagerParams) ([]TResponseInputItem, error) {
+	return fn(ctx, params)
+}
+
+func (r Runner) manageContext(
+	ctx context.Context,
+	agent *Agent,
+	systemPrompt param.Opt[string],
+	input []TResponseInputItem,
+) ([]TResponseInputItem, error) {
+	if r.Config.ContextManager == nil {
+		return input, nil
+	}
+	managed, err := r.Config.ContextManager.ManageContext(ctx, ContextManagerParams{
+		Agent:              agent,
+		SystemInstructions: systemPrompt,
+		Input:              input,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ContextManager failed: %w", err)
+	}
+	return managed, nil
+}
@@ -183,6 +183,62 @@ func NewOutputGuardrailTripwireTriggeredError(guardrailResult OutputGuardrailRes
 	}
 }
 
+// ToolInputGuardrailTripwireTriggeredError is returned when a tool input guardrail tripwire is triggered.
+type ToolInputGuardrailTripwireTriggeredError struct {
+	*AgentsError
+	// The result data of the guardrail that was triggered.
+	GuardrailResult ToolInputGuardrailResult
+}
+
+func (err ToolInputGuardrailTripwireTriggeredError) Error() string {
+	if err.AgentsError == nil {
+		return "ToolInputGuardrailTripwireTriggeredError"
+	}
+	return err.AgentsError.Error()
+}
+
+func (err ToolInputGuardrailTripwireTriggeredError) Unwrap() error {
+	return err.AgentsError
+}
+
+func NewToolInputGuardrailTripwireTriggeredError(guardrailResult ToolInputGuardrailResult) ToolInputGuardrailTripwireTriggeredError {
+	return ToolInputGuardrailTripwireTriggeredError{
+		AgentsError: AgentsErrorf(
+			"tool input guardrail %s triggered tripwire on tool %s",
+			guardrailResult.Guardrail.Name, guardrailResult.Data.Tool.ToolName(),
+		),
+		GuardrailResult: guardrailResult,
+	}
+}
+
+// ToolOutputGuardrailTripwireTriggeredError is returned when a tool output guardrail tripwire is triggered.
+type ToolOutputGuardrailTripwireTriggeredError struct {
+	*AgentsError
+	// The result data of the guardrail that was triggered.
+	GuardrailResult ToolOutputGuardrailResult
+}
+
+func (err ToolOutputGuardrailTripwireTriggeredError) Error() string {
+	if err.AgentsError == nil {
+		return "ToolOutputGuardrailTripwireTriggeredError"
+	}
+	return err.AgentsError.Error()
+}
+
+func (err ToolOutputGuardrailTripwireTriggeredError) Unwrap() error {
+	return err.AgentsError
+}
+
+func NewToolOutputGuardrailTripwireTriggeredError(guardrailResult ToolOutputGuardrailResult) ToolOutputGuardrailTripwireTriggeredError {
+	return ToolOutputGuardrailTripwireTriggeredError{
+		AgentsError: AgentsErrorf(
+			"tool output guardrail %s triggered tripwire on tool %s",
+			guardrailResult.Guardrail.Name, guardrailResult.Data.Tool.ToolName(),
+		),
+		GuardrailResult: guardrailResult,
+	}
+}
+
 // TaskCanceledError is returned when a task has been canceled.
 type TaskCanceledError struct {
 	*AgentsError
@@ -0,0 +1,250 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agentconfig_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/agents/extensions/agentconfig"
+	"github.com/nlpodyssey/openai-agents-go/agentstesting"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testYAML = `
+vars:
+  company: Acme
+agents:
+  - name: triage
+    instructions: "You work for {{.company}} in {{.region}}."
+    model: gpt-4o
+    model_settings:
+      temperature: 0.2
+      tool_choice: auto
+    handoffs: [billing]
+    tools:
+      - type: function
+        name: lookup_order
+      - type: web_search
+        search_context_size: low
+      - type: code_interpreter
+    input_guardrails: [no_profanity]
+  - name: billing
+    handoff_description: Handles billing questions.
+    instructions: Help with billing.
+    output_guardrails: [no_secrets]
+    output_schema:
+      name: invoice
+      schema:
+        type: object
+        properties:
+          amount: {type: number}
+        required: [amount]
+        additionalProperties: false
+`
+
+func testRegistry() *agentconfig.Registry {
+	noop := agents.GuardrailFunctionOutput{}
+	return agentconfig.NewRegistry().
+		RegisterTool(agentstesting.GetFunctionTool("lookup_order", "shipped")).
+		RegisterInputGuardrail(agents.InputGuardrail{
+			Name: "no_profanity",
+			GuardrailFunction: func(context.Context, *agents.Agent, agents.Input) (agents.GuardrailFunctionOutput, error) {
+				return noop, nil
+			},
+		}).
+		RegisterOutputGuardrail(agents.OutputGuardrail{
+			Name: "no_secrets",
+			GuardrailFunction: func(context.Context, *agents.Agent, any) (agents.GuardrailFunctionOutput, error) {
+				return noop, nil
+			},
+		})
+}
+
+func TestLoadYAML(t *testing.T) {
+	loader := agentconfig.Loader{
+		Registry: testRegistry(),
+		Vars:     map[string]any{"region": "EMEA"},
+	}
+	defs, err := loader.Load([]byte(testYAML), agentconfig.FormatYAML)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"triage", "billing"}, defs.Names())
+
+	triage, ok := defs.Agent("triage")
+	require.True(t, ok)
+	billing, ok := defs.Agent("billing")
+	require.True(t, ok)
+
+	assert.Equal(t, agents.InstructionsStr("You work for Acme in EMEA."), triage.Instructions)
+	assert.Equal(t, "gpt-4o", triage.Model.Value.ModelName())
+	assert.Equal(t, 0.2, triage.ModelSettings.Temperature.Value)
+	assert.Equal(t, "auto", triage.ModelSettings.ToolChoice)
+	require.Len(t, triage.AgentHandoffs, 1)
+	assert.Same(t, billing, triage.AgentHandoffs[0])
+	require.Len(t, triage.Tools, 3)
+	assert.Equal(t, "lookup_order", triage.Tools[0].ToolName())
+	assert.Equal(t, "web_search_preview", triage.Tools[1].ToolName())
+	assert.Equal(t, "code_interpreter", triage.Tools[2].ToolName())
+	require.Len(t, triage.InputGuardrails, 1)
+	assert.Equal(t, "no_profanity", triage.InputGuardrails[0].Name)
+
+	assert.Equal(t, "Handles billing questions.", billing.HandoffDescription)
+	require.Len(t, billing.OutputGuardrails, 1)
+	require.NotNil(t, billing.OutputSchema)
+	assert.Equal(t, "invoice", billing.OutputSchema.Name())
+	assert.True(t, billing.OutputSchema.IsStrictJSONSchema())
+
+	v, err := billing.OutputSchema.ValidateJSON(`{"amount": 12.5}`)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"amount": 12.5}, v)
+
+	_, err = billing.OutputSchema.ValidateJSON(`{"amount": "twelve"}`)
+	var behaviorErr agents.ModelBehaviorError
+	assert.ErrorAs(t, err, &behaviorErr)
+}
+
+func TestLoadJSON(t *testing.T) {
+	defs, err := agentconfig.Loader{}.Load([]byte(`{
+		"agents": [
+			{"name": "a", "instructions": "hi", "tools": [{"type": "file_search", "vector_store_ids": ["vs_1"], "max_num_results": 3}]}
+		]
+	}`), agentconfig.FormatJSON)
+	require.NoError(t, err)
+
+	a, ok := defs.Agent("a")
+	require.True(t, ok)
+	require.Len(t, a.Tools, 1)
+	tool := a.Tools[0].(agents.FileSearchTool)
+	assert.Equal(t, []string{"vs_1"}, tool.VectorStoreIDs)
+	assert.Equal(t, int64(3), tool.MaxNumResults.Value)
+}
+
+func TestLoadErrors(t *testing.T) {
+	t.Run("unknown references", func(t *testing.T) {
+		_, err := agentconfig.Loader{}.Load([]byte(`
+agents:
+  - name: a
+    handoffs: [missing]
+    tools: [{type: function, name: nope}]
+    input_guardrails: [nope]
+`), agentconfig.FormatYAML)
+		require.ErrorIs(t, err, agentconfig.ErrUnknownReference)
+		assert.ErrorContains(t, err, `agent "a": unknown reference: handoff to agent "missing"`)
+		assert.ErrorContains(t, err, `agent "a": tools[0]: unknown reference: tool "nope"`)
+		assert.ErrorContains(t, err, `agent "a": unknown reference: input guardrail "nope"`)
+	})
+
+	t.Run("handoff cycle", func(t *testing.T) {
+		data := []byte(`
+agents:
+  - {name: a, handoffs: [b]}
+  - {name: b, handoffs: [c]}
+  - {name: c, handoffs: [a]}
+`)
+		_, err := agentconfig.Loader{}.Load(data, agentconfig.FormatYAML)
+		require.ErrorIs(t, err, agentconfig.ErrHandoffCycle)
+		assert.ErrorContains(t, err, "a -> b -> c -> a")
+
+		defs, err := agentconfig.Loader{AllowHandoffCycles: true}.Load(data, agentconfig.FormatYAML)
+		require.NoError(t, err)
+		c, _ := defs.Agent("c")
+		a, _ := defs.Agent("a")
+		assert.Same(t, a, c.AgentHandoffs[0])
+	})
+
+	t.Run("invalid schema", func(t *testing.T) {
+		_, err := agentconfig.Loader{}.Load([]byte(`
+agents:
+  - name: a
+    output_schema:
+      schema: {type: 42}
+`), agentconfig.FormatYAML)
+		assert.ErrorIs(t, err, agentconfig.ErrInvalidSchema)
+	})
+
+	t.Run("invalid definition", func(t *testing.T) {
+		for name, data := range map[string]string{
+			"unknown field":    `agents: [{name: a, instruction: typo}]`,
+			"duplicate agent":  `agents: [{name: a}, {name: a}]`,
+			"missing name":     `agents: [{instructions: hi}]`,
+			"missing variable": `agents: [{name: a, instructions: "{{.nope}}"}]`,
+			"unknown tool":     `agents: [{name: a, tools: [{type: teleport}]}]`,
+		} {
+			_, err := agentconfig.Loader{}.Load([]byte(data), agentconfig.FormatYAML)
+			assert.ErrorIs(t, err, agentconfig.ErrInvalidDefinition, name)
+		}
+	})
+}
+
+func TestWatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "agents.yaml")
+	write := func(instructions string) {
+		t.Helper()
+		data := []byte("agents: [{name: a, instructions: " + instructions + "}]")
+		require.NoError(t, os.WriteFile(path, data, 0o600))
+	}
+	instructionsOf := func(defs *agentconfig.Definitions) agents.InstructionsGetter {
+		a, _ := defs.Agent("a")
+		return a.Instructions
+	}
+
+	write("v1")
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	reloaded := make(chan error, 10)
+	w, err := agentconfig.Loader{}.Watch(ctx, path, agentconfig.WatchParams{
+		Interval: 5 * time.Millisecond,
+		OnReload: func(_ *agentconfig.Definitions, err error) { reloaded <- err },
+	})
+	require.NoError(t, err)
+	assert.Equal(t, agents.InstructionsStr("v1"), instructionsOf(w.Definitions()))
+
+	// The watcher may observe a partially written file: wait until the
+	// expected outcome, which must never be lost.
+	waitReload := func(done func(error) bool) {
+		t.Helper()
+		timeout := time.After(5 * time.Second)
+		for {
+			select {
+			case err := <-reloaded:
+				if done(err) {
+					return
+				}
+			case <-timeout:
+				require.FailNow(t, "timeout waiting for reload")
+			}
+		}
+	}
+
+	write("version-2")
+	waitReload(func(err error) bool { return err == nil })
+	assert.Equal(t, agents.InstructionsStr("version-2"), instructionsOf(w.Definitions()))
+
+	// A broken file is reported, and the last good definitions are kept.
+	write("'{{.missing}}'")
+	waitReload(func(err error) bool { return errors.Is(err, agentconfig.ErrInvalidDefinition) })
+	assert.Equal(t, agents.InstructionsStr("version-2"), instructionsOf(w.Definitions()))
+
+	cancel()
+	<-w.Done()
+}
@@ -0,0 +1,141 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package agentconfig builds agent graphs from declarative YAML or JSON
+// definitions, so that agents can be edited without recompiling.
+//
+// A definition file looks like this:
+//
+//	vars:
+//	  company: Acme
+//	agents:
+//	  - name: triage
+//	    instructions: "You are the triage agent of {{.company}}."
+//	    model: gpt-4o
+//	    model_settings:
+//	      temperature: 0.2
+//	    handoffs: [billing]
+//	    tools:
+//	      - type: web_search
+//	      - type: function
+//	        name: lookup_order
+//	    input_guardrails: [no_profanity]
+//	  - name: billing
+//	    handoff_description: Handles billing questions.
+//	    instructions: Help the user with billing.
+//	    output_schema:
+//	      name: invoice
+//	      schema:
+//	        type: object
+//	        properties:
+//	          amount: {type: number}
+//	        required: [amount]
+//	        additionalProperties: false
+//
+// Function tools and guardrails cannot be expressed declaratively: they are
+// implemented in Go, registered by name in a Registry, and referenced by name
+// from the definitions.
+package agentconfig
+
+import (
+	"encoding/json"
+
+	"github.com/nlpodyssey/openai-agents-go/modelsettings"
+)
+
+// Config is the root of a definitions file.
+type Config struct {
+	// Variables available to the instructions templates.
+	// They are merged with (and overridden by) Loader.Vars.
+	Vars map[string]any `json:"vars"`
+
+	// The agent definitions.
+	Agents []AgentConfig `json:"agents"`
+}
+
+// AgentConfig is the declarative definition of a single agent.
+type AgentConfig struct {
+	// The name of the agent. It must be unique within a Config.
+	Name string `json:"name"`
+
+	// The instructions of the agent. They are parsed as a text/template and
+	// rendered once, at load time, with the configuration variables.
+	Instructions string `json:"instructions"`
+
+	// Optional description of the agent, used when the agent is a handoff target.
+	HandoffDescription string `json:"handoff_description"`
+
+	// Optional name of the model to use.
+	Model string `json:"model"`
+
+	// Optional model-specific tuning parameters.
+	ModelSettings modelsettings.ModelSettings `json:"model_settings"`
+
+	// Names of the agents to which this agent can hand off.
+	Handoffs []string `json:"handoffs"`
+
+	// Tools available to the agent.
+	Tools []ToolConfig `json:"tools"`
+
+	// Names of registered input guardrails.
+	InputGuardrails []string `json:"input_guardrails"`
+
+	// Names of registered output guardrails.
+	OutputGuardrails []string `json:"output_guardrails"`
+
+	// Optional structured output definition.
+	OutputSchema *OutputSchemaConfig `json:"output_schema"`
+}
+
+// ToolConfig is the declarative definition of a tool.
+type ToolConfig struct {
+	// The kind of tool. One of:
+	//   - "function": a function tool registered in the Registry, referenced by Name
+	//   - "web_search"
+	//   - "file_search"
+	//   - "code_interpreter"
+	//   - "image_generation"
+	Type string `json:"type"`
+
+	// Name of a registered tool (only for "function").
+	Name string `json:"name"`
+
+	// Search context size (only for "web_search").
+	SearchContextSize string `json:"search_context_size"`
+
+	// Vector store IDs (only for "file_search").
+	VectorStoreIDs []string `json:"vector_store_ids"`
+
+	// Maximum number of results (only for "file_search").
+	MaxNumResults int64 `json:"max_num_results"`
+
+	// Whether to include the search results in the output (only for "file_search").
+	IncludeSearchResults bool `json:"include_search_results"`
+
+	// Raw tool configuration, in the format of the OpenAI Responses API
+	// (only for "code_interpreter" and "image_generation").
+	Config json.RawMessage `json:"config"`
+}
+
+// OutputSchemaConfig defines a structured output through an inline JSON Schema.
+type OutputSchemaConfig struct {
+	// The name of the output type. Default: "final_output".
+	Name string `json:"name"`
+
+	// The JSON Schema of the output.
+	Schema map[string]any `json:"schema"`
+
+	// Whether the schema is in strict mode. Default: true.
+	Strict *bool `json:"strict"`
+}
@@ -0,0 +1,383 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agentconfig
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/openai/openai-go/packages/param"
+	"github.com/openai/openai-go/responses"
+	"github.com/openai/openai-go/shared/constant"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	// ErrInvalidDefinition is wrapped by errors caused by malformed or
+	// inconsistent definitions.
+	ErrInvalidDefinition = errors.New("invalid agent definition")
+
+	// ErrUnknownReference is wrapped by errors caused by references to
+	// agents, tools or guardrails which do not exist.
+	ErrUnknownReference = errors.New("unknown reference")
+
+	// ErrInvalidSchema is wrapped by errors caused by invalid output JSON schemas.
+	ErrInvalidSchema = errors.New("invalid output schema")
+
+	// ErrHandoffCycle is wrapped by errors caused by cyclic handoffs,
+	// unless Loader.AllowHandoffCycles is true.
+	ErrHandoffCycle = errors.New("handoff cycle")
+)
+
+// Format is the serialization format of a definitions file.
+type Format string
+
+const (
+	FormatYAML Format = "yaml"
+	FormatJSON Format = "json"
+)
+
+// FormatFromPath infers the Format from the extension of a file path.
+func FormatFromPath(path string) (Format, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return FormatYAML, nil
+	case ".json":
+		return FormatJSON, nil
+	default:
+		return "", fmt.Errorf("unsupported definitions file extension %q", filepath.Ext(path))
+	}
+}
+
+// Loader builds agents from declarative definitions.
+type Loader struct {
+	// Registry of Go-implemented tools and guardrails. Optional.
+	Registry *Registry
+
+	// Variables available to the instructions templates, overriding the
+	// ones defined in the configuration.
+	Vars map[string]any
+
+	// Whether cyclic handoffs (e.g. A -> B -> A) are accepted.
+	// By default, they are reported as errors.
+	AllowHandoffCycles bool
+}
+
+// Definitions is the result of loading a Config: a set of agents, linked to
+// each other through their handoffs.
+type Definitions struct {
+	agents map[string]*agents.Agent
+	names  []string
+}
+
+// Agent returns the agent with the given name.
+func (d *Definitions) Agent(name string) (*agents.Agent, bool) {
+	a, ok := d.agents[name]
+	return a, ok
+}
+
+// Names returns the names of all agents, in definition order.
+func (d *Definitions) Names() []string {
+	return slices.Clone(d.names)
+}
+
+// LoadFile reads and loads a definitions file. The format is inferred from
+// the file extension.
+func (l Loader) LoadFile(path string) (*Definitions, error) {
+	format, err := FormatFromPath(path)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return l.Load(data, format)
+}
+
+// Load parses and builds serialized definitions.
+func (l Loader) Load(data []byte, format Format) (*Definitions, error) {
+	cfg, err := Parse(data, format)
+	if err != nil {
+		return nil, err
+	}
+	return l.Build(cfg)
+}
+
+// Parse decodes serialized definitions, without building the agents.
+// Unknown fields are reported as errors.
+func Parse(data []byte, format Format) (*Config, error) {
+	switch format {
+	case FormatJSON:
+	case FormatYAML:
+		// YAML is converted to JSON, so that the same decoding rules apply
+		// to both formats (including the JSON unmarshalers of the model
+		// settings).
+		var v any
+		if err := yaml.Unmarshal(data, &v); err != nil {
+			return nil, fmt.Errorf("%w: failed to parse YAML: %w", ErrInvalidDefinition, err)
+		}
+		var err error
+		if data, err = json.Marshal(v); err != nil {
+			return nil, fmt.Errorf("%w: failed to convert YAML: %w", ErrInvalidDefinition, err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported definitions format %q", format)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+
+	var cfg Config
+	if err := dec.Decode(&cfg); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidDefinition, err)
+	}
+	return &cfg, nil
+}
+
+// Build creates the agents described by the configuration.
+//
+// All problems are collected and returned together, joined with errors.Join.
+func (l Loader) Build(cfg *Config) (*Definitions, error) {
+	defs := &Definitions{
+		agents: make(map[string]*agents.Agent, len(cfg.Agents)),
+		names:  make([]string, 0, len(cfg.Agents)),
+	}
+
+	var errs []error
+	for i, ac := range cfg.Agents {
+		switch {
+		case ac.Name == "":
+			errs = append(errs, fmt.Errorf("%w: agents[%d]: missing name", ErrInvalidDefinition, i))
+		case defs.agents[ac.Name] != nil:
+			errs = append(errs, fmt.Errorf("%w: duplicate agent %q", ErrInvalidDefinition, ac.Name))
+		default:
+			defs.agents[ac.Name] = agents.New(ac.Name)
+			defs.names = append(defs.names, ac.Name)
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	vars := maps.Clone(cfg.Vars)
+	if vars == nil {
+		vars = make(map[string]any, len(l.Vars))
+	}
+	maps.Copy(vars, l.Vars)
+
+	for _, ac := range cfg.Agents {
+		errs = append(errs, l.buildAgent(defs, ac, vars)...)
+	}
+	if !l.AllowHandoffCycles {
+		errs = append(errs, findHandoffCycles(cfg)...)
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return defs, nil
+}
+
+func (l Loader) buildAgent(defs *Definitions, ac AgentConfig, vars map[string]any) []error {
+	agent := defs.agents[ac.Name]
+
+	var errs []error
+	addErr := func(format string, a ...any) {
+		errs = append(errs, fmt.Errorf("agent %q: "+format, append([]any{ac.Name}, a...)...))
+	}
+
+	if ac.Instructions != "" {
+		instructions, err := renderInstructions(ac.Name, ac.Instructions, vars)
+		if err != nil {
+			addErr("%w: instructions: %w", ErrInvalidDefinition, err)
+		}
+		agent.Instructions = agents.InstructionsStr(instructions)
+	}
+
+	agent.HandoffDescription = ac.HandoffDescription
+	if ac.Model != "" {
+		agent.Model = param.NewOpt(agents.NewAgentModelName(ac.Model))
+	}
+	agent.ModelSettings = ac.ModelSettings
+
+	for _, name := range ac.Handoffs {
+		target, ok := defs.agents[name]
+		if !ok {
+			addErr("%w: handoff to agent %q", ErrUnknownReference, name)
+			continue
+		}
+		agent.AgentHandoffs = append(agent.AgentHandoffs, target)
+	}
+
+	for i, tc := range ac.Tools {
+		tool, err := l.buildTool(tc)
+		if err != nil {
+			addErr("tools[%d]: %w", i, err)
+			continue
+		}
+		agent.Tools = append(agent.Tools, tool)
+	}
+
+	for _, name := range ac.InputGuardrails {
+		g, ok := l.Registry.inputGuardrail(name)
+		if !ok {
+			addErr("%w: input guardrail %q", ErrUnknownReference, name)
+			continue
+		}
+		agent.InputGuardrails = append(agent.InputGuardrails, g)
+	}
+
+	for _, name := range ac.OutputGuardrails {
+		g, ok := l.Registry.outputGuardrail(name)
+		if !ok {
+			addErr("%w: output guardrail %q", ErrUnknownReference, name)
+			continue
+		}
+		agent.OutputGuardrails = append(agent.OutputGuardrails, g)
+	}
+
+	if ac.OutputSchema != nil {
+		name := ac.OutputSchema.Name
+		if name == "" {
+			name = "final_output"
+		}
+		strict := ac.OutputSchema.Strict == nil || *ac.OutputSchema.Strict
+		schema, err := NewJSONSchemaOutput(name, ac.OutputSchema.Schema, strict)
+		if err != nil {
+			addErr("%w: %w", ErrInvalidSchema, err)
+		} else {
+			agent.OutputSchema = schema
+		}
+	}
+
+	return errs
+}
+
+func (l Loader) buildTool(tc ToolConfig) (agents.Tool, error) {
+	switch tc.Type {
+	case "function":
+		tool, ok := l.Registry.tool(tc.Name)
+		if !ok {
+			return nil, fmt.Errorf("%w: tool %q", ErrUnknownReference, tc.Name)
+		}
+		return tool, nil
+	case "web_search":
+		return agents.WebSearchTool{
+			SearchContextSize: responses.WebSearchToolSearchContextSize(tc.SearchContextSize),
+		}, nil
+	case "file_search":
+		if len(tc.VectorStoreIDs) == 0 {
+			return nil, fmt.Errorf("%w: file_search requires vector_store_ids", ErrInvalidDefinition)
+		}
+		tool := agents.FileSearchTool{
+			VectorStoreIDs:       tc.VectorStoreIDs,
+			IncludeSearchResults: tc.IncludeSearchResults,
+		}
+		if tc.MaxNumResults > 0 {
+			tool.MaxNumResults = param.NewOpt(tc.MaxNumResults)
+		}
+		return tool, nil
+	case "code_interpreter":
+		var config responses.ToolCodeInterpreterParam
+		if len(tc.Config) > 0 {
+			if err := json.Unmarshal(tc.Config, &config); err != nil {
+				return nil, fmt.Errorf("%w: code_interpreter config: %w", ErrInvalidDefinition, err)
+			}
+		} else {
+			config.Container.OfCodeInterpreterContainerAuto = &responses.ToolCodeInterpreterContainerCodeInterpreterContainerAutoParam{
+				Type: constant.ValueOf[constant.Auto](),
+			}
+		}
+		return agents.CodeInterpreterTool{ToolConfig: config}, nil
+	case "image_generation":
+		var config responses.ToolImageGenerationParam
+		if len(tc.Config) > 0 {
+			if err := json.Unmarshal(tc.Config, &config); err != nil {
+				return nil, fmt.Errorf("%w: image_generation config: %w", ErrInvalidDefinition, err)
+			}
+		}
+		return agents.ImageGenerationTool{ToolConfig: config}, nil
+	default:
+		return nil, fmt.Errorf("%w: unknown tool type %q", ErrInvalidDefinition, tc.Type)
+	}
+}
+
+func renderInstructions(name, text string, vars map[string]any) (string, error) {
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", err
+	}
+	var sb strings.Builder
+	if err = tmpl.Execute(&sb, vars); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+// findHandoffCycles reports each distinct handoff cycle once, following the
+// definition order.
+func findHandoffCycles(cfg *Config) []error {
+	handoffs := make(map[string][]string, len(cfg.Agents))
+	for _, ac := range cfg.Agents {
+		handoffs[ac.Name] = ac.Handoffs
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[string]int, len(cfg.Agents))
+
+	var (
+		errs  []error
+		stack []string
+		visit func(name string)
+	)
+	visit = func(name string) {
+		state[name] = visiting
+		stack = append(stack, name)
+		for _, next := range handoffs[name] {
+			switch state[next] {
+			case unvisited:
+				if _, ok := handoffs[next]; ok {
+					visit(next)
+				}
+			case visiting:
+				start := slices.Index(stack, next)
+				path := append(slices.Clone(stack[start:]), next)
+				errs = append(errs, fmt.Errorf("%w: %s", ErrHandoffCycle, strings.Join(path, " -> ")))
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[name] = done
+	}
+
+	for _, ac := range cfg.Agents {
+		if state[ac.Name] == unvisited {
+			visit(ac.Name)
+		}
+	}
+	return errs
+}
@@ -0,0 +1,81 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agentconfig
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// JSONSchemaOutput is an agents.AgentOutputSchemaInterface defined by an
+// inline JSON Schema. Validated outputs are returned as generic JSON values
+// (map[string]any, []any, string, float64, bool or nil).
+type JSONSchemaOutput struct {
+	name   string
+	schema map[string]any
+	strict bool
+	loaded *gojsonschema.Schema
+}
+
+var _ agents.AgentOutputSchemaInterface = (*JSONSchemaOutput)(nil)
+
+// NewJSONSchemaOutput compiles the given JSON Schema, returning an error if
+// it is not valid.
+func NewJSONSchemaOutput(name string, schema map[string]any, strict bool) (*JSONSchemaOutput, error) {
+	if len(schema) == 0 {
+		return nil, errors.New("empty JSON schema")
+	}
+	loaded, err := gojsonschema.NewSchema(gojsonschema.NewGoLoader(schema))
+	if err != nil {
+		return nil, err
+	}
+	return &JSONSchemaOutput{
+		name:   name,
+		schema: schema,
+		strict: strict,
+		loaded: loaded,
+	}, nil
+}
+
+func (o *JSONSchemaOutput) IsPlainText() bool          { return false }
+func (o *JSONSchemaOutput) Name() string               { return o.name }
+func (o *JSONSchemaOutput) JSONSchema() map[string]any { return o.schema }
+func (o *JSONSchemaOutput) IsStrictJSONSchema() bool   { return o.strict }
+
+func (o *JSONSchemaOutput) ValidateJSON(jsonStr string) (any, error) {
+	result, err := o.loaded.Validate(gojsonschema.NewStringLoader(jsonStr))
+	if err != nil {
+		return nil, agents.ModelBehaviorErrorf("invalid JSON output: %w", err)
+	}
+	if !result.Valid() {
+		var sb strings.Builder
+		sb.WriteString("JSON output validation failed with the following errors:\n")
+		for _, e := range result.Errors() {
+			_, _ = fmt.Fprintf(&sb, "- %s\n", e)
+		}
+		return nil, agents.NewModelBehaviorError(sb.String())
+	}
+
+	var v any
+	if err = json.Unmarshal([]byte(jsonStr), &v); err != nil {
+		return nil, agents.ModelBehaviorErrorf("invalid JSON output: %w", err)
+	}
+	return v, nil
+}
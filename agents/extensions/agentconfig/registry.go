@@ -0,0 +1,101 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agentconfig
+
+import (
+	"sync"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+)
+
+// Registry holds the Go-implemented components which can be referenced by
+// name from declarative definitions.
+//
+// It is safe for concurrent use.
+type Registry struct {
+	mu               sync.RWMutex
+	tools            map[string]agents.Tool
+	inputGuardrails  map[string]agents.InputGuardrail
+	outputGuardrails map[string]agents.OutputGuardrail
+}
+
+// NewRegistry creates a new empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		tools:            make(map[string]agents.Tool),
+		inputGuardrails:  make(map[string]agents.InputGuardrail),
+		outputGuardrails: make(map[string]agents.OutputGuardrail),
+	}
+}
+
+// RegisterTool registers a tool under its own name (see agents.Tool.ToolName).
+func (r *Registry) RegisterTool(tools ...agents.Tool) *Registry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, tool := range tools {
+		r.tools[tool.ToolName()] = tool
+	}
+	return r
+}
+
+// RegisterInputGuardrail registers input guardrails under their own names.
+func (r *Registry) RegisterInputGuardrail(guardrails ...agents.InputGuardrail) *Registry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, g := range guardrails {
+		r.inputGuardrails[g.Name] = g
+	}
+	return r
+}
+
+// RegisterOutputGuardrail registers output guardrails under their own names.
+func (r *Registry) RegisterOutputGuardrail(guardrails ...agents.OutputGuardrail) *Registry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, g := range guardrails {
+		r.outputGuardrails[g.Name] = g
+	}
+	return r
+}
+
+func (r *Registry) tool(name string) (agents.Tool, bool) {
+	if r == nil {
+		return nil, false
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	v, ok := r.tools[name]
+	return v, ok
+}
+
+func (r *Registry) inputGuardrail(name string) (agents.InputGuardrail, bool) {
+	if r == nil {
+		return agents.InputGuardrail{}, false
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	v, ok := r.inputGuardrails[name]
+	return v, ok
+}
+
+func (r *Registry) outputGuardrail(name string) (agents.OutputGuardrail, bool) {
+	if r == nil {
+		return agents.OutputGuardrail{}, false
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	v, ok := r.outputGuardrails[name]
+	return v, ok
+}
@@ -0,0 +1,162 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agentconfig
+
+import (
+	"context"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultWatchInterval is the default polling interval of a Watcher.
+const DefaultWatchInterval = 2 * time.Second
+
+// Watcher keeps definitions loaded from a file up to date, reloading them
+// whenever the file changes.
+//
+// If a reload fails, the previously loaded definitions are kept, so that a
+// broken edit never takes down running agents.
+type Watcher struct {
+	loader   Loader
+	path     string
+	interval time.Duration
+	onReload func(*Definitions, error)
+
+	current atomic.Pointer[Definitions]
+
+	mu      sync.Mutex
+	modTime time.Time
+	size    int64
+
+	done chan struct{}
+}
+
+// WatchParams configures a Watcher.
+type WatchParams struct {
+	// Polling interval. Default: DefaultWatchInterval.
+	Interval time.Duration
+
+	// Optional function called after each reload attempt triggered by a
+	// file change. On failure, it receives nil definitions and the error.
+	OnReload func(*Definitions, error)
+}
+
+// Watch loads the definitions file and starts watching it for changes,
+// until the context is canceled.
+//
+// An error is returned if the initial load fails.
+func (l Loader) Watch(ctx context.Context, path string, params WatchParams) (*Watcher, error) {
+	w := &Watcher{
+		loader:   l,
+		path:     path,
+		interval: params.Interval,
+		onReload: params.OnReload,
+		done:     make(chan struct{}),
+	}
+	if w.interval <= 0 {
+		w.interval = DefaultWatchInterval
+	}
+
+	if err := w.Reload(); err != nil {
+		return nil, err
+	}
+
+	go w.watch(ctx)
+	return w, nil
+}
+
+// Definitions returns the most recently loaded definitions.
+func (w *Watcher) Definitions() *Definitions {
+	return w.current.Load()
+}
+
+// Reload unconditionally reloads the definitions file.
+func (w *Watcher) Reload() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	info, err := os.Stat(w.path)
+	if err != nil {
+		return err
+	}
+	defs, err := w.loader.LoadFile(w.path)
+	if err != nil {
+		return err
+	}
+
+	w.modTime = info.ModTime()
+	w.size = info.Size()
+	w.current.Store(defs)
+	return nil
+}
+
+// Done returns a channel which is closed when the Watcher stops.
+func (w *Watcher) Done() <-chan struct{} {
+	return w.done
+}
+
+func (w *Watcher) watch(ctx context.Context) {
+	defer close(w.done)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			info, changed := w.changed()
+			if !changed {
+				continue
+			}
+			err := w.Reload()
+			if err != nil {
+				// Avoid retrying continuously the same broken file. The file
+				// info is the one observed before loading: if the file was
+				// still being written, its final version is reloaded later.
+				w.markSeen(info)
+			}
+			if w.onReload != nil {
+				if err != nil {
+					w.onReload(nil, err)
+				} else {
+					w.onReload(w.Definitions(), nil)
+				}
+			}
+		}
+	}
+}
+
+// changed reports whether the file differs from the last loaded or seen
+// version, returning its current info.
+func (w *Watcher) changed() (os.FileInfo, bool) {
+	info, err := os.Stat(w.path)
+	if err != nil {
+		return nil, false
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return info, !info.ModTime().Equal(w.modTime) || info.Size() != w.size
+}
+
+func (w *Watcher) markSeen(info os.FileInfo) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.modTime = info.ModTime()
+	w.size = info.Size()
+}
@@ -0,0 +1,236 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package context_managers provides built-in agents.ContextManager
+// strategies to keep long conversations within the model's context window.
+//
+// All strategies keep tool calls and their outputs together: a function
+// call is never sent to the model without its output, and vice versa.
+// System and developer messages are never removed.
+package context_managers
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/tokens"
+	"github.com/openai/openai-go/responses"
+)
+
+// Chain returns a ContextManager which applies the given managers in order,
+// each one receiving the output of the previous one.
+func Chain(managers ...agents.ContextManager) agents.ContextManager {
+	return agents.ContextManagerFunc(func(ctx context.Context, params agents.ContextManagerParams) ([]agents.TResponseInputItem, error) {
+		for _, m := range managers {
+			input, err := m.ManageContext(ctx, params)
+			if err != nil {
+				return nil, err
+			}
+			params.Input = input
+		}
+		return params.Input, nil
+	})
+}
+
+// TokenCounter returns the (estimated) number of tokens of an input item.
+type TokenCounter func(agents.TResponseInputItem) int
+
+// EstimateTokens is a TokenCounter using a tokens.Estimator backed by the
+// tokens.Heuristic counter.
+//
+// For exact counts, use the CountInputItem method of a tokens.Estimator
+// backed by a tokens.Encoding.
+func EstimateTokens(item agents.TResponseInputItem) int {
+	return tokens.NewEstimator(tokens.Heuristic{}).CountInputItem(item)
+}
+
+func countTokens(counter TokenCounter, items []agents.TResponseInputItem) int {
+	if counter == nil {
+		counter = EstimateTokens
+	}
+	total := 0
+	for _, item := range items {
+		total += counter(item)
+	}
+	return total
+}
+
+// callKey returns a key identifying the tool call an item belongs to, for
+// tool calls and tool call outputs which must be kept together.
+func callKey(item agents.TResponseInputItem) (string, bool) {
+	switch {
+	case item.OfFunctionCall != nil:
+		return "function:" + item.OfFunctionCall.CallID, true
+	case item.OfFunctionCallOutput != nil:
+		return "function:" + item.OfFunctionCallOutput.CallID, true
+	case item.OfComputerCall != nil:
+		return "computer:" + item.OfComputerCall.CallID, true
+	case item.OfComputerCallOutput != nil:
+		return "computer:" + item.OfComputerCallOutput.CallID, true
+	case item.OfLocalShellCall != nil:
+		return "local_shell:" + item.OfLocalShellCall.CallID, true
+	case item.OfLocalShellCallOutput != nil:
+		return "local_shell:" + item.OfLocalShellCallOutput.ID, true
+	default:
+		return "", false
+	}
+}
+
+func isToolOutput(item agents.TResponseInputItem) bool {
+	return item.OfFunctionCallOutput != nil ||
+		item.OfComputerCallOutput != nil ||
+		item.OfLocalShellCallOutput != nil
+}
+
+func messageRole(item agents.TResponseInputItem) string {
+	switch {
+	case item.OfMessage != nil:
+		return string(item.OfMessage.Role)
+	case item.OfInputMessage != nil:
+		return item.OfInputMessage.Role
+	case item.OfOutputMessage != nil:
+		return "assistant"
+	default:
+		return ""
+	}
+}
+
+func isSystemMessage(item agents.TResponseInputItem) bool {
+	role := messageRole(item)
+	return role == "system" || role == "developer"
+}
+
+func isUserMessage(item agents.TResponseInputItem) bool {
+	return messageRole(item) == "user"
+}
+
+// userMessageIndices returns the indices of the user messages, each one
+// starting a new conversation turn.
+func userMessageIndices(items []agents.TResponseInputItem) []int {
+	var indices []int
+	for i, item := range items {
+		if isUserMessage(item) {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
+
+// filterKept returns the kept items, first extending the removal to all
+// items of partially removed tool calls, so that calls and outputs are
+// always kept together.
+func filterKept(items []agents.TResponseInputItem, keep []bool) []agents.TResponseInputItem {
+	removedCalls := make(map[string]bool)
+	for i, item := range items {
+		if key, ok := callKey(item); ok && !keep[i] {
+			removedCalls[key] = true
+		}
+	}
+
+	result := make([]agents.TResponseInputItem, 0, len(items))
+	for i, item := range items {
+		if !keep[i] {
+			continue
+		}
+		if key, ok := callKey(item); ok && removedCalls[key] {
+			continue
+		}
+		result = append(result, item)
+	}
+	return result
+}
+
+// pairSafeCut moves a cut index backwards until no tool call is split
+// between items[:cut] and items[cut:].
+func pairSafeCut(items []agents.TResponseInputItem, cut int) int {
+	for {
+		firstIndex := make(map[string]int)
+		for i, item := range items[:cut] {
+			if key, ok := callKey(item); ok {
+				if _, seen := firstIndex[key]; !seen {
+					firstIndex[key] = i
+				}
+			}
+		}
+		newCut := cut
+		for _, item := range items[cut:] {
+			if key, ok := callKey(item); ok {
+				if i, found := firstIndex[key]; found && i < newCut {
+					newCut = i
+				}
+			}
+		}
+		if newCut == cut {
+			return cut
+		}
+		cut = newCut
+	}
+}
+
+// itemText renders an item as a line of a plain-text transcript.
+func itemText(item agents.TResponseInputItem) string {
+	switch {
+	case item.OfMessage != nil:
+		content := item.OfMessage.Content
+		if content.OfString.Valid() {
+			return string(item.OfMessage.Role) + ": " + content.OfString.Value
+		}
+		return string(item.OfMessage.Role) + ": " + inputContentText(content.OfInputItemContentList)
+	case item.OfInputMessage != nil:
+		return item.OfInputMessage.Role + ": " + inputContentText(item.OfInputMessage.Content)
+	case item.OfOutputMessage != nil:
+		text := ""
+		for _, c := range item.OfOutputMessage.Content {
+			switch {
+			case c.OfOutputText != nil:
+				text += c.OfOutputText.Text
+			case c.OfRefusal != nil:
+				text += c.OfRefusal.Refusal
+			}
+		}
+		return "assistant: " + text
+	case item.OfFunctionCall != nil:
+		return "tool call: " + item.OfFunctionCall.Name + "(" + item.OfFunctionCall.Arguments + ")"
+	case item.OfFunctionCallOutput != nil:
+		return "tool result: " + item.OfFunctionCallOutput.Output
+	case item.OfLocalShellCallOutput != nil:
+		return "shell result: " + item.OfLocalShellCallOutput.Output
+	default:
+		b, err := json.Marshal(item)
+		if err != nil {
+			return ""
+		}
+		var v struct {
+			Type string `json:"type"`
+		}
+		_ = json.Unmarshal(b, &v)
+		return "[" + v.Type + "]"
+	}
+}
+
+func inputContentText(content responses.ResponseInputMessageContentListParam) string {
+	text := ""
+	for _, c := range content {
+		switch {
+		case c.OfInputText != nil:
+			text += c.OfInputText.Text
+		case c.OfInputImage != nil:
+			text += "[image]"
+		case c.OfInputFile != nil:
+			text += "[file]"
+		}
+	}
+	return text
+}
@@ -0,0 +1,237 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package context_managers_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/agents/extensions/context_managers"
+	"github.com/nlpodyssey/openai-agents-go/agentstesting"
+	"github.com/openai/openai-go/packages/param"
+	"github.com/openai/openai-go/responses"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func message(role responses.EasyInputMessageRole, content string) agents.TResponseInputItem {
+	return agents.TResponseInputItem{
+		OfMessage: &responses.EasyInputMessageParam{
+			Content: responses.EasyInputMessageContentUnionParam{OfString: param.NewOpt(content)},
+			Role:    role,
+			Type:    responses.EasyInputMessageTypeMessage,
+		},
+	}
+}
+
+func call(id string) agents.TResponseInputItem {
+	return agents.TResponseInputItem{
+		OfFunctionCall: &responses.ResponseFunctionToolCallParam{CallID: id, Name: "tool", Arguments: "{}"},
+	}
+}
+
+func output(id, content string) agents.TResponseInputItem {
+	return agents.TResponseInputItem{
+		OfFunctionCallOutput: &responses.ResponseInputItemFunctionCallOutputParam{CallID: id, Output: content},
+	}
+}
+
+// describe renders items compactly, to make assertions readable.
+func describe(items []agents.TResponseInputItem) []string {
+	result := make([]string, len(items))
+	for i, item := range items {
+		switch {
+		case item.OfMessage != nil:
+			result[i] = string(item.OfMessage.Role) + ":" + item.OfMessage.Content.OfString.Value
+		case item.OfFunctionCall != nil:
+			result[i] = "call:" + item.OfFunctionCall.CallID
+		case item.OfFunctionCallOutput != nil:
+			result[i] = "output:" + item.OfFunctionCallOutput.CallID + "=" + item.OfFunctionCallOutput.Output
+		}
+	}
+	return result
+}
+
+func history() []agents.TResponseInputItem {
+	return []agents.TResponseInputItem{
+		message(responses.EasyInputMessageRoleSystem, "rules"),
+		message(responses.EasyInputMessageRoleUser, "q1"),
+		call("c1"),
+		output("c1", strings.Repeat("x", 400)),
+		message(responses.EasyInputMessageRoleAssistant, "a1"),
+		message(responses.EasyInputMessageRoleUser, "q2"),
+		call("c2"),
+		message(responses.EasyInputMessageRoleUser, "q3"),
+		output("c2", "r2"),
+		message(responses.EasyInputMessageRoleAssistant, "a3"),
+	}
+}
+
+func manage(t *testing.T, m agents.ContextManager, items []agents.TResponseInputItem) []string {
+	t.Helper()
+	result, err := m.ManageContext(t.Context(), agents.ContextManagerParams{Input: items})
+	require.NoError(t, err)
+	return describe(result)
+}
+
+func TestKeepLastTurns(t *testing.T) {
+	assert.Equal(t, []string{
+		"system:rules",
+		"call:c2",
+		"user:q3",
+		"output:c2=r2",
+		"assistant:a3",
+	}, manage(t, context_managers.KeepLastTurns{N: 1}, history()), "the call of the last output is kept")
+
+	assert.Equal(t, describe(history()), manage(t, context_managers.KeepLastTurns{N: 5}, history()))
+}
+
+func TestDropOldToolOutputs(t *testing.T) {
+	ph := context_managers.DefaultToolOutputPlaceholder
+	assert.Equal(t, []string{
+		"system:rules",
+		"user:q1",
+		"call:c1",
+		"output:c1=" + ph,
+		"assistant:a1",
+		"user:q2",
+		"call:c2",
+		"user:q3",
+		"output:c2=r2",
+		"assistant:a3",
+	}, manage(t, context_managers.DropOldToolOutputs{KeepLast: 1}, history()))
+
+	items := history()
+	_ = manage(t, context_managers.DropOldToolOutputs{}, items)
+	assert.Equal(t, describe(history()), describe(items), "input items are not modified")
+}
+
+func TestTokenBudget(t *testing.T) {
+	items := history()
+	total := 0
+	for _, item := range items {
+		total += context_managers.EstimateTokens(item)
+	}
+
+	// Just enough to drop the big tool output, which is removed with its call.
+	budget := total - context_managers.EstimateTokens(items[3])
+	assert.Equal(t, []string{
+		"system:rules",
+		"assistant:a1",
+		"user:q2",
+		"call:c2",
+		"user:q3",
+		"output:c2=r2",
+		"assistant:a3",
+	}, manage(t, context_managers.TokenBudget{MaxTokens: budget}, items))
+
+	// The current turn is never removed.
+	assert.Equal(t, []string{
+		"system:rules",
+		"call:c2",
+		"user:q3",
+		"output:c2=r2",
+		"assistant:a3",
+	}, manage(t, context_managers.TokenBudget{MaxTokens: 1}, items))
+}
+
+func TestTokenBudgetCountsInstructionsWithCounter(t *testing.T) {
+	// Each item, including the system instructions, counts as one token.
+	budget := context_managers.TokenBudget{
+		MaxTokens: 10,
+		Counter:   func(agents.TResponseInputItem) int { return 1 },
+	}
+	items := history()
+	require.Len(t, items, 10)
+
+	result, err := budget.ManageContext(t.Context(), agents.ContextManagerParams{Input: items})
+	require.NoError(t, err)
+	assert.Equal(t, describe(items), describe(result))
+
+	result, err = budget.ManageContext(t.Context(), agents.ContextManagerParams{
+		Input:              items,
+		SystemInstructions: param.NewOpt(strings.Repeat("long instructions ", 100)),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, describe(slices.Concat(items[:1], items[2:])), describe(result),
+		"the instructions count as one more item, so the oldest one is dropped")
+}
+
+func TestSummarizer(t *testing.T) {
+	model := agentstesting.NewFakeModel(&agentstesting.FakeModelTurnOutput{
+		Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("user asked q1")},
+	})
+	summarizer := context_managers.NewSummarizer(context_managers.SummarizerParams{
+		Model:         model,
+		KeepLastTurns: 1,
+	})
+
+	expected := []string{
+		"system:rules",
+		"developer:" + context_managers.SummaryPrefix + "user asked q1",
+		"call:c2",
+		"user:q3",
+		"output:c2=r2",
+		"assistant:a3",
+	}
+	assert.Equal(t, expected, manage(t, summarizer, history()))
+
+	transcript := model.LastTurnArgs.Input.(agents.InputString)
+	assert.Contains(t, transcript, "user: q1\ntool call: tool({})\ntool result: xxx")
+	assert.Equal(t, context_managers.DefaultSummaryInstructions, model.LastTurnArgs.SystemInstructions.Value)
+
+	// The summary is cached: the fake model has no more outputs.
+	model.SetNextOutput(agentstesting.FakeModelTurnOutput{Error: assert.AnError})
+	assert.Equal(t, expected, manage(t, summarizer, history()))
+}
+
+func TestContextManagerInRun(t *testing.T) {
+	model := agentstesting.NewFakeModel(nil)
+	agent := agents.New("test").
+		WithModelInstance(model).
+		WithTools(agentstesting.GetFunctionTool("tool", strings.Repeat("y", 100)))
+
+	model.AddMultipleTurnOutputs([]agentstesting.FakeModelTurnOutput{
+		{Value: []agents.TResponseOutputItem{agentstesting.GetFunctionToolCall("tool", "{}")}},
+		{Value: []agents.TResponseOutputItem{agentstesting.GetFunctionToolCall("tool", "{}")}},
+		{Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("done")}},
+	})
+
+	runner := agents.Runner{Config: agents.RunConfig{
+		ContextManager: context_managers.Chain(
+			context_managers.DropOldToolOutputs{KeepLast: 1, Placeholder: "-"},
+		),
+	}}
+	result, err := runner.Run(t.Context(), agent, "hi")
+	require.NoError(t, err)
+	assert.Equal(t, "done", result.FinalOutput)
+
+	input := model.LastTurnArgs.Input.(agents.InputItems)
+	require.Len(t, input, 5)
+	assert.Equal(t, "-", input[2].OfFunctionCallOutput.Output)
+	assert.Equal(t, strings.Repeat("y", 100), input[4].OfFunctionCallOutput.Output)
+
+	// The run items are not affected.
+	outputs := 0
+	for _, item := range result.NewItems {
+		if o, ok := item.(agents.ToolCallOutputItem); ok {
+			assert.Equal(t, strings.Repeat("y", 100), o.Output)
+			outputs++
+		}
+	}
+	assert.Equal(t, 2, outputs)
+}
@@ -0,0 +1,177 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package context_managers
+
+import (
+	"context"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/openai/openai-go/responses"
+)
+
+// KeepLastTurns keeps only the last N conversation turns, where a turn
+// starts with a user message and includes everything that follows it.
+type KeepLastTurns struct {
+	// The number of turns to keep. It must be greater than zero.
+	N int
+}
+
+func (k KeepLastTurns) ManageContext(_ context.Context, params agents.ContextManagerParams) ([]agents.TResponseInputItem, error) {
+	items := params.Input
+	userIndices := userMessageIndices(items)
+	if k.N <= 0 || len(userIndices) <= k.N {
+		return items, nil
+	}
+
+	cut := pairSafeCut(items, userIndices[len(userIndices)-k.N])
+	keep := make([]bool, len(items))
+	for i, item := range items {
+		keep[i] = i >= cut || isSystemMessage(item)
+	}
+	return filterKept(items, keep), nil
+}
+
+// DefaultToolOutputPlaceholder is the default replacement text of the
+// outputs removed by DropOldToolOutputs.
+const DefaultToolOutputPlaceholder = "[tool output removed to save context]"
+
+// DropOldToolOutputs replaces the content of old function and shell call
+// outputs with a short placeholder, keeping the calls themselves.
+// Old computer calls are removed together with their outputs, since
+// screenshots cannot be replaced with text.
+type DropOldToolOutputs struct {
+	// The number of most recent tool outputs which are left untouched.
+	KeepLast int
+
+	// Optional replacement text. Default: DefaultToolOutputPlaceholder.
+	Placeholder string
+}
+
+func (d DropOldToolOutputs) ManageContext(_ context.Context, params agents.ContextManagerParams) ([]agents.TResponseInputItem, error) {
+	items := params.Input
+
+	placeholder := d.Placeholder
+	if placeholder == "" {
+		placeholder = DefaultToolOutputPlaceholder
+	}
+
+	outputsToKeep := max(d.KeepLast, 0)
+	lastOld := -1
+	for i := len(items) - 1; i >= 0; i-- {
+		if !isToolOutput(items[i]) {
+			continue
+		}
+		if outputsToKeep == 0 {
+			lastOld = i
+			break
+		}
+		outputsToKeep--
+	}
+	if lastOld < 0 {
+		return items, nil
+	}
+
+	result := make([]agents.TResponseInputItem, len(items))
+	keep := make([]bool, len(items))
+	for i, item := range items {
+		result[i] = item
+		keep[i] = true
+		if i > lastOld {
+			continue
+		}
+		switch {
+		case item.OfFunctionCallOutput != nil:
+			output := *item.OfFunctionCallOutput
+			output.Output = placeholder
+			result[i] = agents.TResponseInputItem{OfFunctionCallOutput: &output}
+		case item.OfLocalShellCallOutput != nil:
+			output := *item.OfLocalShellCallOutput
+			output.Output = placeholder
+			result[i] = agents.TResponseInputItem{OfLocalShellCallOutput: &output}
+		case item.OfComputerCallOutput != nil:
+			keep[i] = false
+		}
+	}
+	return filterKept(result, keep), nil
+}
+
+// TokenBudget removes the oldest items until the estimated size of the
+// conversation fits within the budget.
+//
+// The current turn (the last user message and everything after it) is never
+// removed: if it does not fit within the budget on its own, the best effort
+// result is returned.
+type TokenBudget struct {
+	// The maximum number of tokens of the model input, including the
+	// system instructions.
+	MaxTokens int
+
+	// Optional token counter. Default: EstimateTokens.
+	Counter TokenCounter
+}
+
+func (b TokenBudget) ManageContext(_ context.Context, params agents.ContextManagerParams) ([]agents.TResponseInputItem, error) {
+	items := params.Input
+	counter := b.Counter
+	if counter == nil {
+		counter = EstimateTokens
+	}
+
+	total := countTokens(counter, items)
+	if params.SystemInstructions.Valid() {
+		total += counter(agents.TResponseInputItem{
+			OfMessage: &responses.EasyInputMessageParam{
+				Role:    responses.EasyInputMessageRoleSystem,
+				Content: responses.EasyInputMessageContentUnionParam{OfString: params.SystemInstructions},
+			},
+		})
+	}
+	if b.MaxTokens <= 0 || total <= b.MaxTokens {
+		return items, nil
+	}
+
+	protectedFrom := len(items)
+	if userIndices := userMessageIndices(items); len(userIndices) > 0 {
+		protectedFrom = pairSafeCut(items, userIndices[len(userIndices)-1])
+	}
+
+	partners := make(map[string][]int)
+	for i, item := range items {
+		if key, ok := callKey(item); ok {
+			partners[key] = append(partners[key], i)
+		}
+	}
+
+	keep := make([]bool, len(items))
+	for i := range keep {
+		keep[i] = true
+	}
+	for i := 0; i < protectedFrom && total > b.MaxTokens; i++ {
+		if !keep[i] || isSystemMessage(items[i]) {
+			continue
+		}
+		group := []int{i}
+		if key, ok := callKey(items[i]); ok {
+			group = partners[key]
+		}
+		for _, j := range group {
+			if keep[j] {
+				keep[j] = false
+				total -= counter(items[j])
+			}
+		}
+	}
+	return filterKept(items, keep), nil
+}
@@ -0,0 +1,180 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package context_managers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/modelsettings"
+	"github.com/openai/openai-go/packages/param"
+	"github.com/openai/openai-go/responses"
+)
+
+// DefaultSummaryInstructions are the default system instructions of the
+// summarization model call.
+const DefaultSummaryInstructions = "You compress conversations. " +
+	"Summarize the conversation transcript provided by the user, preserving " +
+	"facts, decisions, open questions, user preferences and the results of " +
+	"tool calls which may still be relevant. Reply with the summary only."
+
+// SummaryPrefix is prepended to the summary message which replaces the
+// older history.
+const SummaryPrefix = "Summary of the earlier conversation:\n"
+
+// SummarizerParams configures a Summarizer.
+type SummarizerParams struct {
+	// The model used to summarize the older history. Required.
+	Model agents.Model
+
+	// Optional settings for the summarization model call.
+	ModelSettings modelsettings.ModelSettings
+
+	// Optional system instructions for the summarization model call.
+	// Default: DefaultSummaryInstructions.
+	Instructions string
+
+	// The number of most recent turns which are kept verbatim.
+	// Default (when zero): 2.
+	KeepLastTurns int
+
+	// Optional minimum size, in tokens, of the conversation for the
+	// summarization to take place. Default (when zero): the older history is
+	// summarized as soon as it exists.
+	TriggerTokens int
+
+	// Optional token counter used for TriggerTokens. Default: EstimateTokens.
+	Counter TokenCounter
+}
+
+// Summarizer replaces the older conversation history with a summary
+// generated by a model call, keeping the most recent turns verbatim.
+//
+// Since a ContextManager always receives the full history, the last summary
+// is cached and reused as long as the summarized history does not change.
+type Summarizer struct {
+	params SummarizerParams
+
+	mu          sync.Mutex
+	cacheKey    [sha256.Size]byte
+	cachedValue string
+}
+
+// NewSummarizer creates a new Summarizer.
+func NewSummarizer(params SummarizerParams) *Summarizer {
+	if params.Instructions == "" {
+		params.Instructions = DefaultSummaryInstructions
+	}
+	if params.KeepLastTurns <= 0 {
+		params.KeepLastTurns = 2
+	}
+	return &Summarizer{params: params}
+}
+
+func (s *Summarizer) ManageContext(ctx context.Context, params agents.ContextManagerParams) ([]agents.TResponseInputItem, error) {
+	items := params.Input
+	if s.params.Model == nil {
+		return nil, errors.New("summarizer model is not set")
+	}
+
+	userIndices := userMessageIndices(items)
+	if len(userIndices) <= s.params.KeepLastTurns {
+		return items, nil
+	}
+	if s.params.TriggerTokens > 0 && countTokens(s.params.Counter, items) < s.params.TriggerTokens {
+		return items, nil
+	}
+
+	cut := pairSafeCut(items, userIndices[len(userIndices)-s.params.KeepLastTurns])
+
+	var system, older []agents.TResponseInputItem
+	for _, item := range items[:cut] {
+		if isSystemMessage(item) {
+			system = append(system, item)
+		} else {
+			older = append(older, item)
+		}
+	}
+	if len(older) == 0 {
+		return items, nil
+	}
+
+	summary, err := s.summarize(ctx, older)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]agents.TResponseInputItem, 0, len(system)+1+len(items)-cut)
+	result = append(result, system...)
+	result = append(result, agents.TResponseInputItem{
+		OfMessage: &responses.EasyInputMessageParam{
+			Content: responses.EasyInputMessageContentUnionParam{OfString: param.NewOpt(SummaryPrefix + summary)},
+			Role:    responses.EasyInputMessageRoleDeveloper,
+			Type:    responses.EasyInputMessageTypeMessage,
+		},
+	})
+	result = append(result, items[cut:]...)
+	return result, nil
+}
+
+func (s *Summarizer) summarize(ctx context.Context, items []agents.TResponseInputItem) (string, error) {
+	b, err := json.Marshal(items)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal history to summarize: %w", err)
+	}
+	key := sha256.Sum256(b)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cachedValue != "" && s.cacheKey == key {
+		return s.cachedValue, nil
+	}
+
+	var transcript strings.Builder
+	for _, item := range items {
+		transcript.WriteString(itemText(item))
+		transcript.WriteByte('\n')
+	}
+
+	response, err := s.params.Model.GetResponse(ctx, agents.ModelResponseParams{
+		SystemInstructions: param.NewOpt(s.params.Instructions),
+		Input:              agents.InputString(transcript.String()),
+		ModelSettings:      s.params.ModelSettings,
+	})
+	if err != nil {
+		return "", fmt.Errorf("summarization model call failed: %w", err)
+	}
+
+	var summary string
+	for _, item := range response.Output {
+		if text, ok := agents.ItemHelpers().ExtractLastText(item); ok {
+			summary = text
+		}
+	}
+	if summary == "" {
+		return "", agents.NewModelBehaviorError("summarization model returned no text")
+	}
+
+	s.cacheKey = key
+	s.cachedValue = summary
+	return summary, nil
+}
@@ -0,0 +1,154 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package guardrails
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/invopop/jsonschema"
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/modelsettings"
+	"github.com/openai/openai-go/packages/param"
+)
+
+// ClassifierParams configures a guardrail classifying texts with a model.
+// T is the type of the classification, a struct whose JSON schema is used
+// as the structured output of the model.
+type ClassifierParams[T any] struct {
+	// The model classifying the text. Required.
+	Model agents.Model
+
+	// Optional model settings.
+	ModelSettings modelsettings.ModelSettings
+
+	// The system instructions of the model, describing the classification
+	// to perform. The text to classify is sent as the user input. Required.
+	Instructions string
+
+	// A function deciding, from the classification, whether the tripwire is
+	// triggered. Required.
+	Tripwire func(T) bool
+}
+
+// Classifier returns a Check asking the model to classify the text. The
+// OutputInfo is the classification, of type T.
+//
+// Example:
+//
+//	type Topic struct {
+//	    IsMathHomework bool   `json:"is_math_homework"`
+//	    Reasoning      string `json:"reasoning"`
+//	}
+//
+//	check := guardrails.Classifier(guardrails.ClassifierParams[Topic]{
+//	    Model:        model,
+//	    Instructions: "Check if the user is asking you to do their math homework.",
+//	    Tripwire:     func(t Topic) bool { return t.IsMathHomework },
+//	})
+func Classifier[T any](params ClassifierParams[T]) Check {
+	schema := newClassifierSchema[T]()
+	return func(ctx context.Context, text string) (agents.GuardrailFunctionOutput, error) {
+		if params.Model == nil {
+			return agents.GuardrailFunctionOutput{}, errors.New("classifier model is not set")
+		}
+		if params.Tripwire == nil {
+			return agents.GuardrailFunctionOutput{}, errors.New("classifier tripwire function is not set")
+		}
+
+		response, err := params.Model.GetResponse(ctx, agents.ModelResponseParams{
+			SystemInstructions: param.NewOpt(params.Instructions),
+			Input:              agents.InputString(text),
+			ModelSettings:      params.ModelSettings,
+			OutputSchema:       schema,
+		})
+		if err != nil {
+			return agents.GuardrailFunctionOutput{}, fmt.Errorf("classifier model call failed: %w", err)
+		}
+
+		var output string
+		for _, item := range response.Output {
+			if s, ok := agents.ItemHelpers().ExtractLastText(item); ok {
+				output = s
+			}
+		}
+		if output == "" {
+			return agents.GuardrailFunctionOutput{}, agents.NewModelBehaviorError("classifier model returned no text")
+		}
+		v, err := schema.ValidateJSON(output)
+		if err != nil {
+			return agents.GuardrailFunctionOutput{}, err
+		}
+
+		classification := v.(T)
+		return agents.GuardrailFunctionOutput{
+			OutputInfo:        classification,
+			TripwireTriggered: params.Tripwire(classification),
+		}, nil
+	}
+}
+
+// ClassifierInputGuardrail returns an input guardrail classifying the input
+// messages with a model.
+func ClassifierInputGuardrail[T any](name string, params ClassifierParams[T]) agents.InputGuardrail {
+	return NewInputGuardrail(name, Classifier(params))
+}
+
+// ClassifierOutputGuardrail returns an output guardrail classifying the final
+// output with a model.
+func ClassifierOutputGuardrail[T any](name string, params ClassifierParams[T]) agents.OutputGuardrail {
+	return NewOutputGuardrail(name, Classifier(params))
+}
+
+// classifierSchema is the strict output schema of a classifier, reflected
+// from the type of the classification.
+type classifierSchema[T any] struct {
+	name   string
+	schema map[string]any
+}
+
+func newClassifierSchema[T any]() classifierSchema[T] {
+	reflector := &jsonschema.Reflector{
+		ExpandedStruct:             true,
+		RequiredFromJSONSchemaTags: false,
+		AllowAdditionalProperties:  false,
+	}
+	var zero T
+	schemaBytes, _ := json.Marshal(reflector.Reflect(&zero))
+	var schemaMap map[string]any
+	_ = json.Unmarshal(schemaBytes, &schemaMap)
+
+	name := "classification"
+	if t := reflect.TypeOf(zero); t != nil && t.Name() != "" {
+		name = t.Name()
+	}
+	return classifierSchema[T]{name: name, schema: schemaMap}
+}
+
+func (s classifierSchema[T]) IsPlainText() bool          { return false }
+func (s classifierSchema[T]) Name() string               { return s.name }
+func (s classifierSchema[T]) JSONSchema() map[string]any { return s.schema }
+func (s classifierSchema[T]) IsStrictJSONSchema() bool   { return true }
+
+func (s classifierSchema[T]) ValidateJSON(jsonStr string) (any, error) {
+	var v T
+	if err := json.Unmarshal([]byte(jsonStr), &v); err != nil {
+		return nil, agents.ModelBehaviorErrorf("invalid classifier output: %w", err)
+	}
+	return v, nil
+}
@@ -0,0 +1,127 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package guardrails provides ready-made input and output guardrails:
+// PII detection, prompt-injection detection, length and language checks,
+// JSON validity, LLM classifiers and OpenAI moderation.
+//
+// Every guardrail is built on a Check, a function inspecting a text. The
+// same Check can be used as an input guardrail (on the text of the input
+// messages) or as an output guardrail (on the final output of the agent)
+// with NewInputGuardrail and NewOutputGuardrail.
+package guardrails
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/openai/openai-go/responses"
+)
+
+// A Check inspects a text and returns a GuardrailFunctionOutput, whose
+// tripwire is triggered if the text is not acceptable.
+type Check = func(ctx context.Context, text string) (agents.GuardrailFunctionOutput, error)
+
+// NewInputGuardrail returns an InputGuardrail running the check on the text
+// of the input, as returned by InputText.
+func NewInputGuardrail(name string, check Check) agents.InputGuardrail {
+	return agents.InputGuardrail{
+		Name: name,
+		GuardrailFunction: func(ctx context.Context, _ *agents.Agent, input agents.Input) (agents.GuardrailFunctionOutput, error) {
+			return check(ctx, InputText(input))
+		},
+	}
+}
+
+// NewOutputGuardrail returns an OutputGuardrail running the check on the
+// text of the final output, as returned by OutputText.
+func NewOutputGuardrail(name string, check Check) agents.OutputGuardrail {
+	return agents.OutputGuardrail{
+		Name: name,
+		GuardrailFunction: func(ctx context.Context, _ *agents.Agent, output any) (agents.GuardrailFunctionOutput, error) {
+			return check(ctx, OutputText(output))
+		},
+	}
+}
+
+// InputText returns the text of the messages of an input, one per line.
+// Other items, such as tool calls and their outputs, are ignored.
+func InputText(input agents.Input) string {
+	switch v := input.(type) {
+	case agents.InputString:
+		return string(v)
+	case agents.InputItems:
+		var texts []string
+		for _, item := range v {
+			if text, ok := messageText(item); ok {
+				texts = append(texts, text)
+			}
+		}
+		return strings.Join(texts, "\n")
+	default:
+		return ""
+	}
+}
+
+// OutputText returns the text of a final output: strings are returned as
+// they are, other values are encoded as JSON.
+func OutputText(output any) string {
+	if s, ok := output.(string); ok {
+		return s
+	}
+	b, err := json.Marshal(output)
+	if err != nil {
+		return fmt.Sprint(output)
+	}
+	return string(b)
+}
+
+func messageText(item agents.TResponseInputItem) (string, bool) {
+	switch {
+	case item.OfMessage != nil:
+		content := item.OfMessage.Content
+		if content.OfString.Valid() {
+			return content.OfString.Value, true
+		}
+		return inputContentText(content.OfInputItemContentList), true
+	case item.OfInputMessage != nil:
+		return inputContentText(item.OfInputMessage.Content), true
+	case item.OfOutputMessage != nil:
+		var sb strings.Builder
+		for _, c := range item.OfOutputMessage.Content {
+			switch {
+			case c.OfOutputText != nil:
+				sb.WriteString(c.OfOutputText.Text)
+			case c.OfRefusal != nil:
+				sb.WriteString(c.OfRefusal.Refusal)
+			}
+		}
+		return sb.String(), true
+	default:
+		return "", false
+	}
+}
+
+func inputContentText(content responses.ResponseInputMessageContentListParam) string {
+	var sb strings.Builder
+	for _, c := range content {
+		if c.OfInputText != nil {
+			sb.WriteString(c.OfInputText.Text)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,131 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package guardrails
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+)
+
+// InjectionRule is a heuristic used by a PromptInjectionDetector: a pattern
+// typical of prompt-injection attempts, with the weight it adds to the score.
+type InjectionRule struct {
+	Name    string
+	Pattern *regexp.Regexp
+	Weight  float64
+}
+
+// DefaultPromptInjectionThreshold is the score from which a text is
+// considered a prompt-injection attempt, when no threshold is set.
+const DefaultPromptInjectionThreshold = 1.0
+
+// DefaultInjectionRules returns the rules used by a PromptInjectionDetector
+// without rules.
+func DefaultInjectionRules() []InjectionRule {
+	return []InjectionRule{
+		{
+			Name:    "ignore_instructions",
+			Pattern: regexp.MustCompile(`(?i)\b(?:ignore|disregard|forget|override)\b.{0,40}\b(?:previous|prior|above|earlier|all|your|system)\b.{0,20}\b(?:instructions?|prompts?|rules|directions)\b`),
+			Weight:  1,
+		},
+		{
+			Name:    "reveal_prompt",
+			Pattern: regexp.MustCompile(`(?i)\b(?:reveal|show|print|repeat|output|tell me)\b.{0,30}\b(?:system prompt|initial prompt|hidden instructions|your instructions)\b`),
+			Weight:  1,
+		},
+		{
+			Name:    "jailbreak",
+			Pattern: regexp.MustCompile(`(?i)\b(?:jailbreak|DAN mode|developer mode|do anything now|no restrictions|without any restrictions)\b`),
+			Weight:  0.8,
+		},
+		{
+			Name:    "role_markers",
+			Pattern: regexp.MustCompile(`(?im)(?:<\|im_start\|>|<\|system\|>|\[/?(?:system|INST)\]|^\s*#{2,}\s*(?:system|instructions?)\b|^\s*system\s*:)`),
+			Weight:  0.8,
+		},
+		{
+			Name:    "new_instructions",
+			Pattern: regexp.MustCompile(`(?i)\b(?:new|updated|real|actual)\s+instructions?\s*:`),
+			Weight:  0.6,
+		},
+		{
+			Name:    "role_play",
+			Pattern: regexp.MustCompile(`(?i)\b(?:you are now|from now on,? you|pretend (?:to be|you are)|act as if)\b`),
+			Weight:  0.4,
+		},
+	}
+}
+
+// PromptInjectionDetector scores texts with heuristic rules to detect
+// prompt-injection attempts. Each matching rule adds its weight to the
+// score, once; the text is an injection attempt if the score reaches the
+// threshold.
+//
+// Heuristics are cheap but easy to evade: combine them with a classifier
+// guardrail where the risk is high.
+type PromptInjectionDetector struct {
+	// Default (when nil): DefaultInjectionRules.
+	Rules []InjectionRule
+
+	// Default (when zero): DefaultPromptInjectionThreshold.
+	Threshold float64
+}
+
+// PromptInjectionReport is the result of a prompt-injection detection.
+// It is the OutputInfo of PromptInjectionGuardrail.
+type PromptInjectionReport struct {
+	Score float64
+
+	// The names of the matching rules.
+	Matched []string
+}
+
+// Detect scores a text.
+func (d PromptInjectionDetector) Detect(text string) PromptInjectionReport {
+	rules := d.Rules
+	if rules == nil {
+		rules = DefaultInjectionRules()
+	}
+	var report PromptInjectionReport
+	for _, rule := range rules {
+		if rule.Pattern.MatchString(text) {
+			report.Score += rule.Weight
+			report.Matched = append(report.Matched, rule.Name)
+		}
+	}
+	return report
+}
+
+// Check is a Check triggering the tripwire when the text scores at least
+// the threshold. The OutputInfo is the PromptInjectionReport.
+func (d PromptInjectionDetector) Check(_ context.Context, text string) (agents.GuardrailFunctionOutput, error) {
+	threshold := d.Threshold
+	if threshold == 0 {
+		threshold = DefaultPromptInjectionThreshold
+	}
+	report := d.Detect(text)
+	return agents.GuardrailFunctionOutput{
+		OutputInfo:        report,
+		TripwireTriggered: report.Score >= threshold,
+	}, nil
+}
+
+// PromptInjectionGuardrail returns an input guardrail tripping when the input
+// messages look like a prompt-injection attempt.
+func PromptInjectionGuardrail(detector PromptInjectionDetector) agents.InputGuardrail {
+	return NewInputGuardrail("prompt_injection", detector.Check)
+}
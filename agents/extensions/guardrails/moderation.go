@@ -0,0 +1,132 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package guardrails
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/openai/openai-go"
+	"github.com/openai/openai-go/option"
+	"github.com/openai/openai-go/packages/param"
+)
+
+// ModerationClient creates moderations. It is implemented by
+// openai.ModerationService, and can be replaced in tests.
+type ModerationClient interface {
+	New(ctx context.Context, body openai.ModerationNewParams, opts ...option.RequestOption) (*openai.ModerationNewResponse, error)
+}
+
+// ModerationParams configures a guardrail based on the OpenAI moderation
+// endpoint.
+type ModerationParams struct {
+	// The client to use. Default: the moderation service of the default
+	// OpenAI client (see agents.SetDefaultOpenaiClient), or of a new client
+	// configured from the environment.
+	Client ModerationClient
+
+	// The moderation model. Default: openai.ModerationModelOmniModerationLatest.
+	Model openai.ModerationModel
+
+	// Trip only when one of these categories is flagged, such as "hate" or
+	// "self-harm/intent". Default (when empty): trip when the text is flagged
+	// in any category.
+	Categories []string
+}
+
+// Moderation returns a Check sending the text to the OpenAI moderation
+// endpoint, triggering the tripwire when it is flagged. The OutputInfo is
+// the openai.ModerationNewResponse.
+func Moderation(params ModerationParams) Check {
+	if params.Model == "" {
+		params.Model = openai.ModerationModelOmniModerationLatest
+	}
+	return func(ctx context.Context, text string) (agents.GuardrailFunctionOutput, error) {
+		client := params.Client
+		if client == nil {
+			client = defaultModerationClient()
+		}
+
+		response, err := client.New(ctx, openai.ModerationNewParams{
+			Input: openai.ModerationNewParamsInputUnion{OfString: param.NewOpt(text)},
+			Model: params.Model,
+		})
+		if err != nil {
+			return agents.GuardrailFunctionOutput{}, fmt.Errorf("moderation request failed: %w", err)
+		}
+		if response == nil {
+			return agents.GuardrailFunctionOutput{}, errors.New("moderation request returned no response")
+		}
+
+		tripped := false
+		for _, result := range response.Results {
+			flagged, err := moderationFlagged(result, params.Categories)
+			if err != nil {
+				return agents.GuardrailFunctionOutput{}, err
+			}
+			tripped = tripped || flagged
+		}
+		return agents.GuardrailFunctionOutput{
+			OutputInfo:        *response,
+			TripwireTriggered: tripped,
+		}, nil
+	}
+}
+
+// ModerationInputGuardrail returns an input guardrail tripping when the input
+// messages are flagged by the OpenAI moderation endpoint.
+func ModerationInputGuardrail(params ModerationParams) agents.InputGuardrail {
+	return NewInputGuardrail("moderation", Moderation(params))
+}
+
+// ModerationOutputGuardrail returns an output guardrail tripping when the
+// final output is flagged by the OpenAI moderation endpoint.
+func ModerationOutputGuardrail(params ModerationParams) agents.OutputGuardrail {
+	return NewOutputGuardrail("moderation", Moderation(params))
+}
+
+func defaultModerationClient() ModerationClient {
+	client := agents.GetDefaultOpenaiClient()
+	if client == nil {
+		c := agents.NewOpenaiClient(param.Opt[string]{})
+		client = &c
+	}
+	return &client.Moderations
+}
+
+// moderationFlagged reports whether a moderation result is flagged in any
+// of the given categories, or at all if no category is given.
+func moderationFlagged(result openai.Moderation, categories []string) (bool, error) {
+	if len(categories) == 0 {
+		return result.Flagged, nil
+	}
+	b, err := json.Marshal(result.Categories)
+	if err != nil {
+		return false, fmt.Errorf("failed to encode moderation categories: %w", err)
+	}
+	var flags map[string]bool
+	if err = json.Unmarshal(b, &flags); err != nil {
+		return false, fmt.Errorf("failed to decode moderation categories: %w", err)
+	}
+	for _, category := range categories {
+		if flags[category] {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,185 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package guardrails
+
+import (
+	"cmp"
+	"context"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+)
+
+// PIIPattern is a regular expression detecting a type of PII.
+type PIIPattern struct {
+	// The type of PII, such as "email". It is used, uppercased, in the
+	// redacted text: "[EMAIL]".
+	Type string
+
+	Regexp *regexp.Regexp
+
+	// Optional function discarding false positives among the matches.
+	Validate func(match string) bool
+}
+
+// DictionaryPIIType is the type of the PII found from a PIIDetector dictionary.
+const DictionaryPIIType = "custom"
+
+// DefaultPIIPatterns returns the patterns used by a PIIDetector without
+// patterns: email addresses, credit card numbers (passing the Luhn check),
+// US social security numbers, phone numbers and IPv4 addresses.
+func DefaultPIIPatterns() []PIIPattern {
+	return []PIIPattern{
+		{Type: "email", Regexp: regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)},
+		{Type: "credit_card", Regexp: regexp.MustCompile(`\b(?:\d[ -]?){12,15}\d\b`), Validate: luhnValid},
+		{Type: "ssn", Regexp: regexp.MustCompile(`\b\d{3}-\d{2}-\d{4}\b`)},
+		{Type: "phone", Regexp: regexp.MustCompile(`(?:\+\d{1,3}[ .-]?)?(?:\(\d{3}\)|\b\d{3})[ .-]?\d{3}[ .-]?\d{4}\b`)},
+		{Type: "ip_address", Regexp: regexp.MustCompile(`\b(?:(?:25[0-5]|2[0-4]\d|1?\d?\d)\.){3}(?:25[0-5]|2[0-4]\d|1?\d?\d)\b`)},
+	}
+}
+
+// PIIDetector finds personally identifiable information in texts, with
+// regular expressions and a dictionary of sensitive terms.
+type PIIDetector struct {
+	// The patterns to look for. Default (when nil): DefaultPIIPatterns.
+	Patterns []PIIPattern
+
+	// Terms which are always PII, such as customer names, matched as whole
+	// words regardless of case. They are reported as DictionaryPIIType.
+	Dictionary []string
+}
+
+// PIIFinding is a piece of PII found in a text.
+type PIIFinding struct {
+	Type string
+	Text string
+
+	// Byte offsets of the finding in the text.
+	Start, End int
+}
+
+// PIIReport is the result of a PII detection. It is the OutputInfo of the
+// PII guardrails.
+type PIIReport struct {
+	// The PII found, in order of appearance. Overlapping matches are
+	// reported once, by the first pattern matching at the earliest offset.
+	Findings []PIIFinding
+
+	// The text with each finding replaced by its uppercased type in square
+	// brackets, such as "[EMAIL]".
+	Redacted string
+}
+
+// Counts returns the number of findings by type.
+func (r PIIReport) Counts() map[string]int {
+	counts := make(map[string]int)
+	for _, f := range r.Findings {
+		counts[f.Type]++
+	}
+	return counts
+}
+
+// Detect finds the PII in a text.
+func (d PIIDetector) Detect(text string) PIIReport {
+	patterns := d.Patterns
+	if patterns == nil {
+		patterns = DefaultPIIPatterns()
+	}
+	var quoted []string
+	for _, term := range d.Dictionary {
+		if term != "" {
+			quoted = append(quoted, regexp.QuoteMeta(term))
+		}
+	}
+	if len(quoted) > 0 {
+		patterns = append(slices.Clone(patterns), PIIPattern{
+			Type:   DictionaryPIIType,
+			Regexp: regexp.MustCompile(`(?i)\b(?:` + strings.Join(quoted, "|") + `)\b`),
+		})
+	}
+
+	var candidates []PIIFinding
+	for _, p := range patterns {
+		for _, loc := range p.Regexp.FindAllStringIndex(text, -1) {
+			match := text[loc[0]:loc[1]]
+			if p.Validate != nil && !p.Validate(match) {
+				continue
+			}
+			candidates = append(candidates, PIIFinding{Type: p.Type, Text: match, Start: loc[0], End: loc[1]})
+		}
+	}
+	slices.SortStableFunc(candidates, func(a, b PIIFinding) int { return cmp.Compare(a.Start, b.Start) })
+
+	var report PIIReport
+	var sb strings.Builder
+	end := 0
+	for _, f := range candidates {
+		if f.Start < end {
+			continue
+		}
+		report.Findings = append(report.Findings, f)
+		sb.WriteString(text[end:f.Start])
+		sb.WriteString("[" + strings.ToUpper(f.Type) + "]")
+		end = f.End
+	}
+	sb.WriteString(text[end:])
+	report.Redacted = sb.String()
+	return report
+}
+
+// Check is a Check triggering the tripwire when the text contains PII.
+// The OutputInfo is the PIIReport.
+func (d PIIDetector) Check(_ context.Context, text string) (agents.GuardrailFunctionOutput, error) {
+	report := d.Detect(text)
+	return agents.GuardrailFunctionOutput{
+		OutputInfo:        report,
+		TripwireTriggered: len(report.Findings) > 0,
+	}, nil
+}
+
+// PIIInputGuardrail returns an input guardrail tripping when the input
+// messages contain PII.
+func PIIInputGuardrail(detector PIIDetector) agents.InputGuardrail {
+	return NewInputGuardrail("pii", detector.Check)
+}
+
+// PIIOutputGuardrail returns an output guardrail tripping when the final
+// output contains PII.
+func PIIOutputGuardrail(detector PIIDetector) agents.OutputGuardrail {
+	return NewOutputGuardrail("pii", detector.Check)
+}
+
+// luhnValid reports whether the digits of s pass the Luhn checksum.
+func luhnValid(s string) bool {
+	sum, n := 0, 0
+	for i := len(s) - 1; i >= 0; i-- {
+		c := s[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+		d := int(c - '0')
+		if n%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		n++
+	}
+	return n > 0 && sum%10 == 0
+}
@@ -0,0 +1,183 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package guardrails
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// LengthReport is the OutputInfo of the max length guardrails.
+type LengthReport struct {
+	// The length of the text, in characters (runes).
+	Length int
+	Max    int
+}
+
+// MaxLength returns a Check triggering the tripwire when the text is longer
+// than maxChars characters. The OutputInfo is a LengthReport.
+func MaxLength(maxChars int) Check {
+	return func(_ context.Context, text string) (agents.GuardrailFunctionOutput, error) {
+		n := utf8.RuneCountInString(text)
+		return agents.GuardrailFunctionOutput{
+			OutputInfo:        LengthReport{Length: n, Max: maxChars},
+			TripwireTriggered: n > maxChars,
+		}, nil
+	}
+}
+
+// MaxLengthInputGuardrail returns an input guardrail tripping when the input
+// messages are longer than maxChars characters.
+func MaxLengthInputGuardrail(maxChars int) agents.InputGuardrail {
+	return NewInputGuardrail("max_length", MaxLength(maxChars))
+}
+
+// MaxLengthOutputGuardrail returns an output guardrail tripping when the final
+// output is longer than maxChars characters.
+func MaxLengthOutputGuardrail(maxChars int) agents.OutputGuardrail {
+	return NewOutputGuardrail("max_length", MaxLength(maxChars))
+}
+
+// DefaultLanguageMinRatio is the default LanguageCheck.MinRatio.
+const DefaultLanguageMinRatio = 0.8
+
+// LanguageCheck checks the language of a text from the Unicode scripts of
+// its letters, such as unicode.Latin or unicode.Han. It cannot distinguish
+// languages written with the same script, but it is enough to keep an agent
+// answering in the expected writing system.
+type LanguageCheck struct {
+	// The scripts the letters of the text are expected to belong to.
+	AllowedScripts []*unicode.RangeTable
+
+	// The minimum ratio of letters belonging to the allowed scripts.
+	// Default (when zero): DefaultLanguageMinRatio.
+	MinRatio float64
+}
+
+// LanguageReport is the OutputInfo of the language guardrails.
+type LanguageReport struct {
+	// The number of letters in the text.
+	Letters int
+
+	// The ratio of letters belonging to the allowed scripts. It is 1 for a
+	// text without letters.
+	Ratio float64
+}
+
+// Check is a Check triggering the tripwire when the ratio of letters of the
+// text in the allowed scripts is lower than the minimum. The OutputInfo is a
+// LanguageReport.
+func (c LanguageCheck) Check(_ context.Context, text string) (agents.GuardrailFunctionOutput, error) {
+	minRatio := c.MinRatio
+	if minRatio == 0 {
+		minRatio = DefaultLanguageMinRatio
+	}
+
+	var letters, allowed int
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		if unicode.In(r, c.AllowedScripts...) {
+			allowed++
+		}
+	}
+	report := LanguageReport{Letters: letters, Ratio: 1}
+	if letters > 0 {
+		report.Ratio = float64(allowed) / float64(letters)
+	}
+	return agents.GuardrailFunctionOutput{
+		OutputInfo:        report,
+		TripwireTriggered: report.Ratio < minRatio,
+	}, nil
+}
+
+// LanguageInputGuardrail returns an input guardrail tripping when the input
+// messages are not mostly written in the allowed scripts.
+func LanguageInputGuardrail(check LanguageCheck) agents.InputGuardrail {
+	return NewInputGuardrail("language", check.Check)
+}
+
+// LanguageOutputGuardrail returns an output guardrail tripping when the final
+// output is not mostly written in the allowed scripts.
+func LanguageOutputGuardrail(check LanguageCheck) agents.OutputGuardrail {
+	return NewOutputGuardrail("language", check.Check)
+}
+
+// JSONReport is the OutputInfo of JSONOutputGuardrail.
+type JSONReport struct {
+	Valid bool
+
+	// The parsing or schema validation errors.
+	Errors []string
+}
+
+// ValidJSON returns a Check triggering the tripwire when the text is not
+// valid JSON or, if schema is not nil, when it does not validate against
+// the JSON schema. The OutputInfo is a JSONReport.
+func ValidJSON(schema map[string]any) (Check, error) {
+	var loaded *gojsonschema.Schema
+	if schema != nil {
+		var err error
+		loaded, err = gojsonschema.NewSchema(gojsonschema.NewGoLoader(schema))
+		if err != nil {
+			return nil, fmt.Errorf("invalid JSON schema: %w", err)
+		}
+	}
+
+	return func(_ context.Context, text string) (agents.GuardrailFunctionOutput, error) {
+		report := JSONReport{Valid: true}
+		if !json.Valid([]byte(text)) {
+			report = JSONReport{Errors: []string{"invalid JSON"}}
+		} else if loaded != nil {
+			result, err := loaded.Validate(gojsonschema.NewStringLoader(text))
+			if err != nil {
+				return agents.GuardrailFunctionOutput{}, fmt.Errorf("JSON schema validation failed: %w", err)
+			}
+			if !result.Valid() {
+				report.Valid = false
+				for _, e := range result.Errors() {
+					report.Errors = append(report.Errors, e.String())
+				}
+			}
+		}
+		return agents.GuardrailFunctionOutput{
+			OutputInfo:        report,
+			TripwireTriggered: !report.Valid,
+		}, nil
+	}, nil
+}
+
+// JSONOutputGuardrail returns an output guardrail tripping when the final
+// output is not valid JSON or, if schema is not nil, when it does not
+// validate against the JSON schema. It returns an error if the schema is
+// not valid.
+//
+// Structured outputs are already encoded as JSON by OutputText: this
+// guardrail is meant for agents producing JSON as plain text.
+func JSONOutputGuardrail(schema map[string]any) (agents.OutputGuardrail, error) {
+	check, err := ValidJSON(schema)
+	if err != nil {
+		return agents.OutputGuardrail{}, err
+	}
+	return NewOutputGuardrail("json", check), nil
+}
@@ -0,0 +1,53 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handoff_filters
+
+import (
+	"context"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+)
+
+// Chain returns a HandoffInputFilter which applies the given filters in order,
+// each one receiving the output of the previous one. Nil filters are skipped.
+//
+// The chain stops at the first error, which is returned as is, and it fails
+// early if the context is canceled.
+func Chain(filters ...agents.HandoffInputFilter) agents.HandoffInputFilter {
+	return func(ctx context.Context, data agents.HandoffInputData) (agents.HandoffInputData, error) {
+		for _, filter := range filters {
+			if filter == nil {
+				continue
+			}
+			if err := ctx.Err(); err != nil {
+				return agents.HandoffInputData{}, err
+			}
+			var err error
+			data, err = filter(ctx, data)
+			if err != nil {
+				return agents.HandoffInputData{}, err
+			}
+		}
+		return data, nil
+	}
+}
+
+// Filter adapts a plain function, such as RemoveAllTools, to a
+// HandoffInputFilter which never fails.
+func Filter(fn func(agents.HandoffInputData) agents.HandoffInputData) agents.HandoffInputFilter {
+	return func(_ context.Context, data agents.HandoffInputData) (agents.HandoffInputData, error) {
+		return fn(data), nil
+	}
+}
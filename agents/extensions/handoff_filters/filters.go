@@ -0,0 +1,361 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handoff_filters
+
+import (
+	"context"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/agents/extensions/guardrails"
+	"github.com/openai/openai-go/responses"
+)
+
+// KeepLastMessages returns a filter which keeps only the last n messages of
+// the whole conversation (input history, pre-handoff items and new items),
+// together with the tool calls and other items following the oldest kept
+// message. A tool call is never separated from its output: the cut is moved
+// earlier if needed. If n is not positive, everything is removed.
+func KeepLastMessages(n int) agents.HandoffInputFilter {
+	return func(_ context.Context, data agents.HandoffInputData) (agents.HandoffInputData, error) {
+		history := historyItems(data.InputHistory)
+		all := allInputItems(history, data.PreHandoffItems, data.NewItems)
+
+		cut := len(all)
+		for i := len(all) - 1; i >= 0 && n > 0; i-- {
+			if isMessage(all[i]) {
+				cut = i
+				n--
+			}
+		}
+		cut = pairSafeCut(all, cut)
+
+		return sliceData(data, history, cut), nil
+	}
+}
+
+// sliceData removes the first cut items from the combined sequence of
+// history, pre-handoff items and new items.
+func sliceData(data agents.HandoffInputData, history []agents.TResponseInputItem, cut int) agents.HandoffInputData {
+	historyCut := min(cut, len(history))
+	cut -= historyCut
+	preCut := min(cut, len(data.PreHandoffItems))
+	cut -= preCut
+
+	filteredHistory := data.InputHistory
+	if historyCut > 0 {
+		filteredHistory = agents.InputItems(slices.Clone(history[historyCut:]))
+	}
+
+	return agents.HandoffInputData{
+		InputHistory:    filteredHistory,
+		PreHandoffItems: slices.Clone(data.PreHandoffItems[preCut:]),
+		NewItems:        slices.Clone(data.NewItems[cut:]),
+	}
+}
+
+// RemoveReasoning is a filter removing all reasoning items.
+func RemoveReasoning(_ context.Context, data agents.HandoffInputData) (agents.HandoffInputData, error) {
+	isReasoningRunItem := func(item agents.RunItem) bool {
+		_, ok := item.(agents.ReasoningItem)
+		return ok
+	}
+
+	var filteredHistory agents.Input
+	switch history := data.InputHistory.(type) {
+	case agents.InputItems:
+		filteredHistory = agents.InputItems(slices.DeleteFunc(slices.Clone(history), func(item agents.TResponseInputItem) bool {
+			return item.OfReasoning != nil
+		}))
+	default:
+		filteredHistory = history
+	}
+
+	return agents.HandoffInputData{
+		InputHistory:    filteredHistory,
+		PreHandoffItems: slices.DeleteFunc(slices.Clone(data.PreHandoffItems), isReasoningRunItem),
+		NewItems:        slices.DeleteFunc(slices.Clone(data.NewItems), isReasoningRunItem),
+	}, nil
+}
+
+// RemoveAgentItems returns a filter which removes the pre-handoff and new
+// items generated by any of the given agents.
+//
+// The input history is left untouched, since its items are not associated
+// with agents.
+func RemoveAgentItems(agentsToRemove ...*agents.Agent) agents.HandoffInputFilter {
+	fromRemovedAgent := func(item agents.RunItem) bool {
+		return slices.Contains(agentsToRemove, runItemAgent(item))
+	}
+	return func(_ context.Context, data agents.HandoffInputData) (agents.HandoffInputData, error) {
+		return agents.HandoffInputData{
+			InputHistory:    data.InputHistory,
+			PreHandoffItems: slices.DeleteFunc(slices.Clone(data.PreHandoffItems), fromRemovedAgent),
+			NewItems:        slices.DeleteFunc(slices.Clone(data.NewItems), fromRemovedAgent),
+		}, nil
+	}
+}
+
+func runItemAgent(item agents.RunItem) *agents.Agent {
+	switch v := item.(type) {
+	case agents.MessageOutputItem:
+		return v.Agent
+	case agents.HandoffCallItem:
+		return v.Agent
+	case agents.HandoffOutputItem:
+		return v.Agent
+	case agents.ToolCallItem:
+		return v.Agent
+	case agents.ToolCallOutputItem:
+		return v.Agent
+	case agents.ReasoningItem:
+		return v.Agent
+	case agents.GuardrailFeedbackItem:
+		return v.Agent
+	case agents.OutputRepairItem:
+		return v.Agent
+	default:
+		return nil
+	}
+}
+
+// RedactedText replaces the text matched by RedactPII and RedactPIIWith.
+const RedactedText = "[REDACTED]"
+
+// DefaultPIIPatterns are the patterns used by RedactPII when none is given:
+// email addresses, credit card numbers, US social security numbers and phone
+// numbers.
+var DefaultPIIPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`),
+	regexp.MustCompile(`\b(?:\d[ -]?){13,16}\b`),
+	regexp.MustCompile(`\b\d{3}-\d{2}-\d{4}\b`),
+	regexp.MustCompile(`(?:\+\d{1,3}[ .-]?)?(?:\(\d{3}\)|\b\d{3})[ .-]?\d{3}[ .-]?\d{4}\b`),
+}
+
+// RedactPII returns a filter which replaces the text matching any of the given
+// patterns with RedactedText, in messages and tool call outputs. If no pattern
+// is given, DefaultPIIPatterns is used.
+//
+// The original items are never modified: redacted items are copies.
+func RedactPII(patterns ...*regexp.Regexp) agents.HandoffInputFilter {
+	if len(patterns) == 0 {
+		patterns = DefaultPIIPatterns
+	}
+	return redactFilter(func(s string) string {
+		for _, p := range patterns {
+			s = p.ReplaceAllString(s, RedactedText)
+		}
+		return s
+	})
+}
+
+// RedactPIIWith returns a filter which replaces the PII found by the given
+// detector with RedactedText, in messages and tool call outputs, so that
+// handoffs redact the same PII detected by the PII guardrails.
+//
+// The original items are never modified: redacted items are copies.
+func RedactPIIWith(detector guardrails.PIIDetector) agents.HandoffInputFilter {
+	return redactFilter(func(s string) string {
+		findings := detector.Detect(s).Findings
+		if len(findings) == 0 {
+			return s
+		}
+		var sb strings.Builder
+		end := 0
+		for _, f := range findings {
+			sb.WriteString(s[end:f.Start])
+			sb.WriteString(RedactedText)
+			end = f.End
+		}
+		sb.WriteString(s[end:])
+		return sb.String()
+	})
+}
+
+// redactFilter returns a filter applying redact to the text of messages and
+// tool call outputs.
+func redactFilter(redact func(string) string) agents.HandoffInputFilter {
+	return func(_ context.Context, data agents.HandoffInputData) (agents.HandoffInputData, error) {
+		var filteredHistory agents.Input
+		switch history := data.InputHistory.(type) {
+		case agents.InputString:
+			filteredHistory = agents.InputString(redact(string(history)))
+		case agents.InputItems:
+			items := make(agents.InputItems, len(history))
+			for i, item := range history {
+				items[i] = redactInputItem(item, redact)
+			}
+			filteredHistory = items
+		default:
+			filteredHistory = history
+		}
+
+		return agents.HandoffInputData{
+			InputHistory:    filteredHistory,
+			PreHandoffItems: redactRunItems(data.PreHandoffItems, redact),
+			NewItems:        redactRunItems(data.NewItems, redact),
+		}, nil
+	}
+}
+
+func redactRunItems(items []agents.RunItem, redact func(string) string) []agents.RunItem {
+	if items == nil {
+		return nil
+	}
+	result := make([]agents.RunItem, len(items))
+	for i, item := range items {
+		switch v := item.(type) {
+		case agents.MessageOutputItem:
+			v.RawItem.Content = slices.Clone(v.RawItem.Content)
+			for j := range v.RawItem.Content {
+				v.RawItem.Content[j].Text = redact(v.RawItem.Content[j].Text)
+			}
+			result[i] = v
+		case agents.ToolCallOutputItem:
+			if raw, ok := v.RawItem.(agents.ResponseInputItemFunctionCallOutputParam); ok {
+				raw.Output = redact(raw.Output)
+				v.RawItem = raw
+			}
+			if s, ok := v.Output.(string); ok {
+				v.Output = redact(s)
+			}
+			result[i] = v
+		default:
+			result[i] = item
+		}
+	}
+	return result
+}
+
+func redactInputItem(item agents.TResponseInputItem, redact func(string) string) agents.TResponseInputItem {
+	switch {
+	case item.OfMessage != nil:
+		m := *item.OfMessage
+		if m.Content.OfString.Valid() {
+			m.Content.OfString.Value = redact(m.Content.OfString.Value)
+		} else {
+			m.Content.OfInputItemContentList = redactInputContent(m.Content.OfInputItemContentList, redact)
+		}
+		item.OfMessage = &m
+	case item.OfInputMessage != nil:
+		m := *item.OfInputMessage
+		m.Content = redactInputContent(m.Content, redact)
+		item.OfInputMessage = &m
+	case item.OfOutputMessage != nil:
+		m := *item.OfOutputMessage
+		m.Content = slices.Clone(m.Content)
+		for i, c := range m.Content {
+			if c.OfOutputText != nil {
+				text := *c.OfOutputText
+				text.Text = redact(text.Text)
+				m.Content[i].OfOutputText = &text
+			}
+		}
+		item.OfOutputMessage = &m
+	case item.OfFunctionCallOutput != nil:
+		o := *item.OfFunctionCallOutput
+		o.Output = redact(o.Output)
+		item.OfFunctionCallOutput = &o
+	}
+	return item
+}
+
+func redactInputContent(
+	content responses.ResponseInputMessageContentListParam,
+	redact func(string) string,
+) responses.ResponseInputMessageContentListParam {
+	content = slices.Clone(content)
+	for i, c := range content {
+		if c.OfInputText != nil {
+			text := *c.OfInputText
+			text.Text = redact(text.Text)
+			content[i].OfInputText = &text
+		}
+	}
+	return content
+}
+
+// historyItems returns the input history as a list of items.
+func historyItems(history agents.Input) []agents.TResponseInputItem {
+	if history == nil {
+		return nil
+	}
+	return agents.ItemHelpers().InputToNewInputList(history)
+}
+
+// allInputItems returns the whole conversation as a list of input items.
+func allInputItems(history []agents.TResponseInputItem, runItemLists ...[]agents.RunItem) []agents.TResponseInputItem {
+	all := slices.Clone(history)
+	for _, items := range runItemLists {
+		for _, item := range items {
+			all = append(all, item.ToInputItem())
+		}
+	}
+	return all
+}
+
+func isMessage(item agents.TResponseInputItem) bool {
+	return item.OfMessage != nil || item.OfInputMessage != nil || item.OfOutputMessage != nil
+}
+
+// callKey returns a key identifying the tool call an item belongs to, for
+// tool calls and tool call outputs which must be kept together.
+func callKey(item agents.TResponseInputItem) (string, bool) {
+	switch {
+	case item.OfFunctionCall != nil:
+		return "function:" + item.OfFunctionCall.CallID, true
+	case item.OfFunctionCallOutput != nil:
+		return "function:" + item.OfFunctionCallOutput.CallID, true
+	case item.OfComputerCall != nil:
+		return "computer:" + item.OfComputerCall.CallID, true
+	case item.OfComputerCallOutput != nil:
+		return "computer:" + item.OfComputerCallOutput.CallID, true
+	case item.OfLocalShellCall != nil:
+		return "local_shell:" + item.OfLocalShellCall.CallID, true
+	case item.OfLocalShellCallOutput != nil:
+		return "local_shell:" + item.OfLocalShellCallOutput.ID, true
+	default:
+		return "", false
+	}
+}
+
+// pairSafeCut moves a cut index backwards until no tool call is split
+// between items[:cut] and items[cut:].
+func pairSafeCut(items []agents.TResponseInputItem, cut int) int {
+	for {
+		firstIndex := make(map[string]int)
+		for i, item := range items[:cut] {
+			if key, ok := callKey(item); ok {
+				if _, seen := firstIndex[key]; !seen {
+					firstIndex[key] = i
+				}
+			}
+		}
+		newCut := cut
+		for _, item := range items[cut:] {
+			if key, ok := callKey(item); ok {
+				if i, found := firstIndex[key]; found && i < newCut {
+					newCut = i
+				}
+			}
+		}
+		if newCut == cut {
+			return cut
+		}
+		cut = newCut
+	}
+}
@@ -0,0 +1,269 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handoff_filters_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/agents/extensions/guardrails"
+	"github.com/nlpodyssey/openai-agents-go/agents/extensions/handoff_filters"
+	"github.com/nlpodyssey/openai-agents-go/agentstesting"
+	"github.com/openai/openai-go/responses"
+	"github.com/openai/openai-go/shared/constant"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getToolCallRunItem(agent *agents.Agent, callID string) agents.ToolCallItem {
+	return agents.ToolCallItem{
+		Agent: agent,
+		RawItem: agents.ResponseFunctionToolCall{
+			CallID:    callID,
+			Name:      "tool",
+			Arguments: "{}",
+			Type:      constant.ValueOf[constant.FunctionCall](),
+		},
+		Type: "tool_call_item",
+	}
+}
+
+func getReasoningRunItem() agents.ReasoningItem {
+	return agents.ReasoningItem{
+		Agent:   newFakeAgent(),
+		RawItem: responses.ResponseReasoningItem{ID: "r", Type: constant.ValueOf[constant.Reasoning]()},
+		Type:    "reasoning_item",
+	}
+}
+
+func TestChain(t *testing.T) {
+	var calls []string
+	record := func(name string) agents.HandoffInputFilter {
+		return func(_ context.Context, data agents.HandoffInputData) (agents.HandoffInputData, error) {
+			calls = append(calls, name)
+			data.NewItems = append(data.NewItems, getMessageOutputRunItem(name))
+			return data, nil
+		}
+	}
+
+	filter := handoff_filters.Chain(record("a"), nil, record("b"))
+	data, err := filter(t.Context(), agents.HandoffInputData{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, calls)
+	assert.Equal(t, "ab", agents.ItemHelpers().TextMessageOutputs(data.NewItems))
+
+	failure := errors.New("failure")
+	calls = nil
+	filter = handoff_filters.Chain(
+		record("a"),
+		func(context.Context, agents.HandoffInputData) (agents.HandoffInputData, error) {
+			return agents.HandoffInputData{}, failure
+		},
+		record("b"),
+	)
+	_, err = filter(t.Context(), agents.HandoffInputData{})
+	assert.ErrorIs(t, err, failure)
+	assert.Equal(t, []string{"a"}, calls)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	calls = nil
+	_, err = handoff_filters.Chain(record("a"))(ctx, agents.HandoffInputData{})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Empty(t, calls)
+}
+
+func TestFilterAdaptsRemoveAllTools(t *testing.T) {
+	filter := handoff_filters.Chain(handoff_filters.Filter(handoff_filters.RemoveAllTools))
+	data, err := filter(t.Context(), agents.HandoffInputData{
+		NewItems: []agents.RunItem{getMessageOutputRunItem("Hello"), getToolOutputRunItem("World")},
+	})
+	require.NoError(t, err)
+	assert.Len(t, data.NewItems, 1)
+}
+
+func TestKeepLastMessages(t *testing.T) {
+	agent := newFakeAgent()
+	data := agents.HandoffInputData{
+		InputHistory: agents.InputItems{
+			getMessageInputItem("m1"),
+			getMessageInputItem("m2"),
+		},
+		PreHandoffItems: []agents.RunItem{
+			getMessageOutputRunItem("m3"),
+			getToolCallRunItem(agent, "1"),
+		},
+		NewItems: []agents.RunItem{
+			getToolOutputRunItem("out"),
+			getMessageOutputRunItem("m4"),
+		},
+	}
+
+	filtered, err := handoff_filters.KeepLastMessages(2)(t.Context(), data)
+	require.NoError(t, err)
+	assert.Equal(t, agents.InputItems{}, filtered.InputHistory)
+	assert.Equal(t, data.PreHandoffItems, filtered.PreHandoffItems)
+	assert.Equal(t, data.NewItems, filtered.NewItems)
+
+	filtered, err = handoff_filters.KeepLastMessages(3)(t.Context(), data)
+	require.NoError(t, err)
+	assert.Equal(t, agents.InputItems{getMessageInputItem("m2")}, filtered.InputHistory)
+
+	// The tool call is kept together with its output.
+	filtered, err = handoff_filters.KeepLastMessages(1)(t.Context(), agents.HandoffInputData{
+		PreHandoffItems: []agents.RunItem{getMessageOutputRunItem("m1"), getToolCallRunItem(agent, "1")},
+		NewItems:        []agents.RunItem{getMessageOutputRunItem("m2"), getToolOutputRunItem("out")},
+	})
+	require.NoError(t, err)
+	assert.Len(t, filtered.PreHandoffItems, 1)
+	assert.Len(t, filtered.NewItems, 2)
+
+	filtered, err = handoff_filters.KeepLastMessages(10)(t.Context(), agents.HandoffInputData{InputHistory: agents.InputString("hi")})
+	require.NoError(t, err)
+	assert.Equal(t, agents.InputString("hi"), filtered.InputHistory)
+}
+
+func TestRemoveReasoning(t *testing.T) {
+	data := agents.HandoffInputData{
+		InputHistory: agents.InputItems{
+			getMessageInputItem("Hello"),
+			{OfReasoning: &responses.ResponseReasoningItemParam{ID: "r"}},
+		},
+		PreHandoffItems: []agents.RunItem{getReasoningRunItem(), getMessageOutputRunItem("a")},
+		NewItems:        []agents.RunItem{getMessageOutputRunItem("b"), getReasoningRunItem()},
+	}
+	filtered, err := handoff_filters.RemoveReasoning(t.Context(), data)
+	require.NoError(t, err)
+	assert.Equal(t, agents.InputItems{getMessageInputItem("Hello")}, filtered.InputHistory)
+	assert.Len(t, filtered.PreHandoffItems, 1)
+	assert.Len(t, filtered.NewItems, 1)
+	assert.Len(t, data.PreHandoffItems, 2, "the original data must not be modified")
+}
+
+func TestRemoveAgentItems(t *testing.T) {
+	other := agents.New("other")
+	data := agents.HandoffInputData{
+		InputHistory: agents.InputString("hi"),
+		PreHandoffItems: []agents.RunItem{
+			getMessageOutputRunItem("a"),
+			getToolCallRunItem(other, "1"),
+		},
+		NewItems: []agents.RunItem{getToolCallRunItem(other, "2")},
+	}
+	filtered, err := handoff_filters.RemoveAgentItems(other)(t.Context(), data)
+	require.NoError(t, err)
+	assert.Equal(t, agents.InputString("hi"), filtered.InputHistory)
+	assert.Len(t, filtered.PreHandoffItems, 1)
+	assert.Empty(t, filtered.NewItems)
+}
+
+func TestRedactPII(t *testing.T) {
+	data := agents.HandoffInputData{
+		InputHistory: agents.InputItems{
+			getMessageInputItem("mail me at john.doe@example.com"),
+			getFunctionResultInputItem("SSN 123-45-6789"),
+		},
+		PreHandoffItems: []agents.RunItem{getMessageOutputRunItem("call +1 555-123-4567")},
+		NewItems:        []agents.RunItem{getToolOutputRunItem("card 4111 1111 1111 1111")},
+	}
+	filtered, err := handoff_filters.RedactPII()(t.Context(), data)
+	require.NoError(t, err)
+
+	history := filtered.InputHistory.(agents.InputItems)
+	assert.Equal(t, "mail me at [REDACTED]", history[0].OfMessage.Content.OfString.Value)
+	assert.Equal(t, "SSN [REDACTED]", history[1].OfFunctionCallOutput.Output)
+	assert.Equal(t, "call [REDACTED]", agents.ItemHelpers().TextMessageOutputs(filtered.PreHandoffItems))
+	toolOutput := filtered.NewItems[0].(agents.ToolCallOutputItem)
+	assert.Equal(t, "card [REDACTED]", toolOutput.Output)
+	assert.Equal(t, "card [REDACTED]", toolOutput.RawItem.(agents.ResponseInputItemFunctionCallOutputParam).Output)
+
+	// The original data is not modified.
+	original := data.InputHistory.(agents.InputItems)
+	assert.Equal(t, "mail me at john.doe@example.com", original[0].OfMessage.Content.OfString.Value)
+	assert.Equal(t, "call +1 555-123-4567", agents.ItemHelpers().TextMessageOutputs(data.PreHandoffItems))
+
+	filtered, err = handoff_filters.RedactPII()(t.Context(), agents.HandoffInputData{InputHistory: agents.InputString("x@y.io")})
+	require.NoError(t, err)
+	assert.Equal(t, agents.InputString("[REDACTED]"), filtered.InputHistory)
+}
+
+func TestRedactPIIWith(t *testing.T) {
+	// The PII guardrails' detector is shared: numbers failing the Luhn check
+	// are not cards, and IP addresses are redacted.
+	filtered, err := handoff_filters.RedactPIIWith(guardrails.PIIDetector{})(t.Context(), agents.HandoffInputData{
+		InputHistory: agents.InputString("order 1234 5678 9012 3456 from 10.0.0.1"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, agents.InputString("order 1234 5678 9012 3456 from [REDACTED]"), filtered.InputHistory)
+
+	detector := guardrails.PIIDetector{Patterns: []guardrails.PIIPattern{{Type: "order", Regexp: regexp.MustCompile(`#\d+`)}}}
+	filtered, err = handoff_filters.RedactPIIWith(detector)(t.Context(), agents.HandoffInputData{
+		InputHistory: agents.InputString("order #42 for x@y.io"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, agents.InputString("order [REDACTED] for x@y.io"), filtered.InputHistory)
+}
+
+func TestNestHistory(t *testing.T) {
+	data := agents.HandoffInputData{
+		InputHistory:    agents.InputString("Hello"),
+		PreHandoffItems: []agents.RunItem{getMessageOutputRunItem("Hi there")},
+		NewItems:        []agents.RunItem{getHandoffOutputRunItem(`{"assistant":"fake_agent"}`)},
+	}
+	filtered, err := handoff_filters.NestHistory(t.Context(), data)
+	require.NoError(t, err)
+	assert.Nil(t, filtered.PreHandoffItems)
+	assert.Nil(t, filtered.NewItems)
+
+	history := filtered.InputHistory.(agents.InputItems)
+	require.Len(t, history, 1)
+	assert.Equal(t, responses.EasyInputMessageRoleAssistant, history[0].OfMessage.Role)
+	assert.Equal(t,
+		handoff_filters.NestedHistoryHeader+
+			"1. user: Hello\n"+
+			"2. assistant: Hi there\n"+
+			`3. tool result: {"assistant":"fake_agent"}`+"\n"+
+			handoff_filters.NestedHistoryFooter,
+		history[0].OfMessage.Content.OfString.Value,
+	)
+}
+
+func TestSummarizeHistory(t *testing.T) {
+	model := agentstesting.NewFakeModel(&agentstesting.FakeModelTurnOutput{
+		Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("the user said hello")},
+	})
+	data := agents.HandoffInputData{
+		InputHistory:    agents.InputString("Hello"),
+		PreHandoffItems: []agents.RunItem{getMessageOutputRunItem("Hi there")},
+		NewItems:        []agents.RunItem{getHandoffOutputRunItem("handoff")},
+	}
+	filtered, err := handoff_filters.SummarizeHistory(handoff_filters.SummarizeHistoryParams{Model: model})(t.Context(), data)
+	require.NoError(t, err)
+
+	assert.Equal(t, agents.InputString("1. user: Hello\n2. assistant: Hi there\n"), model.LastTurnArgs.Input)
+	assert.Equal(t, handoff_filters.DefaultSummaryInstructions, model.LastTurnArgs.SystemInstructions.Value)
+
+	history := filtered.InputHistory.(agents.InputItems)
+	require.Len(t, history, 1)
+	assert.Equal(t, handoff_filters.SummaryPrefix+"the user said hello", history[0].OfMessage.Content.OfString.Value)
+	assert.Nil(t, filtered.PreHandoffItems)
+	assert.Equal(t, data.NewItems, filtered.NewItems)
+
+	_, err = handoff_filters.SummarizeHistory(handoff_filters.SummarizeHistoryParams{})(t.Context(), data)
+	assert.Error(t, err)
+}
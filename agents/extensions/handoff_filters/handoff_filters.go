@@ -13,6 +13,9 @@
 // limitations under the License.
 
 // Package handoff_filters contains common handoff input filters, for convenience.
+//
+// Filters can be composed with Chain. Plain functions such as RemoveAllTools
+// can be adapted to agents.HandoffInputFilter with Filter.
 package handoff_filters
 
 import (
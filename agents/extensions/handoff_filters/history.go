@@ -0,0 +1,194 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handoff_filters
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/modelsettings"
+	"github.com/openai/openai-go/packages/param"
+	"github.com/openai/openai-go/responses"
+)
+
+// NestedHistoryHeader and NestedHistoryFooter enclose the transcript of the
+// message produced by NestHistory.
+const (
+	NestedHistoryHeader = "For context, here is the conversation so far:\n<CONVERSATION HISTORY>\n"
+	NestedHistoryFooter = "</CONVERSATION HISTORY>"
+)
+
+// NestHistory is a filter collapsing the whole conversation, including the
+// handoff call, into a single assistant message containing a plain-text
+// transcript. The next agent starts from that message only.
+func NestHistory(_ context.Context, data agents.HandoffInputData) (agents.HandoffInputData, error) {
+	all := allInputItems(historyItems(data.InputHistory), data.PreHandoffItems, data.NewItems)
+	if len(all) == 0 {
+		return agents.HandoffInputData{InputHistory: agents.InputItems{}}, nil
+	}
+	text := NestedHistoryHeader + transcript(all) + NestedHistoryFooter
+	return agents.HandoffInputData{
+		InputHistory: agents.InputItems{contextMessage(text)},
+	}, nil
+}
+
+// DefaultSummaryInstructions are the default system instructions of the
+// model call made by SummarizeHistory.
+const DefaultSummaryInstructions = "You summarize conversations for another assistant " +
+	"which takes over from here. Preserve facts, decisions, open questions, user " +
+	"preferences and relevant tool results. Reply with the summary only."
+
+// SummaryPrefix is prepended to the summary message produced by SummarizeHistory.
+const SummaryPrefix = "Summary of the conversation so far:\n"
+
+// SummarizeHistoryParams configures SummarizeHistory.
+type SummarizeHistoryParams struct {
+	// The model used to summarize the history. Required.
+	Model agents.Model
+
+	// Optional settings for the summarization model call.
+	ModelSettings modelsettings.ModelSettings
+
+	// Optional system instructions for the summarization model call.
+	// Default: DefaultSummaryInstructions.
+	Instructions string
+}
+
+// SummarizeHistory returns a filter replacing the input history and the
+// pre-handoff items with a single assistant message, containing a summary
+// generated by a model call. The new items, which include the handoff call,
+// are kept as they are.
+func SummarizeHistory(params SummarizeHistoryParams) agents.HandoffInputFilter {
+	if params.Instructions == "" {
+		params.Instructions = DefaultSummaryInstructions
+	}
+	return func(ctx context.Context, data agents.HandoffInputData) (agents.HandoffInputData, error) {
+		if params.Model == nil {
+			return agents.HandoffInputData{}, errors.New("summarization model is not set")
+		}
+
+		older := allInputItems(historyItems(data.InputHistory), data.PreHandoffItems)
+		if len(older) == 0 {
+			return data, nil
+		}
+
+		response, err := params.Model.GetResponse(ctx, agents.ModelResponseParams{
+			SystemInstructions: param.NewOpt(params.Instructions),
+			Input:              agents.InputString(transcript(older)),
+			ModelSettings:      params.ModelSettings,
+		})
+		if err != nil {
+			return agents.HandoffInputData{}, fmt.Errorf("summarization model call failed: %w", err)
+		}
+
+		var summary string
+		for _, item := range response.Output {
+			if text, ok := agents.ItemHelpers().ExtractLastText(item); ok {
+				summary = text
+			}
+		}
+		if summary == "" {
+			return agents.HandoffInputData{}, agents.NewModelBehaviorError("summarization model returned no text")
+		}
+
+		return agents.HandoffInputData{
+			InputHistory: agents.InputItems{contextMessage(SummaryPrefix + summary)},
+			NewItems:     data.NewItems,
+		}, nil
+	}
+}
+
+func contextMessage(text string) agents.TResponseInputItem {
+	return agents.TResponseInputItem{
+		OfMessage: &responses.EasyInputMessageParam{
+			Content: responses.EasyInputMessageContentUnionParam{OfString: param.NewOpt(text)},
+			Role:    responses.EasyInputMessageRoleAssistant,
+			Type:    responses.EasyInputMessageTypeMessage,
+		},
+	}
+}
+
+// transcript renders items as a numbered plain-text transcript.
+func transcript(items []agents.TResponseInputItem) string {
+	var sb strings.Builder
+	for i, item := range items {
+		sb.WriteString(strconv.Itoa(i + 1))
+		sb.WriteString(". ")
+		sb.WriteString(itemText(item))
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+// itemText renders an item as a line of a plain-text transcript.
+func itemText(item agents.TResponseInputItem) string {
+	switch {
+	case item.OfMessage != nil:
+		content := item.OfMessage.Content
+		if content.OfString.Valid() {
+			return string(item.OfMessage.Role) + ": " + content.OfString.Value
+		}
+		return string(item.OfMessage.Role) + ": " + inputContentText(content.OfInputItemContentList)
+	case item.OfInputMessage != nil:
+		return item.OfInputMessage.Role + ": " + inputContentText(item.OfInputMessage.Content)
+	case item.OfOutputMessage != nil:
+		text := ""
+		for _, c := range item.OfOutputMessage.Content {
+			switch {
+			case c.OfOutputText != nil:
+				text += c.OfOutputText.Text
+			case c.OfRefusal != nil:
+				text += c.OfRefusal.Refusal
+			}
+		}
+		return "assistant: " + text
+	case item.OfFunctionCall != nil:
+		return "tool call: " + item.OfFunctionCall.Name + "(" + item.OfFunctionCall.Arguments + ")"
+	case item.OfFunctionCallOutput != nil:
+		return "tool result: " + item.OfFunctionCallOutput.Output
+	case item.OfLocalShellCallOutput != nil:
+		return "shell result: " + item.OfLocalShellCallOutput.Output
+	default:
+		b, err := json.Marshal(item)
+		if err != nil {
+			return ""
+		}
+		var v struct {
+			Type string `json:"type"`
+		}
+		_ = json.Unmarshal(b, &v)
+		return "[" + v.Type + "]"
+	}
+}
+
+func inputContentText(content responses.ResponseInputMessageContentListParam) string {
+	text := ""
+	for _, c := range content {
+		switch {
+		case c.OfInputText != nil:
+			text += c.OfInputText.Text
+		case c.OfInputImage != nil:
+			text += "[image]"
+		case c.OfInputFile != nil:
+			text += "[file]"
+		}
+	}
+	return text
+}
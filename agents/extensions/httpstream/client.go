@@ -0,0 +1,190 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpstream
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+)
+
+// RunFailedError is returned by Client when the remote run terminates with an
+// EventTypeRunFailed event.
+type RunFailedError struct {
+	Message string
+}
+
+func (err RunFailedError) Error() string {
+	return fmt.Sprintf("remote run failed: %s", err.Message)
+}
+
+// Client consumes the Server-Sent Events produced by a Handler.
+type Client struct {
+	// The URL of the Handler endpoint.
+	URL string
+
+	// Optional HTTP client. Default (when nil): http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+// ClientResult is the outcome of a remote run, as reported by the final
+// EventTypeRunCompleted event.
+type ClientResult struct {
+	// The name of the last agent that was run.
+	LastAgentName string
+
+	// The JSON-encoded final output.
+	FinalOutput json.RawMessage
+}
+
+// Run sends the input to the remote Handler and calls fn for each streaming
+// event, rebuilt as agents.StreamEvent.
+//
+// It returns the final result once the stream is complete. If the remote
+// run fails, a RunFailedError is returned. If fn returns an error, the
+// stream is closed and the error is returned.
+func (c Client) Run(ctx context.Context, input agents.Input, fn func(agents.StreamEvent) error) (*ClientResult, error) {
+	return c.RunEvents(ctx, input, decodingEventFunc(fn))
+}
+
+// decodingEventFunc returns a function rebuilding the wire events as
+// agents.StreamEvent, and passing them to fn.
+func decodingEventFunc(fn func(agents.StreamEvent) error) func(Event) error {
+	var decoder Decoder
+	return func(event Event) error {
+		streamEvent, err := decoder.DecodeEvent(event)
+		if err != nil {
+			return err
+		}
+		return fn(streamEvent)
+	}
+}
+
+// RunEvents is like Run, but calls fn with the wire representation of each
+// streaming event, without converting it to agents.StreamEvent.
+// The terminal events are not passed to fn.
+func (c Client) RunEvents(ctx context.Context, input agents.Input, fn func(Event) error) (*ClientResult, error) {
+	req, err := NewRequest(input)
+	if err != nil {
+		return nil, err
+	}
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Accept", "text/event-stream")
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	resp, err := httpClient.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return nil, fmt.Errorf("unexpected HTTP status %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+
+	var result *ClientResult
+	err = ReadEvents(resp.Body, func(event Event) (err error) {
+		result, err = handleEvent(event, fn)
+		if result != nil {
+			return errStopReading
+		}
+		return err
+	})
+	if err != nil && !errors.Is(err, errStopReading) {
+		return nil, err
+	}
+	if result == nil {
+		return nil, errors.New("event stream ended without a final event")
+	}
+	return result, nil
+}
+
+// handleEvent passes a non-terminal event to fn. For the terminal events, it
+// returns either the result of the run, or a RunFailedError.
+func handleEvent(event Event, fn func(Event) error) (*ClientResult, error) {
+	switch event.Type {
+	case EventTypeRunCompleted:
+		return &ClientResult{
+			LastAgentName: event.AgentName,
+			FinalOutput:   event.FinalOutput,
+		}, nil
+	case EventTypeRunFailed:
+		return nil, RunFailedError{Message: event.Error}
+	default:
+		return nil, fn(event)
+	}
+}
+
+var errStopReading = errors.New("stop reading")
+
+// ReadEvents parses Server-Sent Events from r, as written by WriteEvent,
+// and calls fn for each of them, until the end of the stream or until
+// fn returns an error.
+func ReadEvents(r io.Reader, fn func(Event) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+	var data bytes.Buffer
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if line == "" {
+			if data.Len() == 0 {
+				continue
+			}
+			var event Event
+			if err := json.Unmarshal(data.Bytes(), &event); err != nil {
+				return fmt.Errorf("failed to unmarshal event: %w", err)
+			}
+			data.Reset()
+			if err := fn(event); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if value, ok := strings.CutPrefix(line, "data:"); ok {
+			if data.Len() > 0 {
+				data.WriteByte('\n')
+			}
+			data.WriteString(strings.TrimPrefix(value, " "))
+		}
+		// Other fields ("event", "id", "retry") and comments are not needed:
+		// the event type is also part of the JSON data.
+	}
+	return scanner.Err()
+}
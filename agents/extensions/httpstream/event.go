@@ -0,0 +1,412 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package httpstream bridges streamed agent runs over HTTP, using either
+// Server-Sent Events or WebSocket.
+//
+// It defines a stable JSON representation for agents.StreamEvent values,
+// http.Handler implementations which run an agent and stream its events
+// (Handler for SSE, WebSocketHandler for WebSocket), and the matching clients
+// (Client and WebSocketClient) which rebuild the events on the other side.
+package httpstream
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/openai/openai-go/responses"
+)
+
+const (
+	// EventTypeRawResponse is the Event.Type of an encoded agents.RawResponsesStreamEvent.
+	EventTypeRawResponse = "raw_response_event"
+
+	// EventTypeRunItem is the Event.Type of an encoded agents.RunItemStreamEvent.
+	EventTypeRunItem = "run_item_stream_event"
+
+	// EventTypeAgentUpdated is the Event.Type of an encoded agents.AgentUpdatedStreamEvent.
+	EventTypeAgentUpdated = "agent_updated_stream_event"
+
+	// EventTypeRunCompleted is the Event.Type of the last event sent when a run succeeds.
+	EventTypeRunCompleted = "run_completed"
+
+	// EventTypeRunFailed is the Event.Type of the last event sent when a run fails.
+	EventTypeRunFailed = "run_failed"
+)
+
+// Event is the wire representation of a streaming event.
+//
+// Besides the three agents.StreamEvent types, a stream is always terminated
+// by either an EventTypeRunCompleted or an EventTypeRunFailed event.
+type Event struct {
+	// The type of the event. See the EventType* constants.
+	Type string `json:"type"`
+
+	// The raw response event from the LLM, for EventTypeRawResponse events.
+	Data json.RawMessage `json:"data,omitempty"`
+
+	// The name of the run item event, for EventTypeRunItem events.
+	Name agents.RunItemStreamEventName `json:"name,omitempty"`
+
+	// The item that was created, for EventTypeRunItem events.
+	Item *Item `json:"item,omitempty"`
+
+	// The name of the new agent for EventTypeAgentUpdated events, or the name
+	// of the last agent for EventTypeRunCompleted events.
+	AgentName string `json:"agent_name,omitempty"`
+
+	// The depth of the call stack of agents returning to their callers, for
+	// EventTypeAgentUpdated events.
+	CallStackDepth int `json:"call_stack_depth,omitempty"`
+
+	// The final output of the run, for EventTypeRunCompleted events.
+	FinalOutput json.RawMessage `json:"final_output,omitempty"`
+
+	// The error message, for EventTypeRunFailed events.
+	Error string `json:"error,omitempty"`
+}
+
+// Item is the wire representation of an agents.RunItem.
+type Item struct {
+	// The type of the item, such as "message_output_item" or "tool_call_item".
+	Type string `json:"type"`
+
+	// The name of the agent whose run caused this item to be generated.
+	AgentName string `json:"agent_name"`
+
+	// The name of the agent that made the handoff, for "handoff_output_item" items.
+	SourceAgentName string `json:"source_agent_name,omitempty"`
+
+	// The name of the agent being handed off to, for "handoff_output_item" items.
+	TargetAgentName string `json:"target_agent_name,omitempty"`
+
+	// The raw item, in OpenAI Responses format.
+	RawItem json.RawMessage `json:"raw_item"`
+
+	// The output of the tool call, for "tool_call_output_item" items.
+	Output json.RawMessage `json:"output,omitempty"`
+
+	// The name of the guardrail which gave the feedback, for "guardrail_feedback_item" items.
+	GuardrailName string `json:"guardrail_name,omitempty"`
+
+	// The validation error of the output, for "output_repair_item" items.
+	Error string `json:"error,omitempty"`
+}
+
+// EncodeEvent converts a streaming event to its wire representation.
+func EncodeEvent(event agents.StreamEvent) (*Event, error) {
+	switch e := event.(type) {
+	case agents.RawResponsesStreamEvent:
+		data, err := marshalRawResponseEvent(e.Data)
+		if err != nil {
+			return nil, err
+		}
+		return &Event{Type: EventTypeRawResponse, Data: data}, nil
+	case agents.RunItemStreamEvent:
+		item, err := EncodeItem(e.Item)
+		if err != nil {
+			return nil, err
+		}
+		return &Event{Type: EventTypeRunItem, Name: e.Name, Item: item}, nil
+	case agents.AgentUpdatedStreamEvent:
+		return &Event{
+			Type:           EventTypeAgentUpdated,
+			AgentName:      agentName(e.NewAgent),
+			CallStackDepth: e.CallStackDepth,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unexpected StreamEvent type %T", event)
+	}
+}
+
+func marshalRawResponseEvent(event agents.TResponseStreamEvent) ([]byte, error) {
+	if raw := event.RawJSON(); raw != "" {
+		return []byte(raw), nil
+	}
+	return json.Marshal(event)
+}
+
+// EncodeItem converts a run item to its wire representation.
+func EncodeItem(runItem agents.RunItem) (*Item, error) {
+	var (
+		item   = new(Item)
+		raw    any
+		output any
+	)
+
+	switch v := runItem.(type) {
+	case agents.MessageOutputItem:
+		item.Type = "message_output_item"
+		item.AgentName = agentName(v.Agent)
+		raw = v.RawItem
+	case agents.HandoffCallItem:
+		item.Type = "handoff_call_item"
+		item.AgentName = agentName(v.Agent)
+		raw = v.RawItem
+	case agents.HandoffOutputItem:
+		item.Type = "handoff_output_item"
+		item.AgentName = agentName(v.Agent)
+		item.SourceAgentName = agentName(v.SourceAgent)
+		item.TargetAgentName = agentName(v.TargetAgent)
+		raw = v.RawItem
+	case agents.ToolCallItem:
+		item.Type = "tool_call_item"
+		item.AgentName = agentName(v.Agent)
+		raw = v.RawItem
+	case agents.ToolCallOutputItem:
+		item.Type = "tool_call_output_item"
+		item.AgentName = agentName(v.Agent)
+		raw = v.ToInputItem()
+		output = v.Output
+	case agents.ReasoningItem:
+		item.Type = "reasoning_item"
+		item.AgentName = agentName(v.Agent)
+		raw = v.RawItem
+	case agents.GuardrailFeedbackItem:
+		item.Type = "guardrail_feedback_item"
+		item.AgentName = agentName(v.Agent)
+		item.GuardrailName = v.GuardrailName
+		raw = v.RawItem
+	case agents.OutputRepairItem:
+		item.Type = "output_repair_item"
+		item.AgentName = agentName(v.Agent)
+		item.Error = v.Error
+		raw = v.RawItem
+	default:
+		return nil, fmt.Errorf("unexpected RunItem type %T", runItem)
+	}
+
+	var err error
+	item.RawItem, err = json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s raw item: %w", item.Type, err)
+	}
+
+	if output != nil {
+		item.Output, err = json.Marshal(output)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal %s output: %w", item.Type, err)
+		}
+	}
+
+	return item, nil
+}
+
+// Decoder rebuilds streaming events from their wire representation.
+//
+// Agents are not transferred over the wire: the Decoder creates a placeholder
+// Agent for each distinct name it encounters, carrying only the Name, and
+// reuses it for all subsequent events referencing the same agent.
+// The zero value is ready to use.
+type Decoder struct {
+	agents map[string]*agents.Agent
+}
+
+// Agent returns the placeholder Agent for the given name.
+func (d *Decoder) Agent(name string) *agents.Agent {
+	if d.agents == nil {
+		d.agents = make(map[string]*agents.Agent)
+	}
+	a, ok := d.agents[name]
+	if !ok {
+		a = &agents.Agent{Name: name}
+		d.agents[name] = a
+	}
+	return a
+}
+
+// DecodeEvent converts the wire representation of an event back to an agents.StreamEvent.
+//
+// Only EventTypeRawResponse, EventTypeRunItem and EventTypeAgentUpdated events
+// can be converted.
+func (d *Decoder) DecodeEvent(event Event) (agents.StreamEvent, error) {
+	switch event.Type {
+	case EventTypeRawResponse:
+		var data agents.TResponseStreamEvent
+		if err := json.Unmarshal(event.Data, &data); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal raw response event: %w", err)
+		}
+		return agents.RawResponsesStreamEvent{
+			Data: data,
+			Type: EventTypeRawResponse,
+		}, nil
+	case EventTypeRunItem:
+		if event.Item == nil {
+			return nil, fmt.Errorf("%s event without item", event.Type)
+		}
+		item, err := d.DecodeItem(*event.Item)
+		if err != nil {
+			return nil, err
+		}
+		return agents.RunItemStreamEvent{
+			Name: event.Name,
+			Item: item,
+			Type: EventTypeRunItem,
+		}, nil
+	case EventTypeAgentUpdated:
+		return agents.AgentUpdatedStreamEvent{
+			NewAgent:       d.Agent(event.AgentName),
+			CallStackDepth: event.CallStackDepth,
+			Type:           EventTypeAgentUpdated,
+		}, nil
+	default:
+		return nil, fmt.Errorf("event type %q cannot be converted to a StreamEvent", event.Type)
+	}
+}
+
+// DecodeItem converts the wire representation of a run item back to an agents.RunItem.
+func (d *Decoder) DecodeItem(item Item) (agents.RunItem, error) {
+	agent := d.Agent(item.AgentName)
+
+	switch item.Type {
+	case "message_output_item":
+		var raw responses.ResponseOutputMessage
+		if err := unmarshalRawItem(item, &raw); err != nil {
+			return nil, err
+		}
+		return agents.MessageOutputItem{Agent: agent, RawItem: raw, Type: item.Type}, nil
+	case "handoff_call_item":
+		var raw responses.ResponseFunctionToolCall
+		if err := unmarshalRawItem(item, &raw); err != nil {
+			return nil, err
+		}
+		return agents.HandoffCallItem{Agent: agent, RawItem: raw, Type: item.Type}, nil
+	case "handoff_output_item":
+		var raw agents.TResponseInputItem
+		if err := unmarshalRawItem(item, &raw); err != nil {
+			return nil, err
+		}
+		return agents.HandoffOutputItem{
+			Agent:       agent,
+			RawItem:     raw,
+			SourceAgent: d.Agent(item.SourceAgentName),
+			TargetAgent: d.Agent(item.TargetAgentName),
+			Type:        item.Type,
+		}, nil
+	case "tool_call_item":
+		raw, err := decodeToolCallItemType(item)
+		if err != nil {
+			return nil, err
+		}
+		return agents.ToolCallItem{Agent: agent, RawItem: raw, Type: item.Type}, nil
+	case "tool_call_output_item":
+		raw, err := decodeToolCallOutputRawItem(item)
+		if err != nil {
+			return nil, err
+		}
+		var output any
+		if len(item.Output) > 0 {
+			if err = json.Unmarshal(item.Output, &output); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal %s output: %w", item.Type, err)
+			}
+		}
+		return agents.ToolCallOutputItem{Agent: agent, RawItem: raw, Output: output, Type: item.Type}, nil
+	case "reasoning_item":
+		var raw responses.ResponseReasoningItem
+		if err := unmarshalRawItem(item, &raw); err != nil {
+			return nil, err
+		}
+		return agents.ReasoningItem{Agent: agent, RawItem: raw, Type: item.Type}, nil
+	case "guardrail_feedback_item":
+		var raw responses.EasyInputMessageParam
+		if err := unmarshalRawItem(item, &raw); err != nil {
+			return nil, err
+		}
+		return agents.GuardrailFeedbackItem{Agent: agent, GuardrailName: item.GuardrailName, RawItem: raw, Type: item.Type}, nil
+	case "output_repair_item":
+		var raw responses.EasyInputMessageParam
+		if err := unmarshalRawItem(item, &raw); err != nil {
+			return nil, err
+		}
+		return agents.OutputRepairItem{Agent: agent, Error: item.Error, RawItem: raw, Type: item.Type}, nil
+	default:
+		return nil, fmt.Errorf("unexpected item type %q", item.Type)
+	}
+}
+
+func decodeToolCallItemType(item Item) (agents.ToolCallItemType, error) {
+	rawType, err := rawItemType(item)
+	if err != nil {
+		return nil, err
+	}
+
+	switch rawType {
+	case "function_call":
+		var raw agents.ResponseFunctionToolCall
+		return raw, unmarshalRawItem(item, (*responses.ResponseFunctionToolCall)(&raw))
+	case "computer_call":
+		var raw agents.ResponseComputerToolCall
+		return raw, unmarshalRawItem(item, (*responses.ResponseComputerToolCall)(&raw))
+	case "local_shell_call":
+		var raw agents.ResponseOutputItemLocalShellCall
+		return raw, unmarshalRawItem(item, (*responses.ResponseOutputItemLocalShellCall)(&raw))
+	case "file_search_call":
+		var raw agents.ResponseFileSearchToolCall
+		return raw, unmarshalRawItem(item, (*responses.ResponseFileSearchToolCall)(&raw))
+	case "web_search_call":
+		var raw agents.ResponseFunctionWebSearch
+		return raw, unmarshalRawItem(item, (*responses.ResponseFunctionWebSearch)(&raw))
+	case "code_interpreter_call":
+		var raw agents.ResponseCodeInterpreterToolCall
+		return raw, unmarshalRawItem(item, (*responses.ResponseCodeInterpreterToolCall)(&raw))
+	case "image_generation_call":
+		var raw agents.ResponseOutputItemImageGenerationCall
+		return raw, unmarshalRawItem(item, (*responses.ResponseOutputItemImageGenerationCall)(&raw))
+	default:
+		return nil, fmt.Errorf("unexpected tool call type %q", rawType)
+	}
+}
+
+func decodeToolCallOutputRawItem(item Item) (agents.ToolCallOutputRawItem, error) {
+	var raw agents.TResponseInputItem
+	if err := unmarshalRawItem(item, &raw); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case raw.OfFunctionCallOutput != nil:
+		return agents.ResponseInputItemFunctionCallOutputParam(*raw.OfFunctionCallOutput), nil
+	case raw.OfComputerCallOutput != nil:
+		return agents.ResponseInputItemComputerCallOutputParam(*raw.OfComputerCallOutput), nil
+	case raw.OfLocalShellCallOutput != nil:
+		return agents.ResponseInputItemLocalShellCallOutputParam(*raw.OfLocalShellCallOutput), nil
+	default:
+		return nil, fmt.Errorf("unexpected tool call output raw item %s", item.RawItem)
+	}
+}
+
+func rawItemType(item Item) (string, error) {
+	var v struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(item.RawItem, &v); err != nil {
+		return "", fmt.Errorf("failed to unmarshal %s raw item type: %w", item.Type, err)
+	}
+	return v.Type, nil
+}
+
+func unmarshalRawItem(item Item, v any) error {
+	if err := json.Unmarshal(item.RawItem, v); err != nil {
+		return fmt.Errorf("failed to unmarshal %s raw item: %w", item.Type, err)
+	}
+	return nil
+}
+
+func agentName(agent *agents.Agent) string {
+	if agent == nil {
+		return ""
+	}
+	return agent.Name
+}
@@ -0,0 +1,236 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpstream
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+)
+
+// Request is the JSON body accepted by Handler, and sent by Client.
+type Request struct {
+	// The run input: either a JSON string, or a list of input items in
+	// OpenAI Responses format.
+	Input json.RawMessage `json:"input"`
+}
+
+// NewRequest creates a Request for the given run input.
+func NewRequest(input agents.Input) (*Request, error) {
+	var v any
+	switch input := input.(type) {
+	case agents.InputString:
+		v = input.String()
+	case agents.InputItems:
+		v = input
+	default:
+		return nil, fmt.Errorf("unexpected Input type %T", input)
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal input: %w", err)
+	}
+	return &Request{Input: b}, nil
+}
+
+// RunInput converts the request input back to agents.Input.
+func (r Request) RunInput() (agents.Input, error) {
+	raw := bytes.TrimSpace(r.Input)
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return nil, errors.New("missing input")
+	}
+
+	if raw[0] == '"' {
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal string input: %w", err)
+		}
+		return agents.InputString(s), nil
+	}
+
+	var rawItems []json.RawMessage
+	if err := json.Unmarshal(raw, &rawItems); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal input items: %w", err)
+	}
+	items := make(agents.InputItems, len(rawItems))
+	for i, rawItem := range rawItems {
+		if err := UnmarshalInputItem(rawItem, &items[i]); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal input item %d: %w", i, err)
+		}
+	}
+	return items, nil
+}
+
+// UnmarshalInputItem unmarshals a single input item in OpenAI Responses format.
+//
+// Unlike the plain JSON unmarshaling of agents.TResponseInputItem, it also
+// accepts messages without the optional "type" field.
+func UnmarshalInputItem(data []byte, item *agents.TResponseInputItem) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	if _, hasType := fields["type"]; !hasType {
+		if _, hasRole := fields["role"]; hasRole {
+			fields["type"] = json.RawMessage(`"message"`)
+			var err error
+			if data, err = json.Marshal(fields); err != nil {
+				return err
+			}
+		}
+	}
+	return json.Unmarshal(data, item)
+}
+
+// DecodeRequest is the default Handler.InputFunc: it reads a JSON Request
+// from the HTTP request body.
+func DecodeRequest(r *http.Request) (agents.Input, error) {
+	var req Request
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, fmt.Errorf("failed to decode request body: %w", err)
+	}
+	return req.RunInput()
+}
+
+// Handler is an http.Handler which runs an agent in streaming mode and sends
+// its events to the client as Server-Sent Events.
+//
+// Each SSE message has the event type as `event` field, and the JSON-encoded
+// Event as `data` field. The stream is always terminated by an
+// EventTypeRunCompleted or EventTypeRunFailed event.
+//
+// If the client disconnects, the run is canceled with RunResultStreaming.Cancel.
+type Handler struct {
+	// The agent to run.
+	Agent *agents.Agent
+
+	// The runner used to execute the agent. The zero value is valid.
+	Runner agents.Runner
+
+	// Optional function that extracts the run input from the HTTP request.
+	// Default (when nil): DecodeRequest.
+	InputFunc func(*http.Request) (agents.Input, error)
+}
+
+func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	inputFunc := h.InputFunc
+	if inputFunc == nil {
+		inputFunc = DecodeRequest
+	}
+	input, err := inputFunc(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	result, err := runStreamed(ctx, h.Runner, h.Agent, input)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	stop := context.AfterFunc(ctx, result.Cancel)
+	defer stop()
+
+	header := w.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	err = result.StreamEvents(func(event agents.StreamEvent) error {
+		if err := ctx.Err(); err != nil {
+			result.Cancel()
+			return err
+		}
+		e, err := EncodeEvent(event)
+		if err != nil {
+			return err
+		}
+		if err = WriteEvent(w, *e); err != nil {
+			result.Cancel()
+			return err
+		}
+		flusher.Flush()
+		return nil
+	})
+
+	if ctx.Err() != nil {
+		// The client went away: nobody is left to receive the final event.
+		return
+	}
+	if err = WriteEvent(w, finalEvent(result, err)); err == nil {
+		flusher.Flush()
+	}
+}
+
+// runStreamed starts a streamed run of the agent with the given input.
+func runStreamed(ctx context.Context, runner agents.Runner, agent *agents.Agent, input agents.Input) (*agents.RunResultStreaming, error) {
+	switch input := input.(type) {
+	case agents.InputString:
+		return runner.RunStreamed(ctx, agent, input.String())
+	case agents.InputItems:
+		return runner.RunResponseInputsStreamed(ctx, agent, input)
+	default:
+		return nil, fmt.Errorf("unexpected Input type %T", input)
+	}
+}
+
+// finalEvent returns the event terminating the stream of a run, which
+// ended with the given error from RunResultStreaming.StreamEvents.
+func finalEvent(result *agents.RunResultStreaming, err error) Event {
+	if err != nil {
+		return Event{Type: EventTypeRunFailed, Error: err.Error()}
+	}
+	finalOutput, err := json.Marshal(result.FinalOutput())
+	if err != nil {
+		return Event{Type: EventTypeRunFailed, Error: fmt.Sprintf("failed to marshal final output: %s", err)}
+	}
+	return Event{
+		Type:        EventTypeRunCompleted,
+		AgentName:   agentName(result.LastAgent()),
+		FinalOutput: finalOutput,
+	}
+}
+
+// WriteEvent writes a single Server-Sent Event message to w.
+func WriteEvent(w io.Writer, event Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data)
+	return err
+}
@@ -0,0 +1,284 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpstream_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/agents/extensions/httpstream"
+	"github.com/nlpodyssey/openai-agents-go/agentstesting"
+	"github.com/openai/openai-go/packages/param"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// transport is a pair of handler and client of the same wire protocol.
+type transport struct {
+	name    string
+	handler func(*agents.Agent) http.Handler
+	run     func(ctx context.Context, url string, input agents.Input, fn func(agents.StreamEvent) error) (*httpstream.ClientResult, error)
+}
+
+var transports = []transport{
+	{
+		name:    "SSE",
+		handler: func(agent *agents.Agent) http.Handler { return httpstream.Handler{Agent: agent} },
+		run: func(ctx context.Context, url string, input agents.Input, fn func(agents.StreamEvent) error) (*httpstream.ClientResult, error) {
+			return httpstream.Client{URL: url}.Run(ctx, input, fn)
+		},
+	},
+	{
+		name:    "WebSocket",
+		handler: func(agent *agents.Agent) http.Handler { return httpstream.WebSocketHandler{Agent: agent} },
+		run: func(ctx context.Context, url string, input agents.Input, fn func(agents.StreamEvent) error) (*httpstream.ClientResult, error) {
+			return httpstream.WebSocketClient{URL: url}.Run(ctx, input, fn)
+		},
+	},
+}
+
+func TestHandlerAndClientRoundTrip(t *testing.T) {
+	for _, tr := range transports {
+		t.Run(tr.name, func(t *testing.T) { testHandlerAndClientRoundTrip(t, tr) })
+	}
+}
+
+func testHandlerAndClientRoundTrip(t *testing.T, tr transport) {
+	model := agentstesting.NewFakeModel(nil)
+	agent := &agents.Agent{
+		Name:  "test",
+		Model: param.NewOpt(agents.NewAgentModel(model)),
+		Tools: []agents.Tool{
+			agentstesting.GetFunctionTool("foo", "tool_result"),
+		},
+	}
+
+	model.AddMultipleTurnOutputs([]agentstesting.FakeModelTurnOutput{
+		{Value: []agents.TResponseOutputItem{
+			agentstesting.GetTextMessage("a_message"),
+			agentstesting.GetFunctionToolCall("foo", `{"a": "b"}`),
+		}},
+		{Value: []agents.TResponseOutputItem{
+			agentstesting.GetTextMessage("done"),
+		}},
+	})
+
+	server := httptest.NewServer(tr.handler(agent))
+	defer server.Close()
+
+	var events []agents.StreamEvent
+	result, err := tr.run(
+		t.Context(),
+		server.URL,
+		agents.InputString("hello"),
+		func(event agents.StreamEvent) error {
+			events = append(events, event)
+			return nil
+		},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "test", result.LastAgentName)
+	assert.JSONEq(t, `"done"`, string(result.FinalOutput))
+
+	var (
+		agentUpdated  []*agents.Agent
+		rawResponses  []string
+		runItemEvents []agents.RunItemStreamEvent
+	)
+	for _, event := range events {
+		switch e := event.(type) {
+		case agents.AgentUpdatedStreamEvent:
+			agentUpdated = append(agentUpdated, e.NewAgent)
+		case agents.RawResponsesStreamEvent:
+			rawResponses = append(rawResponses, e.Data.Type)
+		case agents.RunItemStreamEvent:
+			runItemEvents = append(runItemEvents, e)
+		default:
+			t.Fatalf("unexpected event type %T", e)
+		}
+	}
+
+	require.Len(t, agentUpdated, 1)
+	assert.Equal(t, "test", agentUpdated[0].Name)
+	// The raw model events are forwarded as they are, including the deltas.
+	assert.Equal(t, []string{
+		"response.created",
+		"response.output_item.added",
+		"response.content_part.added",
+		"response.output_text.delta",
+		"response.output_text.done",
+		"response.content_part.done",
+		"response.output_item.done",
+		"response.output_item.added",
+		"response.function_call_arguments.delta",
+		"response.function_call_arguments.delta",
+		"response.function_call_arguments.done",
+		"response.output_item.done",
+		"response.completed",
+		"response.created",
+		"response.output_item.added",
+		"response.content_part.added",
+		"response.output_text.delta",
+		"response.output_text.done",
+		"response.content_part.done",
+		"response.output_item.done",
+		"response.completed",
+	}, rawResponses)
+
+	require.Len(t, runItemEvents, 4)
+
+	assert.Equal(t, agents.StreamEventMessageOutputCreated, runItemEvents[0].Name)
+	message := runItemEvents[0].Item.(agents.MessageOutputItem)
+	assert.Equal(t, "a_message", agents.ItemHelpers().TextMessageOutput(message))
+	assert.Same(t, agentUpdated[0], message.Agent)
+
+	assert.Equal(t, agents.StreamEventToolCalled, runItemEvents[1].Name)
+	toolCall := runItemEvents[1].Item.(agents.ToolCallItem).RawItem.(agents.ResponseFunctionToolCall)
+	assert.Equal(t, "foo", toolCall.Name)
+	assert.Equal(t, `{"a": "b"}`, toolCall.Arguments)
+
+	assert.Equal(t, agents.StreamEventToolOutput, runItemEvents[2].Name)
+	toolOutput := runItemEvents[2].Item.(agents.ToolCallOutputItem)
+	assert.Equal(t, "tool_result", toolOutput.Output)
+	rawOutput := toolOutput.RawItem.(agents.ResponseInputItemFunctionCallOutputParam)
+	assert.Equal(t, "2", rawOutput.CallID)
+	assert.Equal(t, "tool_result", rawOutput.Output)
+
+	assert.Equal(t, agents.StreamEventMessageOutputCreated, runItemEvents[3].Name)
+}
+
+func TestClientReportsRemoteFailure(t *testing.T) {
+	for _, tr := range transports {
+		t.Run(tr.name, func(t *testing.T) { testClientReportsRemoteFailure(t, tr) })
+	}
+}
+
+func testClientReportsRemoteFailure(t *testing.T, tr transport) {
+	model := agentstesting.NewFakeModel(&agentstesting.FakeModelTurnOutput{
+		Error: errors.New("model exploded"),
+	})
+	agent := &agents.Agent{
+		Name:  "test",
+		Model: param.NewOpt(agents.NewAgentModel(model)),
+	}
+
+	server := httptest.NewServer(tr.handler(agent))
+	defer server.Close()
+
+	_, err := tr.run(
+		t.Context(),
+		server.URL,
+		agents.InputItems{agentstesting.GetTextInputItem("hello")},
+		func(agents.StreamEvent) error { return nil },
+	)
+	var target httpstream.RunFailedError
+	require.ErrorAs(t, err, &target)
+	assert.Contains(t, target.Message, "model exploded")
+}
+
+func TestHandlerRejectsInvalidRequests(t *testing.T) {
+	server := httptest.NewServer(httpstream.Handler{Agent: &agents.Agent{Name: "test"}})
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+
+	_, err = httpstream.Client{URL: server.URL}.RunEvents(
+		t.Context(),
+		agents.InputItems(nil),
+		func(httpstream.Event) error { return nil },
+	)
+	require.ErrorContains(t, err, "400 Bad Request")
+}
+
+func TestWebSocketHandlerRejectsInvalidRequests(t *testing.T) {
+	server := httptest.NewServer(httpstream.WebSocketHandler{Agent: &agents.Agent{Name: "test"}})
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusUpgradeRequired, resp.StatusCode)
+
+	_, err = httpstream.WebSocketClient{URL: server.URL}.RunEvents(
+		t.Context(),
+		agents.InputItems(nil),
+		func(httpstream.Event) error { return nil },
+	)
+	var target httpstream.RunFailedError
+	require.ErrorAs(t, err, &target)
+	assert.Equal(t, "invalid request: missing input", target.Message)
+}
+
+func TestHandlerCancelsRunOnClientDisconnect(t *testing.T) {
+	for _, tr := range transports {
+		t.Run(tr.name, func(t *testing.T) { testHandlerCancelsRunOnClientDisconnect(t, tr) })
+	}
+}
+
+func testHandlerCancelsRunOnClientDisconnect(t *testing.T, tr transport) {
+	toolStarted := make(chan struct{})
+	model := agentstesting.NewFakeModel(&agentstesting.FakeModelTurnOutput{
+		Value: []agents.TResponseOutputItem{
+			agentstesting.GetFunctionToolCall("slow", `{}`),
+		},
+	})
+	agent := &agents.Agent{
+		Name:  "test",
+		Model: param.NewOpt(agents.NewAgentModel(model)),
+		Tools: []agents.Tool{
+			agents.FunctionTool{
+				Name:             "slow",
+				ParamsJSONSchema: map[string]any{"type": "object"},
+				OnInvokeTool: func(ctx context.Context, _ string) (any, error) {
+					close(toolStarted)
+					<-ctx.Done()
+					return nil, ctx.Err()
+				},
+			},
+		},
+	}
+
+	handlerDone := make(chan struct{})
+	handler := tr.handler(agent)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer close(handlerDone)
+		handler.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(t.Context())
+	go func() {
+		<-toolStarted
+		cancel()
+	}()
+
+	_, err := tr.run(ctx, server.URL, agents.InputString("hello"),
+		func(agents.StreamEvent) error { return nil })
+	require.ErrorIs(t, err, context.Canceled)
+
+	select {
+	case <-handlerDone:
+	case <-time.After(5 * time.Second):
+		t.Fatal("handler did not return after client disconnection")
+	}
+}
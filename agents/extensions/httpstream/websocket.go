@@ -0,0 +1,177 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpstream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/coder/websocket"
+	"github.com/coder/websocket/wsjson"
+	"github.com/nlpodyssey/openai-agents-go/agents"
+)
+
+// maxMessageSize is the maximum size of the messages read by WebSocketClient,
+// matching the maximum line length of ReadEvents.
+const maxMessageSize = 16 * 1024 * 1024
+
+// WebSocketHandler is an http.Handler which runs an agent in streaming mode
+// and sends its events to the client over a WebSocket connection.
+//
+// The client sends a JSON Request as first text message. Each event is then
+// sent as a text message with the JSON-encoded Event. The stream is always
+// terminated by an EventTypeRunCompleted or EventTypeRunFailed event, after
+// which the connection is closed normally. Invalid requests are reported
+// with an EventTypeRunFailed event as well.
+//
+// If the client disconnects, the run is canceled with RunResultStreaming.Cancel.
+type WebSocketHandler struct {
+	// The agent to run.
+	Agent *agents.Agent
+
+	// The runner used to execute the agent. The zero value is valid.
+	Runner agents.Runner
+
+	// Optional options for accepting the connections, such as the allowed
+	// origins.
+	AcceptOptions *websocket.AcceptOptions
+}
+
+func (h WebSocketHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	conn, err := websocket.Accept(w, r, h.AcceptOptions)
+	if err != nil {
+		// Accept has already replied with an error.
+		return
+	}
+	defer func() { _ = conn.CloseNow() }()
+
+	ctx := r.Context()
+	var req Request
+	if err = wsjson.Read(ctx, conn, &req); err != nil {
+		return
+	}
+
+	// Further messages are not expected: the context is canceled when the
+	// client goes away.
+	ctx = conn.CloseRead(ctx)
+
+	input, err := req.RunInput()
+	if err != nil {
+		h.finish(ctx, conn, Event{Type: EventTypeRunFailed, Error: fmt.Sprintf("invalid request: %s", err)})
+		return
+	}
+	result, err := runStreamed(ctx, h.Runner, h.Agent, input)
+	if err != nil {
+		h.finish(ctx, conn, Event{Type: EventTypeRunFailed, Error: err.Error()})
+		return
+	}
+
+	stop := context.AfterFunc(ctx, result.Cancel)
+	defer stop()
+
+	err = result.StreamEvents(func(event agents.StreamEvent) error {
+		if err := ctx.Err(); err != nil {
+			result.Cancel()
+			return err
+		}
+		e, err := EncodeEvent(event)
+		if err != nil {
+			return err
+		}
+		if err = wsjson.Write(ctx, conn, e); err != nil {
+			result.Cancel()
+			return err
+		}
+		return nil
+	})
+
+	if ctx.Err() != nil {
+		// The client went away: nobody is left to receive the final event.
+		return
+	}
+	h.finish(ctx, conn, finalEvent(result, err))
+}
+
+// finish sends the last event and closes the connection.
+func (WebSocketHandler) finish(ctx context.Context, conn *websocket.Conn, event Event) {
+	if err := wsjson.Write(ctx, conn, event); err == nil {
+		_ = conn.Close(websocket.StatusNormalClosure, "")
+	}
+}
+
+// WebSocketClient consumes the events sent by a WebSocketHandler.
+type WebSocketClient struct {
+	// The URL of the WebSocketHandler endpoint, with "ws", "wss", "http" or
+	// "https" scheme.
+	URL string
+
+	// Optional options for dialing the connections, such as the HTTP client
+	// and headers.
+	DialOptions *websocket.DialOptions
+}
+
+// Run sends the input to the remote WebSocketHandler and calls fn for each
+// streaming event, rebuilt as agents.StreamEvent.
+//
+// It behaves like Client.Run.
+func (c WebSocketClient) Run(ctx context.Context, input agents.Input, fn func(agents.StreamEvent) error) (*ClientResult, error) {
+	return c.RunEvents(ctx, input, decodingEventFunc(fn))
+}
+
+// RunEvents is like Run, but calls fn with the wire representation of each
+// streaming event, without converting it to agents.StreamEvent.
+// The terminal events are not passed to fn.
+func (c WebSocketClient) RunEvents(ctx context.Context, input agents.Input, fn func(Event) error) (*ClientResult, error) {
+	req, err := NewRequest(input)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, _, err := websocket.Dial(ctx, c.URL, c.DialOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = conn.CloseNow() }()
+	conn.SetReadLimit(maxMessageSize)
+
+	if err = wsjson.Write(ctx, conn, req); err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+
+	for {
+		var event Event
+		if err = wsjson.Read(ctx, conn, &event); err != nil {
+			switch {
+			case ctx.Err() != nil:
+				return nil, ctx.Err()
+			case websocket.CloseStatus(err) == websocket.StatusNormalClosure:
+				return nil, errors.New("event stream ended without a final event")
+			default:
+				return nil, err
+			}
+		}
+
+		result, err := handleEvent(event, fn)
+		if err != nil {
+			return nil, err
+		}
+		if result != nil {
+			_ = conn.Close(websocket.StatusNormalClosure, "")
+			return result, nil
+		}
+	}
+}
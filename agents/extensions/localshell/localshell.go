@@ -0,0 +1,255 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package localshell provides sandboxed executors for agents.LocalShellTool.
+//
+// An Executor runs each command requested by the model as a separate process,
+// honoring the command, environment, working directory, timeout and user of
+// the action, within the limits of a Config: allow-lists, empty by default,
+// of programs, users and environment variables, a root directory the working
+// directories are confined to (or, with Chroot, the whole file system of the
+// commands), timeouts and output size caps. A Session runs the commands in a
+// persistent shell instead, keeping its state, such as the current directory
+// and the exported variables, between calls.
+//
+// The output sent to the model is a Result encoded as JSON, with the
+// captured stdout, stderr and exit code. Commands rejected by the Config, or
+// which cannot be started, are reported to the model in the same way, so that
+// it can try something else.
+//
+// Example:
+//
+//	executor, err := localshell.NewExecutor(localshell.Config{
+//		Root:            "/srv/workspace",
+//		AllowedCommands: []string{"ls", "cat", "grep", "git"},
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	agent := agents.New("Assistant").
+//		WithModel("codex-mini-latest").
+//		WithTools(agents.LocalShellTool{Executor: executor.Execute})
+package localshell
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"time"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/openai/openai-go/responses"
+)
+
+const (
+	// DefaultTimeout is the default timeout of commands not requesting one.
+	DefaultTimeout = 30 * time.Second
+
+	// DefaultMaxTimeout is the default maximum timeout a command may request.
+	DefaultMaxTimeout = 5 * time.Minute
+
+	// DefaultMaxOutputBytes is the default maximum number of bytes of stdout
+	// and stderr, each, reported to the model.
+	DefaultMaxOutputBytes = 64 * 1024
+
+	// DefaultPath is the default PATH used to look up programs.
+	DefaultPath = "/usr/local/bin:/usr/bin:/bin"
+)
+
+// Config is the sandboxing policy of an Executor or a Session.
+type Config struct {
+	// The directory commands are confined to. Working directories requested
+	// by the model are resolved relative to it, and must not escape it, even
+	// through symbolic links. Without Chroot, this does not prevent commands
+	// from accessing files outside the root, for example by absolute paths.
+	// Default: the current working directory.
+	Root string
+
+	// Run the commands chrooted into Root, so that they cannot access files
+	// outside it. The root must contain the programs and the libraries the
+	// commands need, and absolute paths requested by the model are resolved
+	// inside it. Requires privileges; Unix only.
+	Chroot bool
+
+	// The programs the model may run. A program requested by name, such as
+	// "ls", matches an entry with the same name; a program requested by path,
+	// such as "/bin/ls", must match an entry with the same path. Programs
+	// requested by name are looked up in the PATH of Env, ignoring the PATH
+	// requested by the model.
+	//
+	// Allowing a shell, such as "bash", allows any command through
+	// "bash -c". Default: none, unless AllowAnyCommand is set.
+	AllowedCommands []string
+
+	// Allow the model to run any program, ignoring AllowedCommands.
+	AllowAnyCommand bool
+
+	// The user commands run as when the model does not request one.
+	// Default: the user of the current process.
+	User string
+
+	// The other users the model may request. Running commands as another user
+	// requires privileges; Unix only.
+	AllowedUsers []string
+
+	// The base environment of the commands, to which the variables requested
+	// by the model are added. Default: PATH only, set to DefaultPath.
+	Env map[string]string
+
+	// The environment variables the model may set. Variables which can run
+	// arbitrary code or change the programs being run, such as LD_PRELOAD,
+	// BASH_ENV, PATH or GIT_SSH_COMMAND, are always rejected, even if listed.
+	// Default: none.
+	AllowedEnv []string
+
+	// The timeout of commands not requesting one. Default: DefaultTimeout.
+	Timeout time.Duration
+
+	// The maximum timeout a command may request. Default: DefaultMaxTimeout.
+	MaxTimeout time.Duration
+
+	// The maximum number of bytes of stdout and stderr, each, reported to the
+	// model. Longer outputs are truncated. Default: DefaultMaxOutputBytes.
+	MaxOutputBytes int
+
+	// The shell of a Session, looked up like the programs (but not subject to
+	// AllowedCommands). Default: "sh".
+	Shell string
+}
+
+// Result is the outcome of a command, sent to the model as JSON.
+type Result struct {
+	Stdout string `json:"stdout"`
+	Stderr string `json:"stderr"`
+
+	// The exit code of the command, or -1 if it did not exit normally.
+	ExitCode int `json:"exit_code"`
+
+	// Whether the command was killed because it exceeded its timeout.
+	TimedOut bool `json:"timed_out,omitempty"`
+
+	// Whether stdout or stderr were truncated to Config.MaxOutputBytes.
+	Truncated bool `json:"truncated,omitempty"`
+
+	// Why the command could not run or complete, if so.
+	Error string `json:"error,omitempty"`
+}
+
+func errorResult(err error) Result {
+	return Result{ExitCode: -1, Error: err.Error()}
+}
+
+// String returns the result encoded as JSON.
+func (r Result) String() string {
+	b, _ := json.Marshal(r) // cannot fail
+	return string(b)
+}
+
+// Executor runs each command in a new process. It is safe for concurrent use.
+type Executor struct {
+	sandbox *sandbox
+}
+
+// NewExecutor returns an Executor enforcing the given configuration.
+func NewExecutor(config Config) (*Executor, error) {
+	s, err := newSandbox(config)
+	if err != nil {
+		return nil, err
+	}
+	return &Executor{sandbox: s}, nil
+}
+
+// Execute is an agents.LocalShellExecutor running the command of the request.
+// It only fails if ctx is done.
+func (e *Executor) Execute(ctx context.Context, request agents.LocalShellCommandRequest) (string, error) {
+	result := e.Run(ctx, request.Data.Action)
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	return result.String(), nil
+}
+
+// Run runs the command of the action.
+func (e *Executor) Run(ctx context.Context, action responses.ResponseOutputItemLocalShellCallAction) Result {
+	c, err := e.sandbox.command(action)
+	if err != nil {
+		return errorResult(err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, c.path)
+	cmd.Args = c.args
+	cmd.Dir = c.dir
+	cmd.Env = c.env
+	if err = e.sandbox.setSysProcAttr(cmd, c.credential); err != nil {
+		return errorResult(err)
+	}
+	cmd.Cancel = func() error { return killProcessGroup(cmd) }
+	cmd.WaitDelay = time.Second
+
+	stdout := newCappedBuffer(e.sandbox.maxOutputBytes)
+	stderr := newCappedBuffer(e.sandbox.maxOutputBytes)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	err = cmd.Run()
+	result := Result{
+		Stdout:    stdout.String(),
+		Stderr:    stderr.String(),
+		Truncated: stdout.truncated || stderr.truncated,
+	}
+
+	var exitErr *exec.ExitError
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		result.ExitCode = -1
+		result.TimedOut = true
+		result.Error = fmt.Sprintf("command timed out after %s", c.timeout)
+	case errors.As(err, &exitErr):
+		result.ExitCode = exitErr.ExitCode()
+	case err != nil:
+		result.ExitCode = -1
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// cappedBuffer keeps the first bytes written to it, up to a limit.
+type cappedBuffer struct {
+	buf       []byte
+	limit     int
+	truncated bool
+}
+
+func newCappedBuffer(limit int) *cappedBuffer {
+	return &cappedBuffer{limit: limit}
+}
+
+func (b *cappedBuffer) Write(p []byte) (int, error) {
+	n := len(p)
+	if room := b.limit - len(b.buf); len(p) > room {
+		p = p[:max(room, 0)]
+		b.truncated = true
+	}
+	b.buf = append(b.buf, p...)
+	return n, nil
+}
+
+func (b *cappedBuffer) String() string {
+	return string(b.buf)
+}
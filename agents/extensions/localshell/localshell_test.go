@@ -0,0 +1,209 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package localshell_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/agents/extensions/localshell"
+	"github.com/nlpodyssey/openai-agents-go/agentstesting"
+	"github.com/openai/openai-go/responses"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type action = responses.ResponseOutputItemLocalShellCallAction
+
+// newRoot returns a temporary root directory with a "sub" directory, and a
+// "link" symbolic link to the parent of the root.
+func newRoot(t *testing.T) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("requires a Unix shell")
+	}
+	root := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(root, "sub"), 0o755))
+	require.NoError(t, os.Symlink("..", filepath.Join(root, "link")))
+	return root
+}
+
+func TestExecutorRun(t *testing.T) {
+	root := newRoot(t)
+	executor, err := localshell.NewExecutor(localshell.Config{Root: root, AllowAnyCommand: true, AllowedEnv: []string{"GREETING"}})
+	require.NoError(t, err)
+
+	result := executor.Run(t.Context(), action{
+		Command:          []string{"sh", "-c", `pwd; echo "$GREETING" >&2; exit 3`},
+		Env:              map[string]string{"GREETING": "hello"},
+		WorkingDirectory: "sub",
+	})
+	resolvedRoot, err := filepath.EvalSymlinks(root)
+	require.NoError(t, err)
+	assert.Equal(t, localshell.Result{
+		Stdout:   filepath.Join(resolvedRoot, "sub") + "\n",
+		Stderr:   "hello\n",
+		ExitCode: 3,
+	}, result)
+
+	result = executor.Run(t.Context(), action{Command: []string{"env"}})
+	assert.Equal(t, "PATH="+localshell.DefaultPath+"\n", result.Stdout)
+}
+
+func TestExecutorPolicy(t *testing.T) {
+	root := newRoot(t)
+	executor, err := localshell.NewExecutor(localshell.Config{
+		Root:            root,
+		AllowedCommands: []string{"echo", "/bin/sh"},
+		AllowedEnv:      []string{"GREETING", "LD_PRELOAD"},
+	})
+	require.NoError(t, err)
+
+	for _, test := range []struct {
+		action action
+		error  string
+	}{
+		{action{Command: []string{"rm", "-rf", "/"}}, `command "rm" is not allowed`},
+		{action{Command: []string{"sh", "-c", "id"}}, `command "sh" is not allowed`},
+		{action{Command: []string{"/tmp/echo"}}, `command "/tmp/echo" is not allowed`},
+		{action{Command: []string{"echo"}, WorkingDirectory: ".."}, `working directory ".." is outside of the root`},
+		{action{Command: []string{"echo"}, WorkingDirectory: "link"}, `working directory "link" is outside of the root`},
+		{action{Command: []string{"echo"}, WorkingDirectory: "/"}, `working directory "/" is outside of the root`},
+		{action{Command: []string{"echo"}, User: "root"}, `user "root" is not allowed`},
+		{action{Command: []string{"echo"}, Env: map[string]string{"A;B": "x"}}, `invalid environment variable name "A;B"`},
+		{action{Command: []string{"echo"}, Env: map[string]string{"NAME": "x"}}, `environment variable "NAME" is not allowed`},
+		{action{Command: []string{"echo"}, Env: map[string]string{"LD_PRELOAD": "/tmp/x.so"}}, `environment variable "LD_PRELOAD" is not allowed`},
+		{action{Command: []string{"echo"}, Env: map[string]string{"BASH_ENV": "/tmp/x.sh"}}, `environment variable "BASH_ENV" is not allowed`},
+		{action{Command: []string{"echo"}, Env: map[string]string{"PATH": "/tmp"}}, `environment variable "PATH" is not allowed`},
+		{action{Command: []string{"echo"}, Env: map[string]string{"GIT_SSH_COMMAND": "id"}}, `environment variable "GIT_SSH_COMMAND" is not allowed`},
+		{action{}, "empty command"},
+	} {
+		result := executor.Run(t.Context(), test.action)
+		assert.Equal(t, localshell.Result{ExitCode: -1, Error: test.error}, result)
+	}
+
+	result := executor.Run(t.Context(), action{
+		Command:          []string{"/bin/sh", "-c", "echo ok"},
+		WorkingDirectory: filepath.Join(root, "sub"),
+	})
+	assert.Equal(t, localshell.Result{Stdout: "ok\n"}, result)
+}
+
+func TestExecutorDefaultDeniesCommands(t *testing.T) {
+	// Without AllowedCommands, no command is allowed, unless AllowAnyCommand is set.
+	root := newRoot(t)
+	executor, err := localshell.NewExecutor(localshell.Config{Root: root})
+	require.NoError(t, err)
+	result := executor.Run(t.Context(), action{Command: []string{"echo", "hi"}})
+	assert.Equal(t, localshell.Result{ExitCode: -1, Error: `command "echo" is not allowed`}, result)
+
+	session, err := localshell.NewSession(localshell.Config{Root: root})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = session.Close() })
+	result = session.Run(t.Context(), action{Command: []string{"echo", "hi"}})
+	assert.Equal(t, localshell.Result{ExitCode: -1, Error: `command "echo" is not allowed`}, result)
+
+	executor, err = localshell.NewExecutor(localshell.Config{Root: root, AllowAnyCommand: true})
+	require.NoError(t, err)
+	result = executor.Run(t.Context(), action{Command: []string{"echo", "hi"}})
+	assert.Equal(t, localshell.Result{Stdout: "hi\n"}, result)
+}
+
+func TestExecutorLimits(t *testing.T) {
+	root := newRoot(t)
+	executor, err := localshell.NewExecutor(localshell.Config{Root: root, AllowAnyCommand: true, MaxOutputBytes: 10})
+	require.NoError(t, err)
+
+	result := executor.Run(t.Context(), action{Command: []string{"sh", "-c", "echo 0123456789abcdef"}})
+	assert.Equal(t, localshell.Result{Stdout: "0123456789", Truncated: true}, result)
+
+	start := time.Now()
+	result = executor.Run(t.Context(), action{
+		Command:   []string{"sh", "-c", "sleep 10 & sleep 10; echo done"},
+		TimeoutMs: 100,
+	})
+	assert.Less(t, time.Since(start), 5*time.Second)
+	assert.Equal(t, localshell.Result{ExitCode: -1, TimedOut: true, Error: "command timed out after 100ms"}, result)
+}
+
+func TestSession(t *testing.T) {
+	root := newRoot(t)
+	session, err := localshell.NewSession(localshell.Config{Root: root, AllowAnyCommand: true, AllowedEnv: []string{"NAME"}})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = session.Close() })
+
+	result := session.Run(t.Context(), action{
+		Command:          []string{"bash", "-lc", "cd sub && COUNT=1"},
+		WorkingDirectory: ".",
+		Env:              map[string]string{"NAME": "it's me"},
+	})
+	assert.Equal(t, localshell.Result{}, result)
+
+	result = session.Run(t.Context(), action{Command: []string{"sh", "-c", `basename "$PWD"; printf '%s %s' "$NAME" "$COUNT"; false`}})
+	assert.Equal(t, localshell.Result{Stdout: "sub\nit's me 1", ExitCode: 1}, result)
+
+	result = session.Run(t.Context(), action{Command: []string{"echo", "$NAME", ";", "exit"}})
+	assert.Equal(t, localshell.Result{Stdout: "$NAME ; exit\n"}, result)
+
+	// Dangerous variables are never exported into the session.
+	for _, name := range []string{"LD_PRELOAD", "BASH_ENV", "PATH"} {
+		result = session.Run(t.Context(), action{Command: []string{"sh", "-c", `printf '%s' "$` + name + `"`}, Env: map[string]string{name: "/tmp/x"}})
+		assert.Equal(t, localshell.Result{ExitCode: -1, Error: `environment variable "` + name + `" is not allowed`}, result)
+	}
+	result = session.Run(t.Context(), action{Command: []string{"sh", "-c", `printf '%s' "$LD_PRELOAD"`}})
+	assert.Equal(t, localshell.Result{}, result)
+
+	result = session.Run(t.Context(), action{Command: []string{"sleep", "10"}, TimeoutMs: 100})
+	assert.True(t, result.TimedOut)
+
+	result = session.Run(t.Context(), action{Command: []string{"sh", "-c", `echo "[$NAME]"; exit 4`}})
+	assert.Equal(t, localshell.Result{
+		Stdout:   "[]\n",
+		ExitCode: 4,
+		Error:    "the shell exited; it will be restarted on the next command",
+	}, result)
+
+	result = session.Run(t.Context(), action{Command: []string{"pwd"}})
+	assert.Equal(t, 0, result.ExitCode)
+	assert.NotEmpty(t, result.Stdout)
+}
+
+func TestLocalShellTool(t *testing.T) {
+	root := newRoot(t)
+	executor, err := localshell.NewExecutor(localshell.Config{Root: root, AllowedCommands: []string{"echo"}})
+	require.NoError(t, err)
+
+	model := agentstesting.NewFakeModel(nil)
+	model.AddMultipleTurnOutputs([]agentstesting.FakeModelTurnOutput{
+		{Value: []agents.TResponseOutputItem{agentstesting.GetLocalShellCall("echo", "hi")}},
+		{Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("done")}},
+	})
+	agent := agents.New("test").WithModelInstance(model).
+		WithTools(agents.LocalShellTool{Executor: executor.Execute})
+
+	result, err := agents.Run(t.Context(), agent, "Say hi")
+	require.NoError(t, err)
+	require.Len(t, result.NewItems, 3)
+	output := result.NewItems[1].(agents.ToolCallOutputItem).Output.(string)
+
+	var shellResult localshell.Result
+	require.NoError(t, json.Unmarshal([]byte(output), &shellResult))
+	assert.Equal(t, localshell.Result{Stdout: "hi\n"}, shellResult)
+}
@@ -0,0 +1,297 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package localshell
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"os/user"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/openai/openai-go/responses"
+)
+
+// sandbox enforces a Config.
+type sandbox struct {
+	config         Config
+	root           string // absolute, without symbolic links
+	env            map[string]string
+	timeout        time.Duration
+	maxTimeout     time.Duration
+	maxOutputBytes int
+}
+
+// command is a command ready to be run.
+type command struct {
+	path       string // the program, as seen by the process
+	args       []string
+	dir        string // the working directory, as seen by the process
+	env        []string
+	timeout    time.Duration
+	credential *credential
+}
+
+// credential identifies the user a command runs as.
+type credential struct {
+	uid, gid uint32
+}
+
+func newSandbox(config Config) (*sandbox, error) {
+	root := config.Root
+	if root == "" {
+		root = "."
+	}
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return nil, fmt.Errorf("invalid root: %w", err)
+	}
+	if root, err = filepath.EvalSymlinks(root); err != nil {
+		return nil, fmt.Errorf("invalid root: %w", err)
+	}
+	if info, err := os.Stat(root); err != nil {
+		return nil, fmt.Errorf("invalid root: %w", err)
+	} else if !info.IsDir() {
+		return nil, fmt.Errorf("invalid root: %s is not a directory", root)
+	}
+
+	env := maps.Clone(config.Env)
+	if env == nil {
+		env = map[string]string{"PATH": DefaultPath}
+	}
+
+	s := &sandbox{
+		config:         config,
+		root:           root,
+		env:            env,
+		timeout:        config.Timeout,
+		maxTimeout:     config.MaxTimeout,
+		maxOutputBytes: config.MaxOutputBytes,
+	}
+	if s.timeout <= 0 {
+		s.timeout = DefaultTimeout
+	}
+	if s.maxTimeout <= 0 {
+		s.maxTimeout = DefaultMaxTimeout
+	}
+	if s.maxOutputBytes <= 0 {
+		s.maxOutputBytes = DefaultMaxOutputBytes
+	}
+	return s, nil
+}
+
+// command checks the action against the configuration, and resolves the
+// command to run.
+func (s *sandbox) command(action responses.ResponseOutputItemLocalShellCallAction) (*command, error) {
+	if len(action.Command) == 0 || action.Command[0] == "" {
+		return nil, errors.New("empty command")
+	}
+	if err := s.checkCommand(action.Command[0]); err != nil {
+		return nil, err
+	}
+	program, err := s.lookPath(action.Command[0])
+	if err != nil {
+		return nil, err
+	}
+	dir, err := s.workingDirectory(action.WorkingDirectory)
+	if err != nil {
+		return nil, err
+	}
+	cred, err := s.credential(action.User)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.checkEnv(action.Env); err != nil {
+		return nil, err
+	}
+
+	return &command{
+		path:       program,
+		args:       action.Command,
+		dir:        dir,
+		env:        s.environment(action.Env),
+		timeout:    s.commandTimeout(action.TimeoutMs),
+		credential: cred,
+	}, nil
+}
+
+// commandTimeout returns the timeout of a command requesting the given one.
+func (s *sandbox) commandTimeout(requestedMs int64) time.Duration {
+	if requestedMs <= 0 {
+		return s.timeout
+	}
+	return min(time.Duration(requestedMs)*time.Millisecond, s.maxTimeout)
+}
+
+func (s *sandbox) checkCommand(name string) error {
+	if s.config.AllowAnyCommand || slices.Contains(s.config.AllowedCommands, name) {
+		return nil
+	}
+	return fmt.Errorf("command %q is not allowed", name)
+}
+
+// lookPath resolves a program as seen by the process, looking up names in
+// the configured PATH.
+func (s *sandbox) lookPath(name string) (string, error) {
+	if strings.Contains(name, "/") {
+		if !path.IsAbs(name) {
+			return "", fmt.Errorf("command %q: relative program paths are not supported", name)
+		}
+		if err := isExecutable(s.hostPath(name)); err != nil {
+			return "", fmt.Errorf("command %q: %w", name, err)
+		}
+		return name, nil
+	}
+	for _, dir := range filepath.SplitList(s.env["PATH"]) {
+		if !path.IsAbs(dir) {
+			continue
+		}
+		p := path.Join(dir, name)
+		if isExecutable(s.hostPath(p)) == nil {
+			return p, nil
+		}
+	}
+	return "", fmt.Errorf("command %q not found", name)
+}
+
+func isExecutable(p string) error {
+	info, err := os.Stat(p)
+	if err != nil {
+		return err
+	}
+	if info.IsDir() || info.Mode()&0o111 == 0 {
+		return fmt.Errorf("%s is not an executable file", p)
+	}
+	return nil
+}
+
+// hostPath returns the path, on the host, of a path seen by the processes.
+func (s *sandbox) hostPath(p string) string {
+	if s.config.Chroot {
+		return filepath.Join(s.root, p)
+	}
+	return p
+}
+
+// workingDirectory resolves the requested working directory, as seen by the
+// process, making sure it is within the root.
+func (s *sandbox) workingDirectory(requested string) (string, error) {
+	dir := s.root
+	switch {
+	case requested == "":
+	case s.config.Chroot || !filepath.IsAbs(requested):
+		dir = filepath.Join(s.root, requested)
+	default:
+		dir = filepath.Clean(requested)
+	}
+
+	resolved, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return "", fmt.Errorf("invalid working directory %q: %w", requested, err)
+	}
+	rel, err := filepath.Rel(s.root, resolved)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("working directory %q is outside of the root", requested)
+	}
+	if info, err := os.Stat(resolved); err != nil || !info.IsDir() {
+		return "", fmt.Errorf("invalid working directory %q: not a directory", requested)
+	}
+
+	if s.config.Chroot {
+		return path.Join("/", filepath.ToSlash(rel)), nil
+	}
+	return resolved, nil
+}
+
+// credential resolves the requested user. A nil credential means the user of
+// the current process.
+func (s *sandbox) credential(requested string) (*credential, error) {
+	name := requested
+	if name == "" || name == s.config.User {
+		name = s.config.User
+	} else if !slices.Contains(s.config.AllowedUsers, name) {
+		return nil, fmt.Errorf("user %q is not allowed", name)
+	}
+	if name == "" {
+		return nil, nil
+	}
+
+	u, err := user.Lookup(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user: %w", err)
+	}
+	uid, err := strconv.ParseUint(u.Uid, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("user %q: unsupported user ID %q", name, u.Uid)
+	}
+	gid, err := strconv.ParseUint(u.Gid, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("user %q: unsupported group ID %q", name, u.Gid)
+	}
+	if int(uid) == os.Getuid() && int(gid) == os.Getgid() {
+		return nil, nil
+	}
+	return &credential{uid: uint32(uid), gid: uint32(gid)}, nil
+}
+
+var envNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// deniedEnv are the environment variables the model may never set, because
+// they make the dynamic loader, the shells, git or other common programs run
+// arbitrary code, or change the programs being run.
+var deniedEnv = []string{
+	"PATH", "IFS", "ENV", "BASH_ENV", "SHELLOPTS", "BASHOPTS", "PROMPT_COMMAND", "PS4", "CDPATH",
+	"PAGER", "MANPAGER", "EDITOR", "VISUAL", "BROWSER", "LESSOPEN", "LESSCLOSE",
+	"PYTHONSTARTUP", "PYTHONPATH", "PERL5OPT", "PERL5LIB", "RUBYOPT", "RUBYLIB", "NODE_OPTIONS",
+}
+
+// deniedEnvPrefixes are the prefixes of the environment variables the model
+// may never set, such as LD_PRELOAD, exported bash functions and the git
+// variables running commands (GIT_SSH_COMMAND, GIT_EXTERNAL_DIFF, ...).
+var deniedEnvPrefixes = []string{"LD_", "DYLD_", "BASH_FUNC_", "GIT_"}
+
+// checkEnv checks the environment variables requested by the model.
+func (s *sandbox) checkEnv(env map[string]string) error {
+	for _, name := range slices.Sorted(maps.Keys(env)) {
+		if !envNamePattern.MatchString(name) {
+			return fmt.Errorf("invalid environment variable name %q", name)
+		}
+		denied := slices.Contains(deniedEnv, strings.ToUpper(name)) ||
+			slices.ContainsFunc(deniedEnvPrefixes, func(p string) bool { return strings.HasPrefix(strings.ToUpper(name), p) })
+		if denied || !slices.Contains(s.config.AllowedEnv, name) {
+			return fmt.Errorf("environment variable %q is not allowed", name)
+		}
+	}
+	return nil
+}
+
+// environment merges the requested variables into the configured ones.
+func (s *sandbox) environment(requested map[string]string) []string {
+	env := maps.Clone(s.env)
+	maps.Copy(env, requested)
+	keys := slices.Sorted(maps.Keys(env))
+	vars := make([]string, len(keys))
+	for i, k := range keys {
+		vars[i] = k + "=" + env[k]
+	}
+	return vars
+}
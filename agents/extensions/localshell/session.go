@@ -0,0 +1,339 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package localshell
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os/exec"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/openai/openai-go/responses"
+)
+
+// Session runs the commands in a persistent shell, so that changes to its
+// state, such as the current directory or the exported variables, are seen
+// by the following commands. The variables requested by the model are
+// exported in the shell. Commands run one at a time, as the user of
+// Config.User: commands requesting another user are rejected.
+//
+// Commands such as "bash -c script" are unwrapped, running the script in the
+// session shell. Other commands run as they are.
+//
+// The shell is started on the first command. If a command times out, or exits
+// the shell, the shell is killed with its children and started again on the
+// next command, losing its state.
+type Session struct {
+	sandbox *sandbox
+	shell   string
+
+	mu      sync.Mutex
+	process *shellProcess
+}
+
+// shellProcess is a running session shell.
+type shellProcess struct {
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	stdout *bufio.Reader
+	stderr *bufio.Reader
+	marker string
+}
+
+// NewSession returns a Session enforcing the given configuration.
+func NewSession(config Config) (*Session, error) {
+	s, err := newSandbox(config)
+	if err != nil {
+		return nil, err
+	}
+	name := config.Shell
+	if name == "" {
+		name = "sh"
+	}
+	shell, err := s.lookPath(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid shell: %w", err)
+	}
+	return &Session{sandbox: s, shell: shell}, nil
+}
+
+// Execute is an agents.LocalShellExecutor running the command of the request
+// in the session. It only fails if ctx is done.
+func (s *Session) Execute(ctx context.Context, request agents.LocalShellCommandRequest) (string, error) {
+	result := s.Run(ctx, request.Data.Action)
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	return result.String(), nil
+}
+
+// Run runs the command of the action in the session.
+func (s *Session) Run(ctx context.Context, action responses.ResponseOutputItemLocalShellCallAction) Result {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	script, err := s.script(action)
+	if err != nil {
+		return errorResult(err)
+	}
+	if s.process == nil {
+		if s.process, err = s.start(); err != nil {
+			return errorResult(err)
+		}
+	}
+	p := s.process
+
+	timeout := s.sandbox.commandTimeout(action.TimeoutMs)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	status := "__localshell_status"
+	_, err = fmt.Fprintf(p.stdin, "{ %s\n} </dev/null\n%s=$?\nprintf '\\n%%s %%d\\n' %s \"$%s\"\nprintf '\\n%%s\\n' %s >&2\n",
+		script, status, p.marker, status, p.marker)
+	if err != nil {
+		s.stop()
+		return errorResult(fmt.Errorf("the shell is not running: %w", err))
+	}
+
+	stdout := newCappedBuffer(s.sandbox.maxOutputBytes)
+	stderr := newCappedBuffer(s.sandbox.maxOutputBytes)
+	var exitStatus string
+	var stdoutErr, stderrErr error
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		exitStatus, stdoutErr = readUntilMarker(p.stdout, p.marker, stdout)
+	}()
+	go func() {
+		defer wg.Done()
+		_, stderrErr = readUntilMarker(p.stderr, p.marker, stderr)
+	}()
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		s.stop()
+		<-done
+	}
+
+	result := Result{
+		Stdout:    stdout.String(),
+		Stderr:    stderr.String(),
+		Truncated: stdout.truncated || stderr.truncated,
+	}
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		result.ExitCode = -1
+		result.TimedOut = true
+		result.Error = fmt.Sprintf("command timed out after %s; the shell was restarted", timeout)
+	case ctx.Err() != nil:
+		result.ExitCode = -1
+		result.Error = ctx.Err().Error()
+	case stdoutErr != nil || stderrErr != nil:
+		result.ExitCode = s.stop()
+		result.Error = "the shell exited; it will be restarted on the next command"
+	default:
+		if result.ExitCode, err = strconv.Atoi(exitStatus); err != nil {
+			result.ExitCode = -1
+			result.Error = fmt.Sprintf("invalid exit status %q", exitStatus)
+		}
+	}
+	return result
+}
+
+// Close kills the shell, if running.
+func (s *Session) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stop()
+	return nil
+}
+
+// script checks the action against the configuration, and returns the
+// script running its command in the shell.
+func (s *Session) script(action responses.ResponseOutputItemLocalShellCallAction) (string, error) {
+	args := action.Command
+	if len(args) == 0 || args[0] == "" {
+		return "", errors.New("empty command")
+	}
+	if err := s.sandbox.checkCommand(args[0]); err != nil {
+		return "", err
+	}
+	if action.User != "" && action.User != s.sandbox.config.User {
+		return "", fmt.Errorf("user %q is not allowed: a session runs all commands as the same user", action.User)
+	}
+
+	var prefix []string
+	if action.WorkingDirectory != "" {
+		dir, err := s.sandbox.workingDirectory(action.WorkingDirectory)
+		if err != nil {
+			return "", err
+		}
+		prefix = append(prefix, "cd -- "+quote(dir))
+	}
+	if err := s.sandbox.checkEnv(action.Env); err != nil {
+		return "", err
+	}
+	for _, k := range slices.Sorted(maps.Keys(action.Env)) {
+		prefix = append(prefix, "export "+k+"="+quote(action.Env[k]))
+	}
+
+	var body string
+	if script, ok := shellScript(args); ok {
+		if _, err := s.sandbox.lookPath(args[0]); err != nil {
+			return "", err
+		}
+		body = script
+	} else {
+		program, err := s.sandbox.lookPath(args[0])
+		if err != nil {
+			return "", err
+		}
+		quoted := []string{quote(program)}
+		for _, arg := range args[1:] {
+			quoted = append(quoted, quote(arg))
+		}
+		body = strings.Join(quoted, " ")
+	}
+
+	if len(prefix) == 0 {
+		return body, nil
+	}
+	return strings.Join(prefix, " && ") + " && {\n" + body + "\n}", nil
+}
+
+// shellScript returns the script of commands such as "bash -c script".
+func shellScript(args []string) (string, bool) {
+	if len(args) != 3 || (args[1] != "-c" && args[1] != "-lc") {
+		return "", false
+	}
+	switch path.Base(args[0]) {
+	case "sh", "bash", "dash", "zsh", "ksh":
+		return args[2], true
+	default:
+		return "", false
+	}
+}
+
+func quote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+func (s *Session) start() (*shellProcess, error) {
+	dir, err := s.sandbox.workingDirectory("")
+	if err != nil {
+		return nil, err
+	}
+	cred, err := s.sandbox.credential("")
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := exec.Command(s.shell)
+	cmd.Dir = dir
+	cmd.Env = s.sandbox.environment(nil)
+	if err = s.sandbox.setSysProcAttr(cmd, cred); err != nil {
+		return nil, err
+	}
+	cmd.WaitDelay = time.Second
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err = cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start the shell: %w", err)
+	}
+
+	return &shellProcess{
+		cmd:    cmd,
+		stdin:  stdin,
+		stdout: bufio.NewReader(stdout),
+		stderr: bufio.NewReader(stderr),
+		marker: "__localshell_" + rand.Text(),
+	}, nil
+}
+
+// stop kills the shell with its children, if running, and returns its exit
+// code.
+func (s *Session) stop() int {
+	p := s.process
+	if p == nil {
+		return -1
+	}
+	s.process = nil
+	_ = p.stdin.Close()
+	_ = killProcessGroup(p.cmd)
+	_ = p.cmd.Wait()
+	return p.cmd.ProcessState.ExitCode()
+}
+
+// readUntilMarker copies the output of a command from r to out, until the
+// line starting with the marker, and returns the rest of that line. The
+// newline printed before the marker is not copied.
+func readUntilMarker(r *bufio.Reader, marker string, out io.Writer) (string, error) {
+	atLineStart := true
+	pendingNewline := false
+	for {
+		chunk, err := r.ReadSlice('\n')
+		complete := len(chunk) > 0 && chunk[len(chunk)-1] == '\n'
+		if atLineStart && complete && bytes.HasPrefix(chunk, []byte(marker)) {
+			return strings.TrimSpace(string(chunk[len(marker):])), nil
+		}
+		if len(chunk) > 0 {
+			if pendingNewline {
+				_, _ = out.Write([]byte{'\n'})
+			}
+			pendingNewline = complete
+			if complete {
+				chunk = chunk[:len(chunk)-1]
+			}
+			_, _ = out.Write(chunk)
+			atLineStart = complete
+		}
+		if err != nil && !errors.Is(err, bufio.ErrBufferFull) {
+			if pendingNewline {
+				_, _ = out.Write([]byte{'\n'})
+			}
+			return "", err
+		}
+	}
+}
@@ -0,0 +1,36 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !unix
+
+package localshell
+
+import (
+	"errors"
+	"os/exec"
+)
+
+func (s *sandbox) setSysProcAttr(_ *exec.Cmd, cred *credential) error {
+	if s.config.Chroot {
+		return errors.New("chroot is not supported on this platform")
+	}
+	if cred != nil {
+		return errors.New("running commands as another user is not supported on this platform")
+	}
+	return nil
+}
+
+func killProcessGroup(cmd *exec.Cmd) error {
+	return cmd.Process.Kill()
+}
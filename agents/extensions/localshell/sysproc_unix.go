@@ -0,0 +1,41 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build unix
+
+package localshell
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// setSysProcAttr runs the command in its own process group, so that it can
+// be killed with its children, chrooted and as the given user if required.
+func (s *sandbox) setSysProcAttr(cmd *exec.Cmd, cred *credential) error {
+	attr := &syscall.SysProcAttr{Setpgid: true}
+	if s.config.Chroot {
+		attr.Chroot = s.root
+	}
+	if cred != nil {
+		attr.Credential = &syscall.Credential{Uid: cred.uid, Gid: cred.gid}
+	}
+	cmd.SysProcAttr = attr
+	return nil
+}
+
+// killProcessGroup kills the started command with its children.
+func killProcessGroup(cmd *exec.Cmd) error {
+	return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+}
@@ -0,0 +1,422 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openaiserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/usage"
+	"github.com/openai/openai-go/packages/param"
+	"github.com/openai/openai-go/responses"
+	"github.com/openai/openai-go/shared/constant"
+)
+
+type chatCompletionRequest struct {
+	Model         string        `json:"model"`
+	Messages      []chatMessage `json:"messages"`
+	Stream        bool          `json:"stream"`
+	StreamOptions *struct {
+		IncludeUsage bool `json:"include_usage"`
+	} `json:"stream_options"`
+}
+
+type chatMessage struct {
+	Role       string          `json:"role"`
+	Content    json.RawMessage `json:"content"`
+	ToolCalls  []chatToolCall  `json:"tool_calls"`
+	ToolCallID string          `json:"tool_call_id"`
+}
+
+type chatToolCall struct {
+	ID       string `json:"id"`
+	Type     string `json:"type"`
+	Function struct {
+		Name      string `json:"name"`
+		Arguments string `json:"arguments"`
+	} `json:"function"`
+}
+
+type chatContentPart struct {
+	Type     string `json:"type"`
+	Text     string `json:"text"`
+	ImageURL struct {
+		URL    string `json:"url"`
+		Detail string `json:"detail"`
+	} `json:"image_url"`
+}
+
+type chatCompletion struct {
+	ID      string                 `json:"id"`
+	Object  string                 `json:"object"`
+	Created int64                  `json:"created"`
+	Model   string                 `json:"model"`
+	Choices []chatCompletionChoice `json:"choices"`
+	Usage   *chatCompletionUsage   `json:"usage,omitempty"`
+}
+
+type chatCompletionChoice struct {
+	Index        int                   `json:"index"`
+	Message      chatCompletionMessage `json:"message"`
+	FinishReason string                `json:"finish_reason"`
+}
+
+type chatCompletionMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type chatCompletionChunk struct {
+	ID      string                      `json:"id"`
+	Object  string                      `json:"object"`
+	Created int64                       `json:"created"`
+	Model   string                      `json:"model"`
+	Choices []chatCompletionChunkChoice `json:"choices"`
+	Usage   *chatCompletionUsage        `json:"usage,omitempty"`
+}
+
+type chatCompletionChunkChoice struct {
+	Index        int                      `json:"index"`
+	Delta        chatCompletionChunkDelta `json:"delta"`
+	FinishReason *string                  `json:"finish_reason"`
+}
+
+type chatCompletionChunkDelta struct {
+	Role    string `json:"role,omitempty"`
+	Content string `json:"content,omitempty"`
+}
+
+type chatCompletionUsage struct {
+	PromptTokens        uint64 `json:"prompt_tokens"`
+	CompletionTokens    uint64 `json:"completion_tokens"`
+	TotalTokens         uint64 `json:"total_tokens"`
+	PromptTokensDetails struct {
+		CachedTokens int64 `json:"cached_tokens"`
+	} `json:"prompt_tokens_details"`
+	CompletionTokensDetails struct {
+		ReasoningTokens int64 `json:"reasoning_tokens"`
+	} `json:"completion_tokens_details"`
+}
+
+func newChatCompletionUsage(u *usage.Usage) *chatCompletionUsage {
+	cu := &chatCompletionUsage{
+		PromptTokens:     u.InputTokens,
+		CompletionTokens: u.OutputTokens,
+		TotalTokens:      u.TotalTokens,
+	}
+	cu.PromptTokensDetails.CachedTokens = u.InputTokensDetails.CachedTokens
+	cu.CompletionTokensDetails.ReasoningTokens = u.OutputTokensDetails.ReasoningTokens
+	return cu
+}
+
+func (s *Server) handleChatCompletions(w http.ResponseWriter, r *http.Request) {
+	var req chatCompletionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request_error", "invalid request body: %s", err)
+		return
+	}
+
+	agent, ok := s.getAgent(req.Model)
+	if !ok {
+		writeModelNotFound(w, req.Model)
+		return
+	}
+
+	input, err := chatMessagesToInputItems(req.Messages)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request_error", "%s", err)
+		return
+	}
+	if len(input) == 0 {
+		writeError(w, http.StatusBadRequest, "invalid_request_error", "messages must not be empty")
+		return
+	}
+
+	id := newID("chatcmpl-")
+	created := s.now().Unix()
+
+	if req.Stream {
+		includeUsage := req.StreamOptions != nil && req.StreamOptions.IncludeUsage
+		s.streamChatCompletion(w, r, agent, input, id, created, req.Model, includeUsage)
+		return
+	}
+
+	result, err := s.run(r, agent, input)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "server_error", "agent run failed: %s", err)
+		return
+	}
+
+	text, err := finalOutputText(result.FinalOutput)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "server_error", "%s", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, chatCompletion{
+		ID:      id,
+		Object:  "chat.completion",
+		Created: created,
+		Model:   req.Model,
+		Choices: []chatCompletionChoice{{
+			Index:        0,
+			Message:      chatCompletionMessage{Role: "assistant", Content: text},
+			FinishReason: "stop",
+		}},
+		Usage: newChatCompletionUsage(totalUsage(result.RawResponses)),
+	})
+}
+
+func (s *Server) streamChatCompletion(
+	w http.ResponseWriter,
+	r *http.Request,
+	agent *agents.Agent,
+	input []agents.TResponseInputItem,
+	id string,
+	created int64,
+	model string,
+	includeUsage bool,
+) {
+	result, err := s.runStreamed(r, agent, input)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "server_error", "agent run failed: %s", err)
+		return
+	}
+
+	sse, ok := newSSEWriter(w)
+	if !ok {
+		result.Cancel()
+		writeError(w, http.StatusInternalServerError, "server_error", "streaming unsupported")
+		return
+	}
+
+	newChunk := func(delta chatCompletionChunkDelta, finishReason *string) chatCompletionChunk {
+		return chatCompletionChunk{
+			ID:      id,
+			Object:  "chat.completion.chunk",
+			Created: created,
+			Model:   model,
+			Choices: []chatCompletionChunkChoice{{Index: 0, Delta: delta, FinishReason: finishReason}},
+		}
+	}
+
+	if err = sse.writeData(newChunk(chatCompletionChunkDelta{Role: "assistant"}, nil)); err != nil {
+		result.Cancel()
+		return
+	}
+
+	streamedText := false
+	filter := s.newTextFilter(agent)
+	err = result.StreamEvents(func(event agents.StreamEvent) error {
+		for _, delta := range filter.add(event) {
+			if delta == "" {
+				continue
+			}
+			streamedText = true
+			if err := sse.writeData(newChunk(chatCompletionChunkDelta{Content: delta}, nil)); err != nil {
+				result.Cancel()
+				return err
+			}
+		}
+		return nil
+	})
+	if r.Context().Err() != nil {
+		return
+	}
+	if err != nil {
+		_ = sse.writeData(errorResponse{Error: apiError{
+			Message: fmt.Sprintf("agent run failed: %s", err),
+			Type:    "server_error",
+		}})
+		return
+	}
+
+	if !streamedText {
+		// No text delta of the final response was streamed: send the whole final output at once.
+		text, err := finalOutputText(result.FinalOutput())
+		if err != nil {
+			_ = sse.writeData(errorResponse{Error: apiError{Message: err.Error(), Type: "server_error"}})
+			return
+		}
+		if err = sse.writeData(newChunk(chatCompletionChunkDelta{Content: text}, nil)); err != nil {
+			return
+		}
+	}
+
+	stop := "stop"
+	if err = sse.writeData(newChunk(chatCompletionChunkDelta{}, &stop)); err != nil {
+		return
+	}
+
+	if includeUsage {
+		usageChunk := chatCompletionChunk{
+			ID:      id,
+			Object:  "chat.completion.chunk",
+			Created: created,
+			Model:   model,
+			Choices: []chatCompletionChunkChoice{},
+			Usage:   newChatCompletionUsage(totalUsage(result.RawResponses())),
+		}
+		if err = sse.writeData(usageChunk); err != nil {
+			return
+		}
+	}
+
+	_ = sse.writeRaw("", []byte("[DONE]"))
+}
+
+// chatMessagesToInputItems converts Chat Completions messages to input items
+// in OpenAI Responses format.
+func chatMessagesToInputItems(messages []chatMessage) ([]agents.TResponseInputItem, error) {
+	var items []agents.TResponseInputItem
+
+	for i, m := range messages {
+		switch m.Role {
+		case "system", "developer", "user":
+			content, err := chatContentToEasyInputContent(m.Content)
+			if err != nil {
+				return nil, fmt.Errorf("messages[%d]: %w", i, err)
+			}
+			items = append(items, agents.TResponseInputItem{
+				OfMessage: &responses.EasyInputMessageParam{
+					Content: content,
+					Role:    responses.EasyInputMessageRole(m.Role),
+					Type:    responses.EasyInputMessageTypeMessage,
+				},
+			})
+		case "assistant":
+			text, err := chatContentText(m.Content)
+			if err != nil {
+				return nil, fmt.Errorf("messages[%d]: %w", i, err)
+			}
+			if text != "" {
+				items = append(items, agents.TResponseInputItem{
+					OfMessage: &responses.EasyInputMessageParam{
+						Content: responses.EasyInputMessageContentUnionParam{OfString: param.NewOpt(text)},
+						Role:    responses.EasyInputMessageRoleAssistant,
+						Type:    responses.EasyInputMessageTypeMessage,
+					},
+				})
+			}
+			for _, toolCall := range m.ToolCalls {
+				items = append(items, agents.TResponseInputItem{
+					OfFunctionCall: &responses.ResponseFunctionToolCallParam{
+						Arguments: toolCall.Function.Arguments,
+						CallID:    toolCall.ID,
+						Name:      toolCall.Function.Name,
+						Type:      constant.ValueOf[constant.FunctionCall](),
+					},
+				})
+			}
+		case "tool":
+			text, err := chatContentText(m.Content)
+			if err != nil {
+				return nil, fmt.Errorf("messages[%d]: %w", i, err)
+			}
+			items = append(items, agents.TResponseInputItem{
+				OfFunctionCallOutput: &responses.ResponseInputItemFunctionCallOutputParam{
+					CallID: m.ToolCallID,
+					Output: text,
+					Type:   constant.ValueOf[constant.FunctionCallOutput](),
+				},
+			})
+		default:
+			return nil, fmt.Errorf("messages[%d]: unsupported role %q", i, m.Role)
+		}
+	}
+
+	return items, nil
+}
+
+func chatContentToEasyInputContent(raw json.RawMessage) (responses.EasyInputMessageContentUnionParam, error) {
+	var zero responses.EasyInputMessageContentUnionParam
+
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return responses.EasyInputMessageContentUnionParam{OfString: param.NewOpt("")}, nil
+	}
+	if raw[0] == '"' {
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return zero, fmt.Errorf("invalid content: %w", err)
+		}
+		return responses.EasyInputMessageContentUnionParam{OfString: param.NewOpt(s)}, nil
+	}
+
+	var parts []chatContentPart
+	if err := json.Unmarshal(raw, &parts); err != nil {
+		return zero, fmt.Errorf("invalid content: %w", err)
+	}
+
+	list := make(responses.ResponseInputMessageContentListParam, 0, len(parts))
+	for _, part := range parts {
+		switch part.Type {
+		case "text":
+			list = append(list, responses.ResponseInputContentUnionParam{
+				OfInputText: &responses.ResponseInputTextParam{
+					Text: part.Text,
+					Type: constant.ValueOf[constant.InputText](),
+				},
+			})
+		case "image_url":
+			detail := responses.ResponseInputImageDetail(part.ImageURL.Detail)
+			if detail == "" {
+				detail = responses.ResponseInputImageDetailAuto
+			}
+			list = append(list, responses.ResponseInputContentUnionParam{
+				OfInputImage: &responses.ResponseInputImageParam{
+					Detail:   detail,
+					ImageURL: param.NewOpt(part.ImageURL.URL),
+					Type:     constant.ValueOf[constant.InputImage](),
+				},
+			})
+		default:
+			return zero, fmt.Errorf("unsupported content part type %q", part.Type)
+		}
+	}
+	return responses.EasyInputMessageContentUnionParam{OfInputItemContentList: list}, nil
+}
+
+// chatContentText extracts the text of a content which can be either a string
+// or a list of text parts.
+func chatContentText(raw json.RawMessage) (string, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return "", nil
+	}
+	if raw[0] == '"' {
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return "", fmt.Errorf("invalid content: %w", err)
+		}
+		return s, nil
+	}
+
+	var parts []chatContentPart
+	if err := json.Unmarshal(raw, &parts); err != nil {
+		return "", fmt.Errorf("invalid content: %w", err)
+	}
+	var buf bytes.Buffer
+	for _, part := range parts {
+		if part.Type != "text" {
+			return "", fmt.Errorf("unsupported content part type %q", part.Type)
+		}
+		buf.WriteString(part.Text)
+	}
+	return buf.String(), nil
+}
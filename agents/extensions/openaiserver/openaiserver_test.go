@@ -0,0 +1,268 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openaiserver_test
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/agents/extensions/openaiserver"
+	"github.com/nlpodyssey/openai-agents-go/agentstesting"
+	"github.com/nlpodyssey/openai-agents-go/usage"
+	"github.com/openai/openai-go"
+	"github.com/openai/openai-go/option"
+	"github.com/openai/openai-go/responses"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupServer(t *testing.T) (*agentstesting.FakeModel, openai.Client) {
+	t.Helper()
+
+	model := agentstesting.NewFakeModel(nil)
+	model.SetHardcodedUsage(usage.Usage{
+		Requests:     1,
+		InputTokens:  10,
+		OutputTokens: 5,
+		TotalTokens:  15,
+	})
+
+	specialist := agents.New("specialist").WithModelInstance(model)
+	triage := agents.New("triage").
+		WithModelInstance(model).
+		WithAgentHandoffs(specialist).
+		WithTools(agentstesting.GetFunctionTool("lookup", "42"))
+
+	server := httptest.NewServer(
+		openaiserver.NewServer(agents.Runner{}).Register("triage-workflow", triage),
+	)
+	t.Cleanup(server.Close)
+
+	client := openai.NewClient(
+		option.WithBaseURL(server.URL+"/v1/"),
+		option.WithAPIKey("test"),
+		option.WithMaxRetries(0),
+	)
+
+	model.AddMultipleTurnOutputs([]agentstesting.FakeModelTurnOutput{
+		{Value: []agents.TResponseOutputItem{agentstesting.GetFunctionToolCall("lookup", `{}`)}},
+		{Value: []agents.TResponseOutputItem{agentstesting.GetHandoffToolCall(specialist, "", "")}},
+		{Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("the answer is 42")}},
+	})
+
+	return model, client
+}
+
+func TestChatCompletions(t *testing.T) {
+	model, client := setupServer(t)
+
+	completion, err := client.Chat.Completions.New(t.Context(), openai.ChatCompletionNewParams{
+		Model: "triage-workflow",
+		Messages: []openai.ChatCompletionMessageParamUnion{
+			openai.SystemMessage("be nice"),
+			openai.UserMessage("what is the answer?"),
+		},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, completion.Choices, 1)
+	assert.Equal(t, "the answer is 42", completion.Choices[0].Message.Content)
+	assert.Equal(t, "stop", completion.Choices[0].FinishReason)
+	assert.Equal(t, "triage-workflow", completion.Model)
+	assert.Equal(t, int64(30), completion.Usage.PromptTokens)
+	assert.Equal(t, int64(15), completion.Usage.CompletionTokens)
+	assert.Equal(t, int64(45), completion.Usage.TotalTokens)
+
+	input := model.LastTurnArgs.Input.(agents.InputItems)
+	assert.Equal(t, "be nice", input[0].OfMessage.Content.OfString.Value)
+	assert.Equal(t, "what is the answer?", input[1].OfMessage.Content.OfString.Value)
+}
+
+func TestChatCompletionsStreaming(t *testing.T) {
+	_, client := setupServer(t)
+
+	stream := client.Chat.Completions.NewStreaming(t.Context(), openai.ChatCompletionNewParams{
+		Model:    "triage-workflow",
+		Messages: []openai.ChatCompletionMessageParamUnion{openai.UserMessage("what is the answer?")},
+		StreamOptions: openai.ChatCompletionStreamOptionsParam{
+			IncludeUsage: openai.Bool(true),
+		},
+	})
+
+	var acc openai.ChatCompletionAccumulator
+	for stream.Next() {
+		acc.AddChunk(stream.Current())
+	}
+	require.NoError(t, stream.Err())
+
+	require.Len(t, acc.Choices, 1)
+	assert.Equal(t, "the answer is 42", acc.Choices[0].Message.Content)
+	assert.Equal(t, "stop", acc.Choices[0].FinishReason)
+	assert.Equal(t, int64(45), acc.Usage.TotalTokens)
+}
+
+func TestResponses(t *testing.T) {
+	_, client := setupServer(t)
+
+	response, err := client.Responses.New(t.Context(), responses.ResponseNewParams{
+		Model: "triage-workflow",
+		Input: responses.ResponseNewParamsInputUnion{OfString: openai.String("what is the answer?")},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, responses.ResponseStatusCompleted, response.Status)
+	assert.Equal(t, "the answer is 42", response.OutputText())
+	assert.Equal(t, int64(30), response.Usage.InputTokens)
+	assert.Equal(t, int64(15), response.Usage.OutputTokens)
+	assert.Equal(t, int64(45), response.Usage.TotalTokens)
+}
+
+func TestResponsesStreaming(t *testing.T) {
+	_, client := setupServer(t)
+
+	stream := client.Responses.NewStreaming(t.Context(), responses.ResponseNewParams{
+		Model: "triage-workflow",
+		Input: responses.ResponseNewParamsInputUnion{OfString: openai.String("what is the answer?")},
+	})
+
+	var (
+		text      strings.Builder
+		completed *responses.Response
+	)
+	for stream.Next() {
+		event := stream.Current()
+		switch event.Type {
+		case "response.output_text.delta":
+			text.WriteString(event.Delta.OfString)
+		case "response.completed":
+			completed = &event.Response
+		}
+	}
+	require.NoError(t, stream.Err())
+
+	assert.Equal(t, "the answer is 42", text.String())
+	require.NotNil(t, completed)
+	assert.Equal(t, "the answer is 42", completed.OutputText())
+	assert.Equal(t, int64(45), completed.Usage.TotalTokens)
+}
+
+func TestModelsAndUnknownModel(t *testing.T) {
+	_, client := setupServer(t)
+
+	page, err := client.Models.List(t.Context())
+	require.NoError(t, err)
+	require.Len(t, page.Data, 1)
+	assert.Equal(t, "triage-workflow", page.Data[0].ID)
+
+	_, err = client.Chat.Completions.New(t.Context(), openai.ChatCompletionNewParams{
+		Model:    "unknown",
+		Messages: []openai.ChatCompletionMessageParamUnion{openai.UserMessage("hi")},
+	})
+	var apiErr *openai.Error
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, 404, apiErr.StatusCode)
+	assert.Equal(t, "model_not_found", apiErr.Code)
+}
+
+func TestStreamingMatchesBlockingContent(t *testing.T) {
+	model := agentstesting.NewFakeModel(nil)
+	specialist := agents.New("specialist").WithModelInstance(model)
+	triage := agents.New("triage").WithModelInstance(model).WithAgentHandoffs(specialist)
+
+	server := openaiserver.NewServer(agents.Runner{}).Register("triage-workflow", triage)
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+	client := openai.NewClient(
+		option.WithBaseURL(httpServer.URL+"/v1/"),
+		option.WithAPIKey("test"),
+		option.WithMaxRetries(0),
+	)
+
+	addTurns := func() {
+		model.AddMultipleTurnOutputs([]agentstesting.FakeModelTurnOutput{
+			{Value: []agents.TResponseOutputItem{
+				agentstesting.GetTextMessage("transferring. "),
+				agentstesting.GetHandoffToolCall(specialist, "", ""),
+			}},
+			{Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("the answer is 42")}},
+		})
+	}
+	params := openai.ChatCompletionNewParams{
+		Model:    "triage-workflow",
+		Messages: []openai.ChatCompletionMessageParamUnion{openai.UserMessage("what is the answer?")},
+	}
+	streamChat := func() string {
+		stream := client.Chat.Completions.NewStreaming(t.Context(), params)
+		var acc openai.ChatCompletionAccumulator
+		for stream.Next() {
+			acc.AddChunk(stream.Current())
+		}
+		require.NoError(t, stream.Err())
+		require.Len(t, acc.Choices, 1)
+		return acc.Choices[0].Message.Content
+	}
+	streamResponses := func() (string, *responses.Response) {
+		stream := client.Responses.NewStreaming(t.Context(), responses.ResponseNewParams{
+			Model: "triage-workflow",
+			Input: responses.ResponseNewParamsInputUnion{OfString: openai.String("what is the answer?")},
+		})
+		var (
+			text      strings.Builder
+			completed *responses.Response
+		)
+		for stream.Next() {
+			event := stream.Current()
+			switch event.Type {
+			case "response.output_text.delta":
+				text.WriteString(event.Delta.OfString)
+			case "response.completed":
+				completed = &event.Response
+			}
+		}
+		require.NoError(t, stream.Err())
+		return text.String(), completed
+	}
+
+	addTurns()
+	completion, err := client.Chat.Completions.New(t.Context(), params)
+	require.NoError(t, err)
+	require.Len(t, completion.Choices, 1)
+	blocking := completion.Choices[0].Message.Content
+	assert.Equal(t, "the answer is 42", blocking)
+
+	// By default, the text of the intermediate agent is not streamed.
+	addTurns()
+	assert.Equal(t, blocking, streamChat())
+
+	addTurns()
+	text, completed := streamResponses()
+	assert.Equal(t, blocking, text)
+	require.NotNil(t, completed)
+	assert.Equal(t, blocking, completed.OutputText())
+
+	// With intermediate text, every text delta is forwarded.
+	server.WithIntermediateText(true)
+
+	addTurns()
+	assert.Equal(t, "transferring. the answer is 42", streamChat())
+
+	addTurns()
+	text, completed = streamResponses()
+	assert.Equal(t, "transferring. the answer is 42", text)
+	require.NotNil(t, completed)
+	assert.Equal(t, blocking, completed.OutputText())
+}
@@ -0,0 +1,339 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openaiserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/usage"
+	"github.com/openai/openai-go/packages/param"
+	"github.com/openai/openai-go/responses"
+)
+
+type responseRequest struct {
+	Model        string          `json:"model"`
+	Input        json.RawMessage `json:"input"`
+	Instructions string          `json:"instructions"`
+	Stream       bool            `json:"stream"`
+}
+
+type responseObject struct {
+	ID                string            `json:"id"`
+	Object            string            `json:"object"`
+	CreatedAt         int64             `json:"created_at"`
+	Status            string            `json:"status"`
+	Model             string            `json:"model"`
+	Output            []responseMessage `json:"output"`
+	ParallelToolCalls bool              `json:"parallel_tool_calls"`
+	ToolChoice        string            `json:"tool_choice"`
+	Tools             []any             `json:"tools"`
+	Error             *responseError    `json:"error"`
+	Usage             *responseUsage    `json:"usage,omitempty"`
+	Metadata          map[string]string `json:"metadata"`
+}
+
+type responseError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type responseMessage struct {
+	ID      string               `json:"id"`
+	Type    string               `json:"type"`
+	Role    string               `json:"role"`
+	Status  string               `json:"status"`
+	Content []responseOutputText `json:"content"`
+}
+
+type responseOutputText struct {
+	Type        string `json:"type"`
+	Text        string `json:"text"`
+	Annotations []any  `json:"annotations"`
+}
+
+type responseUsage struct {
+	InputTokens        uint64 `json:"input_tokens"`
+	InputTokensDetails struct {
+		CachedTokens int64 `json:"cached_tokens"`
+	} `json:"input_tokens_details"`
+	OutputTokens        uint64 `json:"output_tokens"`
+	OutputTokensDetails struct {
+		ReasoningTokens int64 `json:"reasoning_tokens"`
+	} `json:"output_tokens_details"`
+	TotalTokens uint64 `json:"total_tokens"`
+}
+
+func newResponseUsage(u *usage.Usage) *responseUsage {
+	ru := &responseUsage{
+		InputTokens:  u.InputTokens,
+		OutputTokens: u.OutputTokens,
+		TotalTokens:  u.TotalTokens,
+	}
+	ru.InputTokensDetails.CachedTokens = u.InputTokensDetails.CachedTokens
+	ru.OutputTokensDetails.ReasoningTokens = u.OutputTokensDetails.ReasoningTokens
+	return ru
+}
+
+func (s *Server) handleResponses(w http.ResponseWriter, r *http.Request) {
+	var req responseRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request_error", "invalid request body: %s", err)
+		return
+	}
+
+	agent, ok := s.getAgent(req.Model)
+	if !ok {
+		writeModelNotFound(w, req.Model)
+		return
+	}
+
+	input, err := responsesRequestInput(req)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request_error", "%s", err)
+		return
+	}
+
+	response := responseObject{
+		ID:         newID("resp_"),
+		Object:     "response",
+		CreatedAt:  s.now().Unix(),
+		Status:     "in_progress",
+		Model:      req.Model,
+		Output:     []responseMessage{},
+		ToolChoice: "auto",
+		Tools:      []any{},
+		Metadata:   map[string]string{},
+	}
+
+	if req.Stream {
+		s.streamResponse(w, r, agent, input, response)
+		return
+	}
+
+	result, err := s.run(r, agent, input)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "server_error", "agent run failed: %s", err)
+		return
+	}
+
+	text, err := finalOutputText(result.FinalOutput)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "server_error", "%s", err)
+		return
+	}
+
+	response.Status = "completed"
+	response.Output = []responseMessage{newResponseMessage(newID("msg_"), "completed", text)}
+	response.Usage = newResponseUsage(totalUsage(result.RawResponses))
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (s *Server) streamResponse(
+	w http.ResponseWriter,
+	r *http.Request,
+	agent *agents.Agent,
+	input []agents.TResponseInputItem,
+	response responseObject,
+) {
+	result, err := s.runStreamed(r, agent, input)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "server_error", "agent run failed: %s", err)
+		return
+	}
+
+	sse, ok := newSSEWriter(w)
+	if !ok {
+		result.Cancel()
+		writeError(w, http.StatusInternalServerError, "server_error", "streaming unsupported")
+		return
+	}
+
+	sequenceNumber := 0
+	send := func(eventType string, fields map[string]any) error {
+		fields["type"] = eventType
+		fields["sequence_number"] = sequenceNumber
+		sequenceNumber++
+		return sse.writeEvent(eventType, fields)
+	}
+
+	messageID := newID("msg_")
+	emptyPart := responseOutputText{Type: "output_text", Text: "", Annotations: []any{}}
+
+	err = errors.Join(
+		send("response.created", map[string]any{"response": response}),
+		send("response.output_item.added", map[string]any{
+			"output_index": 0,
+			"item":         newResponseMessage(messageID, "in_progress", ""),
+		}),
+		send("response.content_part.added", map[string]any{
+			"item_id":       messageID,
+			"output_index":  0,
+			"content_index": 0,
+			"part":          emptyPart,
+		}),
+	)
+	if err != nil {
+		result.Cancel()
+		return
+	}
+
+	var streamedText bytes.Buffer
+	filter := s.newTextFilter(agent)
+	err = result.StreamEvents(func(event agents.StreamEvent) error {
+		for _, delta := range filter.add(event) {
+			if delta == "" {
+				continue
+			}
+			streamedText.WriteString(delta)
+			err := send("response.output_text.delta", map[string]any{
+				"item_id":       messageID,
+				"output_index":  0,
+				"content_index": 0,
+				"delta":         delta,
+			})
+			if err != nil {
+				result.Cancel()
+				return err
+			}
+		}
+		return nil
+	})
+	if r.Context().Err() != nil {
+		return
+	}
+
+	var text string
+	if err == nil {
+		text, err = finalOutputText(result.FinalOutput())
+	}
+	if err != nil {
+		response.Status = "failed"
+		response.Error = &responseError{Code: "server_error", Message: fmt.Sprintf("agent run failed: %s", err)}
+		_ = send("response.failed", map[string]any{"response": response})
+		return
+	}
+
+	if streamedText.Len() == 0 && text != "" {
+		// No text delta of the final response was streamed: send the whole final output at once.
+		err = send("response.output_text.delta", map[string]any{
+			"item_id":       messageID,
+			"output_index":  0,
+			"content_index": 0,
+			"delta":         text,
+		})
+		if err != nil {
+			return
+		}
+	}
+
+	message := newResponseMessage(messageID, "completed", text)
+	response.Status = "completed"
+	response.Output = []responseMessage{message}
+	response.Usage = newResponseUsage(totalUsage(result.RawResponses()))
+
+	_ = errors.Join(
+		send("response.output_text.done", map[string]any{
+			"item_id":       messageID,
+			"output_index":  0,
+			"content_index": 0,
+			"text":          text,
+		}),
+		send("response.content_part.done", map[string]any{
+			"item_id":       messageID,
+			"output_index":  0,
+			"content_index": 0,
+			"part":          message.Content[0],
+		}),
+		send("response.output_item.done", map[string]any{
+			"output_index": 0,
+			"item":         message,
+		}),
+		send("response.completed", map[string]any{"response": response}),
+	)
+}
+
+func newResponseMessage(id, status, text string) responseMessage {
+	content := []responseOutputText{}
+	if status == "completed" {
+		content = append(content, responseOutputText{Type: "output_text", Text: text, Annotations: []any{}})
+	}
+	return responseMessage{
+		ID:      id,
+		Type:    "message",
+		Role:    "assistant",
+		Status:  status,
+		Content: content,
+	}
+}
+
+// responsesRequestInput converts the input of a Responses API request to a
+// list of input items, prepending the optional instructions.
+func responsesRequestInput(req responseRequest) ([]agents.TResponseInputItem, error) {
+	var items []agents.TResponseInputItem
+
+	if req.Instructions != "" {
+		items = append(items, agents.TResponseInputItem{
+			OfMessage: &responses.EasyInputMessageParam{
+				Content: responses.EasyInputMessageContentUnionParam{OfString: param.NewOpt(req.Instructions)},
+				Role:    responses.EasyInputMessageRoleDeveloper,
+				Type:    responses.EasyInputMessageTypeMessage,
+			},
+		})
+	}
+
+	raw := bytes.TrimSpace(req.Input)
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return nil, errors.New("missing input")
+	}
+
+	if raw[0] == '"' {
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return nil, fmt.Errorf("invalid input: %w", err)
+		}
+		return append(items, agents.ItemHelpers().InputToNewInputList(agents.InputString(s))...), nil
+	}
+
+	var rawItems []map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &rawItems); err != nil {
+		return nil, fmt.Errorf("invalid input: %w", err)
+	}
+	for i, fields := range rawItems {
+		// The "type" field is optional for messages.
+		if _, hasType := fields["type"]; !hasType {
+			if _, hasRole := fields["role"]; hasRole {
+				fields["type"] = json.RawMessage(`"message"`)
+			}
+		}
+		b, err := json.Marshal(fields)
+		if err != nil {
+			return nil, fmt.Errorf("invalid input[%d]: %w", i, err)
+		}
+		var item agents.TResponseInputItem
+		if err = json.Unmarshal(b, &item); err != nil {
+			return nil, fmt.Errorf("invalid input[%d]: %w", i, err)
+		}
+		items = append(items, item)
+	}
+	if len(items) == 0 {
+		return nil, errors.New("input must not be empty")
+	}
+	return items, nil
+}
@@ -0,0 +1,333 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package openaiserver exposes agents through an OpenAI-compatible HTTP API.
+//
+// Each registered agent is published as a "model": existing OpenAI clients
+// can call it through the `/v1/chat/completions` and `/v1/responses`
+// endpoints, both in blocking and in streaming mode, without knowing that a
+// whole multi-agent workflow runs behind it.
+package openaiserver
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/usage"
+)
+
+// Server is an http.Handler implementing a subset of the OpenAI API on top of
+// registered agents.
+//
+// Supported endpoints:
+//   - GET  /v1/models
+//   - POST /v1/chat/completions
+//   - POST /v1/responses
+//
+// Each request runs the agent registered under the requested model name,
+// using the configured Runner.
+//
+// The response content is the final output of the run, in streaming mode
+// too: only the text of the model response producing the final output is
+// streamed. The text deltas of an agent without tools, handoffs or output
+// guardrails are forwarded as soon as they are generated; otherwise, the
+// text of a model response is held back until the response is complete and
+// known to be final. The text produced by intermediate agents before a
+// handoff or alongside tool calls is only streamed when enabled with
+// WithIntermediateText.
+type Server struct {
+	runner           agents.Runner
+	intermediateText bool
+	mu               sync.RWMutex
+	agents           map[string]*agents.Agent
+	mux              *http.ServeMux
+	now              func() time.Time
+}
+
+// NewServer creates a new Server which runs agents with the given Runner.
+func NewServer(runner agents.Runner) *Server {
+	s := &Server{
+		runner: runner,
+		agents: make(map[string]*agents.Agent),
+		mux:    http.NewServeMux(),
+		now:    time.Now,
+	}
+	s.mux.HandleFunc("GET /v1/models", s.handleListModels)
+	s.mux.HandleFunc("POST /v1/chat/completions", s.handleChatCompletions)
+	s.mux.HandleFunc("POST /v1/responses", s.handleResponses)
+	return s
+}
+
+// Register exposes the agent under the given model name.
+// A previously registered agent with the same name is replaced.
+func (s *Server) Register(modelName string, agent *agents.Agent) *Server {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.agents[modelName] = agent
+	return s
+}
+
+// WithIntermediateText sets whether streaming responses forward the text deltas
+// of every model response as soon as they are generated, including the text
+// produced by intermediate agents before a handoff or alongside tool calls.
+// The streamed content can then be a superset of the final output.
+func (s *Server) WithIntermediateText(enabled bool) *Server {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.intermediateText = enabled
+	return s
+}
+
+// ModelNames returns the sorted list of registered model names.
+func (s *Server) ModelNames() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	names := make([]string, 0, len(s.agents))
+	for name := range s.agents {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) getAgent(modelName string) (*agents.Agent, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	agent, ok := s.agents[modelName]
+	return agent, ok
+}
+
+// newTextFilter returns a textFilter for a streamed run of the given agent.
+func (s *Server) newTextFilter(agent *agents.Agent) *textFilter {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return &textFilter{
+		agent:            agent,
+		intermediateText: s.intermediateText,
+		runnerGuardrails: len(s.runner.Config.OutputGuardrails) > 0,
+	}
+}
+
+type modelObject struct {
+	ID      string `json:"id"`
+	Object  string `json:"object"`
+	Created int64  `json:"created"`
+	OwnedBy string `json:"owned_by"`
+}
+
+type modelList struct {
+	Object string        `json:"object"`
+	Data   []modelObject `json:"data"`
+}
+
+func (s *Server) handleListModels(w http.ResponseWriter, _ *http.Request) {
+	names := s.ModelNames()
+	list := modelList{Object: "list", Data: make([]modelObject, len(names))}
+	created := s.now().Unix()
+	for i, name := range names {
+		list.Data[i] = modelObject{ID: name, Object: "model", Created: created, OwnedBy: "openai-agents-go"}
+	}
+	writeJSON(w, http.StatusOK, list)
+}
+
+// run executes the agent in blocking mode.
+func (s *Server) run(r *http.Request, agent *agents.Agent, input []agents.TResponseInputItem) (*agents.RunResult, error) {
+	return s.runner.RunResponseInputs(r.Context(), agent, input)
+}
+
+// runStreamed executes the agent in streaming mode.
+func (s *Server) runStreamed(r *http.Request, agent *agents.Agent, input []agents.TResponseInputItem) (*agents.RunResultStreaming, error) {
+	return s.runner.RunResponseInputsStreamed(r.Context(), agent, input)
+}
+
+type apiError struct {
+	Message string  `json:"message"`
+	Type    string  `json:"type"`
+	Param   *string `json:"param"`
+	Code    *string `json:"code"`
+}
+
+type errorResponse struct {
+	Error apiError `json:"error"`
+}
+
+func writeError(w http.ResponseWriter, status int, errType string, format string, a ...any) {
+	writeJSON(w, status, errorResponse{Error: apiError{
+		Message: fmt.Sprintf(format, a...),
+		Type:    errType,
+	}})
+}
+
+func writeModelNotFound(w http.ResponseWriter, modelName string) {
+	code := "model_not_found"
+	writeJSON(w, http.StatusNotFound, errorResponse{Error: apiError{
+		Message: fmt.Sprintf("The model %q does not exist.", modelName),
+		Type:    "invalid_request_error",
+		Code:    &code,
+	}})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// sseWriter writes Server-Sent Events in the format used by the OpenAI API.
+type sseWriter struct {
+	w       http.ResponseWriter
+	flusher http.Flusher
+}
+
+func newSSEWriter(w http.ResponseWriter) (*sseWriter, bool) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		return nil, false
+	}
+	header := w.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+	return &sseWriter{w: w, flusher: flusher}, true
+}
+
+// writeData writes a data-only message, as used by the Chat Completions API.
+func (s *sseWriter) writeData(v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return s.writeRaw("", data)
+}
+
+// writeEvent writes a named event, as used by the Responses API.
+func (s *sseWriter) writeEvent(eventType string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return s.writeRaw(eventType, data)
+}
+
+func (s *sseWriter) writeRaw(eventType string, data []byte) error {
+	var err error
+	if eventType != "" {
+		_, err = fmt.Fprintf(s.w, "event: %s\ndata: %s\n\n", eventType, data)
+	} else {
+		_, err = fmt.Fprintf(s.w, "data: %s\n\n", data)
+	}
+	if err == nil {
+		s.flusher.Flush()
+	}
+	return err
+}
+
+// totalUsage sums the usage of all model responses of a run.
+func totalUsage(responses []agents.ModelResponse) *usage.Usage {
+	u := usage.NewUsage()
+	for _, r := range responses {
+		if r.Usage != nil {
+			u.Add(r.Usage)
+		}
+	}
+	return u
+}
+
+// finalOutputText converts the final output of a run to the text returned to
+// the client. Structured outputs are returned as JSON.
+func finalOutputText(finalOutput any) (string, error) {
+	switch v := finalOutput.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case []byte:
+		return string(v), nil
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal final output: %w", err)
+		}
+		return string(b), nil
+	}
+}
+
+// textFilter selects the text deltas of a streamed run to forward to the client.
+type textFilter struct {
+	agent            *agents.Agent
+	intermediateText bool
+	runnerGuardrails bool
+	pending          []string
+}
+
+// add processes a streaming event, returning the text deltas to forward, if any.
+//
+// Unless intermediate text is enabled, the deltas are held back until the end of
+// the model response, then forwarded only if the response has no other output
+// than messages, and no output guardrail can reject it. The deltas of an agent
+// which can only produce a final output are forwarded immediately.
+func (f *textFilter) add(event agents.StreamEvent) []string {
+	switch e := event.(type) {
+	case agents.AgentUpdatedStreamEvent:
+		f.agent = e.NewAgent
+		f.pending = nil
+	case agents.RawResponsesStreamEvent:
+		switch e.Data.Type {
+		case "response.output_text.delta":
+			delta := e.Data.Delta.OfString
+			if f.intermediateText || f.alwaysFinal() {
+				return []string{delta}
+			}
+			f.pending = append(f.pending, delta)
+		case "response.completed":
+			pending := f.pending
+			f.pending = nil
+			final := !f.hasOutputGuardrails() && !slices.ContainsFunc(e.Data.Response.Output, func(item agents.TResponseOutputItem) bool {
+				return item.Type != "message"
+			})
+			if final {
+				return pending
+			}
+		}
+	}
+	return nil
+}
+
+// alwaysFinal reports whether any response of the current agent produces the
+// final output.
+func (f *textFilter) alwaysFinal() bool {
+	return len(f.agent.Tools) == 0 && len(f.agent.Handoffs) == 0 && len(f.agent.AgentHandoffs) == 0 &&
+		!f.hasOutputGuardrails()
+}
+
+func (f *textFilter) hasOutputGuardrails() bool {
+	return f.runnerGuardrails || len(f.agent.OutputGuardrails) > 0
+}
+
+func newID(prefix string) string {
+	return prefix + strings.ToLower(rand.Text())
+}
@@ -16,6 +16,8 @@ package agents
 
 import (
 	"context"
+
+	"github.com/openai/openai-go/packages/param"
 )
 
 // An InputGuardrail is a check that runs in parallel to the agent's execution.
@@ -26,6 +28,8 @@ import (
 //
 // Guardrails return a InputGuardrailResult. If GuardrailFunctionOutput.TripwireTriggered is true,
 // the agent execution will immediately stop and an InputGuardrailTripwireTriggeredError will be returned.
+// Otherwise, the guardrail can replace the input or end the run with a fallback output: see
+// GuardrailBehavior.
 type InputGuardrail struct {
 	// A function that receives the agent input and the context, and returns a
 	// GuardrailFunctionOutput. The result marks whether the tripwire was
@@ -34,6 +38,14 @@ type InputGuardrail struct {
 
 	// The name of the guardrail, used for error reporting and debugging.
 	Name string
+
+	// Whether the guardrail may return GuardrailRewrite or GuardrailFallback.
+	// When any input guardrail of a run can rewrite, the first turn only starts
+	// after all input guardrails complete, so that no tool is executed and no
+	// hook is called for an input which is then replaced. Guardrails without
+	// it run in parallel with the first turn, and the run fails with a UserError
+	// if they return GuardrailRewrite or GuardrailFallback.
+	CanRewrite bool
 }
 
 type InputGuardrailFunction = func(context.Context, *Agent, Input) (GuardrailFunctionOutput, error)
@@ -64,13 +76,89 @@ type GuardrailFunctionOutput struct {
 
 	// Whether the tripwire was triggered. If triggered, the agent's execution will be halted.
 	TripwireTriggered bool
+
+	// What the runner should do when the tripwire is not triggered. Default: GuardrailAllow.
+	Behavior GuardrailBehavior
+
+	// The input replacing the run input, when an input guardrail returns GuardrailRewrite.
+	ReplacementInput Input
+
+	// The final output of the run, when an output guardrail returns GuardrailRewrite, or
+	// any guardrail returns GuardrailFallback.
+	ReplacementOutput any
+
+	// The message sent to the model as a user message before running the agent again, when
+	// an output guardrail returns GuardrailRetry.
+	Feedback string
+}
+
+// GuardrailBehavior is the action requested by a guardrail whose tripwire was not triggered.
+//
+// When several guardrails request an action other than GuardrailAllow, only the first one,
+// in the order in which the guardrails are listed, is applied. A triggered tripwire always
+// takes precedence.
+//
+// In streamed runs, the model response is already being streamed while the input guardrails
+// run: input guardrails requesting an action other than GuardrailAllow make the run fail with
+// a UserError.
+type GuardrailBehavior uint8
+
+const (
+	// GuardrailAllow lets the run continue normally.
+	GuardrailAllow GuardrailBehavior = iota
+
+	// GuardrailRewrite replaces the checked data. For an input guardrail, the first turn is
+	// run (again) with GuardrailFunctionOutput.ReplacementInput; for an output guardrail, the
+	// final output is replaced with GuardrailFunctionOutput.ReplacementOutput.
+	//
+	// Input guardrails requesting it must set InputGuardrail.CanRewrite, so that the first
+	// turn waits for them.
+	GuardrailRewrite
+
+	// GuardrailRetry, for output guardrails only, rejects the final output and runs the agent
+	// again, with GuardrailFunctionOutput.Feedback appended to the conversation as a user
+	// message (a GuardrailFeedbackItem). After OutputGuardrail.MaxRetries retries, the run
+	// fails with an OutputGuardrailTripwireTriggeredError.
+	GuardrailRetry
+
+	// GuardrailFallback ends the run with GuardrailFunctionOutput.ReplacementOutput as final
+	// output, such as a canned response. The other output guardrails are not run on it.
+	//
+	// Input guardrails requesting it must set InputGuardrail.CanRewrite, as for GuardrailRewrite.
+	GuardrailFallback
+)
+
+// GuardrailReplaceInput returns a GuardrailFunctionOutput replacing the run input.
+func GuardrailReplaceInput(input Input, outputInfo any) GuardrailFunctionOutput {
+	return GuardrailFunctionOutput{OutputInfo: outputInfo, Behavior: GuardrailRewrite, ReplacementInput: input}
+}
+
+// GuardrailRewriteOutput returns a GuardrailFunctionOutput replacing the final output.
+func GuardrailRewriteOutput(output any, outputInfo any) GuardrailFunctionOutput {
+	return GuardrailFunctionOutput{OutputInfo: outputInfo, Behavior: GuardrailRewrite, ReplacementOutput: output}
+}
+
+// GuardrailRetryWithFeedback returns a GuardrailFunctionOutput asking the runner to run the
+// agent again with the given feedback.
+func GuardrailRetryWithFeedback(feedback string, outputInfo any) GuardrailFunctionOutput {
+	return GuardrailFunctionOutput{OutputInfo: outputInfo, Behavior: GuardrailRetry, Feedback: feedback}
+}
+
+// GuardrailFallbackOutput returns a GuardrailFunctionOutput ending the run with the given
+// final output.
+func GuardrailFallbackOutput(output any, outputInfo any) GuardrailFunctionOutput {
+	return GuardrailFunctionOutput{OutputInfo: outputInfo, Behavior: GuardrailFallback, ReplacementOutput: output}
 }
 
+// DefaultOutputGuardrailMaxRetries is the default OutputGuardrail.MaxRetries.
+const DefaultOutputGuardrailMaxRetries = 2
+
 // An OutputGuardrail is a check that runs on the final output of an agent.
 // Output guardrails can be used to do check if the output passes certain validation criteria.
 //
 // Guardrails return an OutputGuardrailResult. If GuardrailFunctionOutput.TripwireTriggered is true,
-// an OutputGuardrailTripwireTriggeredError will be returned.
+// an OutputGuardrailTripwireTriggeredError will be returned. Otherwise, the guardrail can rewrite
+// the output, ask the runner to retry, or replace the output with a fallback: see GuardrailBehavior.
 type OutputGuardrail struct {
 	// A function that receives the final agent, its output, and the context, and returns a
 	// GuardrailFunctionOutput. The result marks whether the tripwire was triggered, and can optionally
@@ -79,6 +167,11 @@ type OutputGuardrail struct {
 
 	// The name of the guardrail, used for error reporting and debugging.
 	Name string
+
+	// The maximum number of times the guardrail can ask the runner to run the agent again
+	// with GuardrailRetry, in a run. Retries are counted by guardrail name.
+	// Default (when not set): DefaultOutputGuardrailMaxRetries.
+	MaxRetries param.Opt[int]
 }
 
 type OutputGuardrailFunction = func(ctx context.Context, agent *Agent, agentOutput any) (GuardrailFunctionOutput, error)
@@ -0,0 +1,321 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents_test
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"testing"
+	"time"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/agentstesting"
+	"github.com/nlpodyssey/openai-agents-go/usage"
+	"github.com/openai/openai-go/packages/param"
+	"github.com/openai/openai-go/responses"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// echoModel answers with the text of the first input message.
+type echoModel struct{}
+
+func (echoModel) GetResponse(_ context.Context, params agents.ModelResponseParams) (*agents.ModelResponse, error) {
+	var text string
+	switch input := params.Input.(type) {
+	case agents.InputString:
+		text = string(input)
+	case agents.InputItems:
+		text = input[0].OfMessage.Content.OfString.Value
+	}
+	return &agents.ModelResponse{
+		Output: []agents.TResponseOutputItem{agentstesting.GetTextMessage("echo: " + text)},
+		Usage:  usage.NewUsage(),
+	}, nil
+}
+
+func (echoModel) StreamResponse(context.Context, agents.ModelResponseParams) (iter.Seq2[*agents.TResponseStreamEvent, error], error) {
+	return nil, errors.New("not implemented")
+}
+
+// rejectOutputGuardrail asks for a retry while the output is the given text.
+func rejectOutputGuardrail(rejected string, maxRetries param.Opt[int]) agents.OutputGuardrail {
+	return agents.OutputGuardrail{
+		Name:       "reject",
+		MaxRetries: maxRetries,
+		GuardrailFunction: func(_ context.Context, _ *agents.Agent, output any) (agents.GuardrailFunctionOutput, error) {
+			if output == rejected {
+				return agents.GuardrailRetryWithFeedback("try again", nil), nil
+			}
+			return agents.GuardrailFunctionOutput{}, nil
+		},
+	}
+}
+
+func TestInputGuardrailReplacesInput(t *testing.T) {
+	agent := agents.New("test").
+		WithModelInstance(echoModel{}).
+		WithInputGuardrails([]agents.InputGuardrail{{
+			Name:       "sanitize",
+			CanRewrite: true,
+			GuardrailFunction: func(context.Context, *agents.Agent, agents.Input) (agents.GuardrailFunctionOutput, error) {
+				return agents.GuardrailReplaceInput(agents.InputString("sanitized"), nil), nil
+			},
+		}})
+
+	result, err := agents.Run(t.Context(), agent, "original")
+	require.NoError(t, err)
+	assert.Equal(t, "echo: sanitized", result.FinalOutput)
+	assert.Equal(t, agents.InputString("sanitized"), result.Input)
+	assert.Len(t, result.RawResponses, 1)
+	require.Len(t, result.InputGuardrailResults, 1)
+	assert.Equal(t, agents.GuardrailRewrite, result.InputGuardrailResults[0].Output.Behavior)
+}
+
+func TestInputGuardrailCanRewriteWaitsBeforeFirstTurn(t *testing.T) {
+	toolCalls := 0
+	tool := agentstesting.GetFunctionTool("delete", "deleted")
+	tool.OnInvokeTool = func(context.Context, string) (any, error) {
+		toolCalls++
+		return "deleted", nil
+	}
+
+	model := agentstesting.NewScriptedFakeModel(
+		agentstesting.Turn().
+			When(agentstesting.InputContains("original"), agentstesting.GetFunctionToolCall("delete", `{}`)).
+			Reply(agentstesting.GetTextMessage("done")),
+	)
+	agent := agents.New("test").
+		WithModelInstance(model).
+		WithTools(tool).
+		WithInputGuardrails([]agents.InputGuardrail{{
+			Name:       "sanitize",
+			CanRewrite: true,
+			GuardrailFunction: func(context.Context, *agents.Agent, agents.Input) (agents.GuardrailFunctionOutput, error) {
+				// Without waiting, the first turn would run on the original input meanwhile.
+				time.Sleep(20 * time.Millisecond)
+				return agents.GuardrailReplaceInput(agents.InputString("sanitized"), nil), nil
+			},
+		}})
+	hooks := NewRunHooksForTests()
+
+	result, err := agents.Runner{Config: agents.RunConfig{Hooks: hooks}}.Run(t.Context(), agent, "original")
+	require.NoError(t, err)
+	assert.Equal(t, "done", result.FinalOutput)
+	assert.Equal(t, 0, toolCalls)
+	assert.Equal(t, 1, hooks.Events["OnAgentStart"])
+	calls := model.GetCalls()
+	require.Len(t, calls, 1)
+	assert.NoError(t, agentstesting.InputContains("sanitized")(calls[0]))
+}
+
+func TestInputGuardrailFallback(t *testing.T) {
+	agent := agents.New("test").
+		WithModelInstance(echoModel{}).
+		WithInputGuardrails([]agents.InputGuardrail{{
+			Name:       "off_topic",
+			CanRewrite: true,
+			GuardrailFunction: func(context.Context, *agents.Agent, agents.Input) (agents.GuardrailFunctionOutput, error) {
+				return agents.GuardrailFallbackOutput("I can only talk about cooking.", nil), nil
+			},
+		}})
+
+	result, err := agents.Run(t.Context(), agent, "hello")
+	require.NoError(t, err)
+	assert.Equal(t, "I can only talk about cooking.", result.FinalOutput)
+	assert.Empty(t, result.NewItems)
+	assert.Empty(t, result.RawResponses)
+}
+
+func TestInputGuardrailRewriteRequiresCanRewrite(t *testing.T) {
+	// Without CanRewrite, the first turn may already have run on the input.
+	for _, output := range []agents.GuardrailFunctionOutput{
+		agents.GuardrailReplaceInput(agents.InputString("sanitized"), nil),
+		agents.GuardrailFallbackOutput("I can only talk about cooking.", nil),
+	} {
+		agent := agents.New("test").
+			WithModelInstance(echoModel{}).
+			WithInputGuardrails([]agents.InputGuardrail{{
+				Name: "sanitize",
+				GuardrailFunction: func(context.Context, *agents.Agent, agents.Input) (agents.GuardrailFunctionOutput, error) {
+					return output, nil
+				},
+			}})
+
+		_, err := agents.Run(t.Context(), agent, "original")
+		assert.ErrorAs(t, err, &agents.UserError{})
+	}
+}
+
+func TestInputGuardrailRetryIsInvalid(t *testing.T) {
+	agent := agents.New("test").
+		WithModelInstance(echoModel{}).
+		WithInputGuardrails([]agents.InputGuardrail{{
+			Name: "retry",
+			GuardrailFunction: func(context.Context, *agents.Agent, agents.Input) (agents.GuardrailFunctionOutput, error) {
+				return agents.GuardrailRetryWithFeedback("no", nil), nil
+			},
+		}})
+
+	_, err := agents.Run(t.Context(), agent, "hello")
+	var userErr agents.UserError
+	assert.ErrorAs(t, err, &userErr)
+}
+
+func TestOutputGuardrailRewritesOutput(t *testing.T) {
+	model := agentstesting.NewFakeModel(&agentstesting.FakeModelTurnOutput{
+		Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("damn good")},
+	})
+	agent := agents.New("test").
+		WithModelInstance(model).
+		WithOutputGuardrails([]agents.OutputGuardrail{
+			{
+				Name: "allow",
+				GuardrailFunction: func(context.Context, *agents.Agent, any) (agents.GuardrailFunctionOutput, error) {
+					return agents.GuardrailFunctionOutput{}, nil
+				},
+			},
+			{
+				Name: "profanity",
+				GuardrailFunction: func(context.Context, *agents.Agent, any) (agents.GuardrailFunctionOutput, error) {
+					return agents.GuardrailRewriteOutput("**** good", nil), nil
+				},
+			},
+			{
+				Name: "fallback",
+				GuardrailFunction: func(context.Context, *agents.Agent, any) (agents.GuardrailFunctionOutput, error) {
+					return agents.GuardrailFallbackOutput("ignored", nil), nil
+				},
+			},
+		})
+
+	result, err := agents.Run(t.Context(), agent, "hello")
+	require.NoError(t, err)
+	// Only the first action is applied.
+	assert.Equal(t, "**** good", result.FinalOutput)
+	require.Len(t, result.OutputGuardrailResults, 3)
+	assert.Equal(t, "damn good", result.OutputGuardrailResults[1].AgentOutput)
+}
+
+func TestOutputGuardrailRetry(t *testing.T) {
+	model := agentstesting.NewFakeModel(nil)
+	model.AddMultipleTurnOutputs([]agentstesting.FakeModelTurnOutput{
+		{Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("bad")}},
+		{Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("good")}},
+	})
+	agent := agents.New("test").
+		WithModelInstance(model).
+		WithOutputGuardrails([]agents.OutputGuardrail{rejectOutputGuardrail("bad", param.Opt[int]{})})
+
+	result, err := agents.Run(t.Context(), agent, "hello")
+	require.NoError(t, err)
+	assert.Equal(t, "good", result.FinalOutput)
+
+	require.Len(t, result.NewItems, 3)
+	feedback, ok := result.NewItems[1].(agents.GuardrailFeedbackItem)
+	require.True(t, ok)
+	assert.Equal(t, "reject", feedback.GuardrailName)
+	assert.Same(t, agent, feedback.Agent)
+
+	// The model saw its rejected output, followed by the feedback.
+	input := model.LastTurnArgs.Input.(agents.InputItems)
+	require.Len(t, input, 3)
+	assert.Equal(t, responses.EasyInputMessageRoleUser, input[2].OfMessage.Role)
+	assert.Equal(t, "try again", input[2].OfMessage.Content.OfString.Value)
+
+	require.Len(t, result.OutputGuardrailResults, 2)
+	assert.Equal(t, agents.GuardrailRetry, result.OutputGuardrailResults[0].Output.Behavior)
+	assert.Equal(t, agents.GuardrailAllow, result.OutputGuardrailResults[1].Output.Behavior)
+}
+
+func TestOutputGuardrailRetriesExhausted(t *testing.T) {
+	model := agentstesting.NewFakeModel(nil)
+	model.AddMultipleTurnOutputs([]agentstesting.FakeModelTurnOutput{
+		{Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("bad")}},
+		{Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("bad")}},
+		{Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("good")}},
+	})
+	agent := agents.New("test").
+		WithModelInstance(model).
+		WithOutputGuardrails([]agents.OutputGuardrail{rejectOutputGuardrail("bad", param.NewOpt(1))})
+
+	_, err := agents.Run(t.Context(), agent, "hello")
+	var tripwireErr agents.OutputGuardrailTripwireTriggeredError
+	require.ErrorAs(t, err, &tripwireErr)
+	assert.Equal(t, "reject", tripwireErr.GuardrailResult.Guardrail.Name)
+	assert.Len(t, tripwireErr.RunData.OutputGuardrailResults, 2)
+}
+
+func TestOutputGuardrailZeroRetries(t *testing.T) {
+	model := agentstesting.NewFakeModel(&agentstesting.FakeModelTurnOutput{
+		Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("bad")},
+	})
+	agent := agents.New("test").
+		WithModelInstance(model).
+		WithOutputGuardrails([]agents.OutputGuardrail{rejectOutputGuardrail("bad", param.NewOpt(0))})
+
+	_, err := agents.Run(t.Context(), agent, "hello")
+	var tripwireErr agents.OutputGuardrailTripwireTriggeredError
+	require.ErrorAs(t, err, &tripwireErr)
+	assert.Len(t, model.GetCalls(), 1)
+}
+
+func TestOutputGuardrailRetryStreamed(t *testing.T) {
+	model := agentstesting.NewFakeModel(nil)
+	model.AddMultipleTurnOutputs([]agentstesting.FakeModelTurnOutput{
+		{Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("bad")}},
+		{Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("good")}},
+	})
+	agent := agents.New("test").
+		WithModelInstance(model).
+		WithOutputGuardrails([]agents.OutputGuardrail{rejectOutputGuardrail("bad", param.Opt[int]{})})
+
+	result, err := agents.RunStreamed(t.Context(), agent, "hello")
+	require.NoError(t, err)
+
+	var feedbackEvents int
+	err = result.StreamEvents(func(event agents.StreamEvent) error {
+		if e, ok := event.(agents.RunItemStreamEvent); ok && e.Name == agents.StreamEventGuardrailFeedback {
+			feedbackEvents++
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "good", result.FinalOutput())
+	assert.Equal(t, 1, feedbackEvents)
+	assert.Len(t, result.NewItems(), 3)
+	assert.Len(t, result.OutputGuardrailResults(), 2)
+}
+
+func TestInputGuardrailRewriteStreamedIsInvalid(t *testing.T) {
+	model := agentstesting.NewFakeModel(&agentstesting.FakeModelTurnOutput{
+		Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("ok")},
+	})
+	agent := agents.New("test").
+		WithModelInstance(model).
+		WithInputGuardrails([]agents.InputGuardrail{{
+			Name: "sanitize",
+			GuardrailFunction: func(context.Context, *agents.Agent, agents.Input) (agents.GuardrailFunctionOutput, error) {
+				return agents.GuardrailReplaceInput(agents.InputString("sanitized"), nil), nil
+			},
+		}})
+
+	result, err := agents.RunStreamed(t.Context(), agent, "hello")
+	require.NoError(t, err)
+	err = result.StreamEvents(func(agents.StreamEvent) error { return nil })
+	var userErr agents.UserError
+	assert.ErrorAs(t, err, &userErr)
+}
@@ -0,0 +1,270 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// DefaultStreamingGuardrailCheckChars is the default number of characters of
+// new text after which a StreamingOutputGuardrail is run again.
+const DefaultStreamingGuardrailCheckChars = 300
+
+// A StreamingOutputGuardrail is a check that runs on the partial text output of an agent
+// while the model response is being streamed, so that forbidden content can be detected
+// before the response is complete. It is only used by streamed runs.
+//
+// The check runs in the background each time enough new text has been accumulated; at most
+// one check per guardrail runs at a time, and a check finding enough new text at its completion
+// is immediately followed by another. A last check runs on the complete text of the response,
+// if it changed since the previous one.
+//
+// The stream events are held back until a check covering their text has passed, so that
+// forbidden content is never delivered to the stream consumer: text is released in chunks
+// of CheckEveryChars (or CheckEveryTokens), after each check. Set DeliverUnchecked to forward
+// the events without waiting for the checks, trading this guarantee for latency.
+//
+// If GuardrailFunctionOutput.TripwireTriggered is true, the model stream is canceled, the
+// events still held back are discarded, and the run fails with an
+// OutputGuardrailTripwireTriggeredError.
+type StreamingOutputGuardrail struct {
+	// A function that receives the agent and the text streamed so far in the current model
+	// response, and returns a GuardrailFunctionOutput.
+	GuardrailFunction StreamingOutputGuardrailFunction
+
+	// The name of the guardrail, used for error reporting and debugging.
+	Name string
+
+	// Run the check each time the text grows by at least this number of characters.
+	// Default (when both CheckEveryChars and CheckEveryTokens are zero):
+	// DefaultStreamingGuardrailCheckChars.
+	CheckEveryChars int
+
+	// Run the check each time the text grows by at least this number of tokens.
+	// Tokens are approximated by the number of text delta events, each of which
+	// usually carries a single token.
+	CheckEveryTokens int
+
+	// If true, the stream events are forwarded to the stream consumer without
+	// waiting for the checks of this guardrail. The text checked when the tripwire
+	// triggers, and any text received while the check was running, has then
+	// already been delivered: consumers should discard it when the run fails.
+	DeliverUnchecked bool
+}
+
+type StreamingOutputGuardrailFunction = func(ctx context.Context, agent *Agent, partialText string) (GuardrailFunctionOutput, error)
+
+func (g StreamingOutputGuardrail) Run(ctx context.Context, agent *Agent, partialText string) (OutputGuardrailResult, error) {
+	output, err := g.GuardrailFunction(ctx, agent, partialText)
+	result := OutputGuardrailResult{
+		Guardrail:   g.outputGuardrail(),
+		Agent:       agent,
+		AgentOutput: partialText,
+		Output:      output,
+	}
+	return result, err
+}
+
+// outputGuardrail returns an OutputGuardrail running the same check on a
+// final output, used to describe the guardrail in an OutputGuardrailResult.
+func (g StreamingOutputGuardrail) outputGuardrail() OutputGuardrail {
+	return OutputGuardrail{
+		Name: g.Name,
+		GuardrailFunction: func(ctx context.Context, agent *Agent, agentOutput any) (GuardrailFunctionOutput, error) {
+			text, ok := agentOutput.(string)
+			if !ok {
+				text = fmt.Sprint(agentOutput)
+			}
+			return g.GuardrailFunction(ctx, agent, text)
+		},
+	}
+}
+
+// isDue reports whether a new check is needed, given the growth of the text
+// since the previous check.
+func (g StreamingOutputGuardrail) isDue(newChars, newTokens int) bool {
+	if g.CheckEveryChars <= 0 && g.CheckEveryTokens <= 0 {
+		return newChars >= DefaultStreamingGuardrailCheckChars
+	}
+	return (g.CheckEveryChars > 0 && newChars >= g.CheckEveryChars) ||
+		(g.CheckEveryTokens > 0 && newTokens >= g.CheckEveryTokens)
+}
+
+// streamingGuardrailChecker runs the streaming output guardrails of an agent
+// on the text of a model response while it is streamed, holding back the
+// stream events until their text has been checked.
+type streamingGuardrailChecker struct {
+	ctx          context.Context
+	agent        *Agent
+	cancelStream context.CancelFunc
+	emit         func(TResponseStreamEvent)
+	states       []streamingGuardrailState
+
+	wg      sync.WaitGroup
+	mu      sync.Mutex
+	text    strings.Builder
+	tokens  int
+	pending []pendingStreamEvent
+	err     error
+}
+
+type streamingGuardrailState struct {
+	guardrail     StreamingOutputGuardrail
+	running       bool
+	checkedChars  int
+	checkedTokens int
+	passedChars   int
+}
+
+// pendingStreamEvent is a stream event held back until the text received up
+// to it, textLen characters, has been checked.
+type pendingStreamEvent struct {
+	event   TResponseStreamEvent
+	textLen int
+}
+
+func newStreamingGuardrailChecker(
+	ctx context.Context,
+	agent *Agent,
+	guardrails []StreamingOutputGuardrail,
+	cancelStream context.CancelFunc,
+	emit func(TResponseStreamEvent),
+) *streamingGuardrailChecker {
+	states := make([]streamingGuardrailState, len(guardrails))
+	for i, g := range guardrails {
+		states[i].guardrail = g
+	}
+	return &streamingGuardrailChecker{
+		ctx:          ctx,
+		agent:        agent,
+		cancelStream: cancelStream,
+		emit:         emit,
+		states:       states,
+	}
+}
+
+// addEvent accumulates the text of a stream event, starting the checks which
+// are due, and emits the event as soon as its text has been checked.
+func (c *streamingGuardrailChecker) addEvent(event TResponseStreamEvent) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.err != nil {
+		return
+	}
+	if event.Type == "response.output_text.delta" {
+		c.text.WriteString(event.Delta.OfString)
+		c.tokens++
+		for i := range c.states {
+			s := &c.states[i]
+			if !s.running && s.guardrail.isDue(c.text.Len()-s.checkedChars, c.tokens-s.checkedTokens) {
+				c.startCheck(s)
+			}
+		}
+	}
+	c.pending = append(c.pending, pendingStreamEvent{event: event, textLen: c.text.Len()})
+	c.release()
+}
+
+// release emits, in order, the pending events whose text has passed the
+// checks of all the guardrails holding back the stream.
+// It must be called while holding the lock.
+func (c *streamingGuardrailChecker) release() {
+	passed := c.text.Len()
+	for _, s := range c.states {
+		if !s.guardrail.DeliverUnchecked {
+			passed = min(passed, s.passedChars)
+		}
+	}
+	n := 0
+	for ; n < len(c.pending) && c.pending[n].textLen <= passed; n++ {
+		c.emit(c.pending[n].event)
+	}
+	c.pending = c.pending[n:]
+}
+
+// startCheck runs a guardrail in the background on the current text.
+// It must be called while holding the lock.
+func (c *streamingGuardrailChecker) startCheck(s *streamingGuardrailState) {
+	s.running = true
+	s.checkedChars = c.text.Len()
+	s.checkedTokens = c.tokens
+	text := c.text.String()
+
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		result, err := s.guardrail.Run(c.ctx, c.agent, text)
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		s.running = false
+		if c.err != nil {
+			return
+		}
+		switch {
+		case err != nil:
+			c.err = fmt.Errorf("failed to run streaming output guardrail %s: %w", s.guardrail.Name, err)
+		case result.Output.TripwireTriggered:
+			c.err = NewOutputGuardrailTripwireTriggeredError(result)
+		default:
+			s.passedChars = len(text)
+			c.release()
+			// Catch up with the text received during the check.
+			if s.guardrail.isDue(c.text.Len()-s.checkedChars, c.tokens-s.checkedTokens) {
+				c.startCheck(s)
+			}
+			return
+		}
+		c.pending = nil
+		c.cancelStream()
+	}()
+}
+
+// tripped returns the error of a failed check, if any.
+func (c *streamingGuardrailChecker) tripped() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.err
+}
+
+// finish waits for the running checks, then checks the complete text with
+// the guardrails which have not seen it yet, and emits the events held back
+// if all the checks passed.
+func (c *streamingGuardrailChecker) finish() error {
+	c.wg.Wait()
+
+	c.mu.Lock()
+	if c.err == nil {
+		for i := range c.states {
+			if s := &c.states[i]; s.checkedChars < c.text.Len() {
+				c.startCheck(s)
+			}
+		}
+	}
+	c.mu.Unlock()
+
+	c.wg.Wait()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err == nil {
+		c.release()
+	}
+	return c.err
+}
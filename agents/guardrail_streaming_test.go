@@ -0,0 +1,227 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents_test
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/agentstesting"
+	"github.com/openai/openai-go/responses"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// deltaStreamingModel streams the given text deltas. If hang is true, after
+// the deltas it blocks until the context is canceled, otherwise it completes
+// the response with the whole text.
+type deltaStreamingModel struct {
+	deltas []string
+	hang   bool
+}
+
+func (m deltaStreamingModel) GetResponse(context.Context, agents.ModelResponseParams) (*agents.ModelResponse, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m deltaStreamingModel) StreamResponse(ctx context.Context, _ agents.ModelResponseParams) (iter.Seq2[*agents.TResponseStreamEvent, error], error) {
+	return func(yield func(*agents.TResponseStreamEvent, error) bool) {
+		for _, delta := range m.deltas {
+			event := &agents.TResponseStreamEvent{
+				Type:  "response.output_text.delta",
+				Delta: responses.ResponseStreamEventUnionDelta{OfString: delta},
+			}
+			if !yield(event, nil) {
+				return
+			}
+		}
+		if m.hang {
+			<-ctx.Done()
+			yield(nil, ctx.Err())
+			return
+		}
+		text := strings.Join(m.deltas, "")
+		yield(&agents.TResponseStreamEvent{
+			Response: agentstesting.GetResponseObj([]agents.TResponseOutputItem{agentstesting.GetTextMessage(text)}, "", nil),
+			Type:     "response.completed",
+		}, nil)
+	}, nil
+}
+
+// forbiddenWordGuardrail trips when the text contains the given word, and
+// records the texts it checked.
+func forbiddenWordGuardrail(word string, checked *[]string) agents.StreamingOutputGuardrail {
+	var mu sync.Mutex
+	return agents.StreamingOutputGuardrail{
+		Name:             "forbidden_word",
+		CheckEveryTokens: 2,
+		GuardrailFunction: func(_ context.Context, _ *agents.Agent, partialText string) (agents.GuardrailFunctionOutput, error) {
+			mu.Lock()
+			*checked = append(*checked, partialText)
+			mu.Unlock()
+			return agents.GuardrailFunctionOutput{TripwireTriggered: strings.Contains(partialText, word)}, nil
+		},
+	}
+}
+
+func TestStreamingOutputGuardrailTripsMidStream(t *testing.T) {
+	var checked []string
+	agent := agents.New("test").
+		WithModelInstance(deltaStreamingModel{deltas: []string{"a ", "b ", "secret ", "c "}, hang: true}).
+		WithStreamingOutputGuardrails([]agents.StreamingOutputGuardrail{forbiddenWordGuardrail("secret", &checked)})
+
+	result, err := agents.RunStreamed(t.Context(), agent, "hello")
+	require.NoError(t, err)
+	err = result.StreamEvents(func(agents.StreamEvent) error { return nil })
+
+	var tripwireErr agents.OutputGuardrailTripwireTriggeredError
+	require.ErrorAs(t, err, &tripwireErr)
+	assert.Equal(t, "forbidden_word", tripwireErr.GuardrailResult.Guardrail.Name)
+	assert.Contains(t, tripwireErr.GuardrailResult.AgentOutput, "secret")
+	assert.Same(t, agent, tripwireErr.GuardrailResult.Agent)
+}
+
+func TestStreamingOutputGuardrailChecksFinalText(t *testing.T) {
+	var checked []string
+	guardrail := forbiddenWordGuardrail("secret", &checked)
+	guardrail.CheckEveryTokens = 0
+	guardrail.CheckEveryChars = 1000
+
+	model := deltaStreamingModel{deltas: []string{"a ", "b ", "c"}}
+	agent := agents.New("test").WithModelInstance(model)
+	runner := agents.Runner{Config: agents.RunConfig{
+		StreamingOutputGuardrails: []agents.StreamingOutputGuardrail{guardrail},
+	}}
+
+	result, err := runner.RunStreamed(t.Context(), agent, "hello")
+	require.NoError(t, err)
+	require.NoError(t, result.StreamEvents(func(agents.StreamEvent) error { return nil }))
+	assert.Equal(t, "a b c", result.FinalOutput())
+	assert.Equal(t, []string{"a b c"}, checked)
+
+	// The final check can trip too.
+	checked = nil
+	agent = agents.New("test").WithModelInstance(deltaStreamingModel{deltas: []string{"a ", "secret"}})
+	result, err = runner.RunStreamed(t.Context(), agent, "hello")
+	require.NoError(t, err)
+	err = result.StreamEvents(func(agents.StreamEvent) error { return nil })
+	var tripwireErr agents.OutputGuardrailTripwireTriggeredError
+	assert.ErrorAs(t, err, &tripwireErr)
+}
+
+func TestStreamingOutputGuardrailError(t *testing.T) {
+	failure := errors.New("guardrail failure")
+	agent := agents.New("test").
+		WithModelInstance(deltaStreamingModel{deltas: []string{"a", "b"}, hang: true}).
+		WithStreamingOutputGuardrails([]agents.StreamingOutputGuardrail{{
+			Name:            "failing",
+			CheckEveryChars: 1,
+			GuardrailFunction: func(context.Context, *agents.Agent, string) (agents.GuardrailFunctionOutput, error) {
+				return agents.GuardrailFunctionOutput{}, failure
+			},
+		}})
+
+	result, err := agents.RunStreamed(t.Context(), agent, "hello")
+	require.NoError(t, err)
+	err = result.StreamEvents(func(agents.StreamEvent) error { return nil })
+	assert.ErrorIs(t, err, failure)
+}
+
+func TestStreamingOutputGuardrailDeliversUncheckedText(t *testing.T) {
+	// With DeliverUnchecked, the check of "secret" only completes after the
+	// consumer has received the delta carrying it.
+	received := make(chan struct{})
+	agent := agents.New("test").
+		WithModelInstance(deltaStreamingModel{deltas: []string{"a ", "secret ", "b "}, hang: true}).
+		WithStreamingOutputGuardrails([]agents.StreamingOutputGuardrail{{
+			Name:             "blocking",
+			CheckEveryChars:  1,
+			DeliverUnchecked: true,
+			GuardrailFunction: func(ctx context.Context, _ *agents.Agent, partialText string) (agents.GuardrailFunctionOutput, error) {
+				if !strings.Contains(partialText, "secret") {
+					return agents.GuardrailFunctionOutput{}, nil
+				}
+				select {
+				case <-received:
+				case <-ctx.Done():
+					return agents.GuardrailFunctionOutput{}, ctx.Err()
+				}
+				return agents.GuardrailFunctionOutput{TripwireTriggered: true}, nil
+			},
+		}})
+
+	result, err := agents.RunStreamed(t.Context(), agent, "hello")
+	require.NoError(t, err)
+	var deltas []string
+	err = result.StreamEvents(func(event agents.StreamEvent) error {
+		if e, ok := event.(agents.RawResponsesStreamEvent); ok && e.Data.Type == "response.output_text.delta" {
+			deltas = append(deltas, e.Data.Delta.OfString)
+			if e.Data.Delta.OfString == "secret " {
+				close(received)
+			}
+		}
+		return nil
+	})
+
+	var tripwireErr agents.OutputGuardrailTripwireTriggeredError
+	require.ErrorAs(t, err, &tripwireErr)
+	assert.Contains(t, deltas, "secret ")
+}
+
+func TestStreamingOutputGuardrailHoldsUncheckedText(t *testing.T) {
+	// A slow check of "secret" trips after the whole response has been
+	// streamed: the text it covers is never delivered.
+	agent := agents.New("test").
+		WithModelInstance(deltaStreamingModel{deltas: []string{"a ", "b ", "secret ", "c "}}).
+		WithStreamingOutputGuardrails([]agents.StreamingOutputGuardrail{{
+			Name:             "slow",
+			CheckEveryTokens: 2,
+			GuardrailFunction: func(ctx context.Context, _ *agents.Agent, partialText string) (agents.GuardrailFunctionOutput, error) {
+				if !strings.Contains(partialText, "secret") {
+					return agents.GuardrailFunctionOutput{}, nil
+				}
+				select {
+				case <-time.After(100 * time.Millisecond):
+				case <-ctx.Done():
+					return agents.GuardrailFunctionOutput{}, ctx.Err()
+				}
+				return agents.GuardrailFunctionOutput{TripwireTriggered: true}, nil
+			},
+		}})
+
+	result, err := agents.RunStreamed(t.Context(), agent, "hello")
+	require.NoError(t, err)
+	var deltas, types []string
+	err = result.StreamEvents(func(event agents.StreamEvent) error {
+		if e, ok := event.(agents.RawResponsesStreamEvent); ok {
+			types = append(types, e.Data.Type)
+			if e.Data.Type == "response.output_text.delta" {
+				deltas = append(deltas, e.Data.Delta.OfString)
+			}
+		}
+		return nil
+	})
+
+	var tripwireErr agents.OutputGuardrailTripwireTriggeredError
+	require.ErrorAs(t, err, &tripwireErr)
+	assert.Equal(t, []string{"a ", "b "}, deltas)
+	assert.NotContains(t, types, "response.completed")
+}
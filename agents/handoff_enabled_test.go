@@ -0,0 +1,142 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/agentstesting"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type authContext struct {
+	Authenticated bool
+}
+
+// handoffsRecordingModel records the names of the handoffs offered at each turn.
+type handoffsRecordingModel struct {
+	*agentstesting.FakeModel
+	offered [][]string
+}
+
+func (m *handoffsRecordingModel) GetResponse(ctx context.Context, params agents.ModelResponseParams) (*agents.ModelResponse, error) {
+	var names []string
+	for _, h := range params.Handoffs {
+		names = append(names, h.ToolName)
+	}
+	m.offered = append(m.offered, names)
+	return m.FakeModel.GetResponse(ctx, params)
+}
+
+func TestHandoffIsEnabled(t *testing.T) {
+	model := &handoffsRecordingModel{FakeModel: agentstesting.NewFakeModel(nil)}
+	escalation := agents.New("escalation").WithModelInstance(model)
+	billing := agents.New("billing").WithModelInstance(model)
+	login := agents.NewFunctionTool("login", "", func(ctx context.Context, _ struct{}) (string, error) {
+		w, _ := agents.RunContextFromContext(ctx)
+		w.Context.(*authContext).Authenticated = true
+		return "ok", nil
+	})
+
+	triage := agents.New("triage").
+		WithModelInstance(model).
+		WithTools(login).
+		WithHandoffs(
+			agents.HandoffFromAgent(agents.HandoffFromAgentParams{
+				Agent: escalation,
+				IsEnabled: agents.HandoffEnablerFunc(func(ctx context.Context, _ *agents.Agent, _ []agents.TResponseInputItem) (bool, error) {
+					auth, _ := agents.ContextValue[*authContext](ctx)
+					return auth.Authenticated, nil
+				}),
+			}),
+			agents.HandoffFromAgent(agents.HandoffFromAgentParams{
+				Agent:     billing,
+				IsEnabled: agents.HandoffDisabled(),
+			}),
+		)
+
+	model.AddMultipleTurnOutputs([]agentstesting.FakeModelTurnOutput{
+		{Value: []agents.TResponseOutputItem{agentstesting.GetFunctionToolCall("login", `{}`)}},
+		{Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("done")}},
+	})
+
+	runner := agents.Runner{Config: agents.RunConfig{Context: &authContext{}}}
+	result, err := runner.Run(t.Context(), triage, "hello")
+	require.NoError(t, err)
+	assert.Equal(t, "done", result.FinalOutput)
+	assert.Equal(t, [][]string{nil, {"transfer_to_escalation"}}, model.offered)
+}
+
+func TestDynamicHandoffs(t *testing.T) {
+	model := agentstesting.NewFakeModel(nil)
+	escalation := agents.New("escalation").WithModelInstance(model)
+
+	var historyLengths []int
+	triage := agents.New("triage").
+		WithModelInstance(model).
+		WithDynamicHandoffs(func(_ context.Context, _ *agents.Agent, history []agents.TResponseInputItem) ([]agents.Handoff, error) {
+			historyLengths = append(historyLengths, len(history))
+			if len(history) < 3 {
+				return nil, nil
+			}
+			return []agents.Handoff{agents.HandoffFromAgent(agents.HandoffFromAgentParams{Agent: escalation})}, nil
+		})
+
+	model.AddMultipleTurnOutputs([]agentstesting.FakeModelTurnOutput{
+		{Value: []agents.TResponseOutputItem{
+			agentstesting.GetTextMessage("a"),
+			agentstesting.GetHandoffToolCall(escalation, "", ""),
+		}},
+		{Value: []agents.TResponseOutputItem{agentstesting.GetHandoffToolCall(escalation, "", "")}},
+		{Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("escalated")}},
+	})
+
+	// The handoff is not available at the first turn: calling it fails.
+	_, err := agents.Run(t.Context(), triage, "hello")
+	var behaviorErr agents.ModelBehaviorError
+	require.ErrorAs(t, err, &behaviorErr)
+
+	historyLengths = nil
+	result, err := agents.RunResponseInputs(t.Context(), triage, []agents.TResponseInputItem{
+		agentstesting.GetTextInputItem("hello"),
+		agentstesting.GetTextInputItem("still there?"),
+		agentstesting.GetTextInputItem("please escalate"),
+	})
+	require.NoError(t, err)
+	assert.Same(t, escalation, result.LastAgent)
+	assert.Equal(t, []int{3}, historyLengths)
+}
+
+func TestHandoffIsEnabledError(t *testing.T) {
+	model := agentstesting.NewFakeModel(&agentstesting.FakeModelTurnOutput{
+		Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("done")},
+	})
+	failure := errors.New("enabler failure")
+	agent := agents.New("triage").
+		WithModelInstance(model).
+		WithHandoffs(agents.HandoffFromAgent(agents.HandoffFromAgentParams{
+			Agent: agents.New("other"),
+			IsEnabled: agents.HandoffEnablerFunc(func(context.Context, *agents.Agent, []agents.TResponseInputItem) (bool, error) {
+				return false, failure
+			}),
+		}))
+
+	_, err := agents.Run(t.Context(), agent, "hello")
+	assert.ErrorIs(t, err, failure)
+}
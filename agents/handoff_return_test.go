@@ -0,0 +1,158 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/agentstesting"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// callStackProbe is a tool recording the call stack depth when invoked.
+func callStackProbe(depths *[]int) agents.FunctionTool {
+	return agents.NewFunctionTool("probe", "", func(ctx context.Context, _ struct{}) (string, error) {
+		w, _ := agents.RunContextFromContext(ctx)
+		*depths = append(*depths, w.CallStackDepth())
+		return "ok", nil
+	})
+}
+
+func returningHandoff(agent *agents.Agent) agents.Handoff {
+	return agents.HandoffFromAgent(agents.HandoffFromAgentParams{
+		Agent:          agent,
+		ReturnToCaller: true,
+	})
+}
+
+func TestHandoffReturnsToCallerOnFinalOutput(t *testing.T) {
+	var depths []int
+	model := agentstesting.NewFakeModel(nil)
+	specialist := agents.New("specialist").WithModelInstance(model).WithTools(callStackProbe(&depths))
+	triage := agents.New("triage").
+		WithModelInstance(model).
+		WithTools(callStackProbe(&depths)).
+		WithHandoffs(returningHandoff(specialist))
+
+	model.AddMultipleTurnOutputs([]agentstesting.FakeModelTurnOutput{
+		{Value: []agents.TResponseOutputItem{agentstesting.GetHandoffToolCall(specialist, "", "")}},
+		{Value: []agents.TResponseOutputItem{agentstesting.GetFunctionToolCall("probe", `{}`)}},
+		{Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("specialist result")}},
+		{Value: []agents.TResponseOutputItem{agentstesting.GetFunctionToolCall("probe", `{}`)}},
+		{Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("all done")}},
+	})
+
+	result, err := agents.Run(t.Context(), triage, "hello")
+	require.NoError(t, err)
+	assert.Equal(t, "all done", result.FinalOutput)
+	assert.Same(t, triage, result.LastAgent)
+	assert.Equal(t, 1, result.MaxCallStackDepth)
+	assert.Equal(t, 0, result.RunContext.CallStackDepth())
+	assert.Equal(t, []int{1, 0}, depths)
+
+	// The caller sees the result of the specialist in the history.
+	assert.Equal(t, "specialist resultall done", agents.ItemHelpers().TextMessageOutputs(result.NewItems))
+}
+
+func TestHandoffReturnsToCallerWithTool(t *testing.T) {
+	model := agentstesting.NewFakeModel(nil)
+	worker := agents.New("worker").WithModelInstance(model)
+	specialist := agents.New("specialist").WithModelInstance(model).WithHandoffs(returningHandoff(worker))
+	triage := agents.New("triage").WithModelInstance(model).WithHandoffs(returningHandoff(specialist))
+
+	model.AddMultipleTurnOutputs([]agentstesting.FakeModelTurnOutput{
+		{Value: []agents.TResponseOutputItem{agentstesting.GetHandoffToolCall(specialist, "", "")}},
+		{Value: []agents.TResponseOutputItem{agentstesting.GetHandoffToolCall(worker, "", "")}},
+		{Value: []agents.TResponseOutputItem{agentstesting.GetFunctionToolCall("return_to_specialist", `{"result":"42"}`)}},
+		{Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("the answer is 42")}},
+		{Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("done")}},
+	})
+
+	result, err := agents.Run(t.Context(), triage, "hello")
+	require.NoError(t, err)
+	assert.Equal(t, "done", result.FinalOutput)
+	assert.Same(t, triage, result.LastAgent)
+	assert.Equal(t, 2, result.MaxCallStackDepth)
+
+	var returnOutput *agents.HandoffOutputItem
+	for _, item := range result.NewItems {
+		if h, ok := item.(agents.HandoffOutputItem); ok && h.TargetAgent == specialist && h.SourceAgent == worker {
+			returnOutput = &h
+		}
+	}
+	require.NotNil(t, returnOutput, "missing handoff output item returning to the specialist")
+
+	// The result is handed over to the caller as the output of the tool.
+	assert.JSONEq(t,
+		`{"assistant": "specialist", "returned_from": "worker", "result": "42"}`,
+		returnOutput.RawItem.OfFunctionCallOutput.Output)
+	input := model.LastTurnArgs.Input.(agents.InputItems)
+	assert.True(t, slices.ContainsFunc(input, func(item agents.TResponseInputItem) bool {
+		return item.OfFunctionCallOutput != nil && item.OfFunctionCallOutput.Output == returnOutput.RawItem.OfFunctionCallOutput.Output
+	}))
+}
+
+func TestReturnToolNotAvailableWithoutCaller(t *testing.T) {
+	model := agentstesting.NewFakeModel(nil)
+	specialist := agents.New("specialist").WithModelInstance(model)
+	triage := agents.New("triage").WithModelInstance(model).WithAgentHandoffs(specialist)
+
+	model.AddMultipleTurnOutputs([]agentstesting.FakeModelTurnOutput{
+		{Value: []agents.TResponseOutputItem{agentstesting.GetHandoffToolCall(specialist, "", "")}},
+		{Value: []agents.TResponseOutputItem{agentstesting.GetFunctionToolCall("return_to_triage", `{"result":"x"}`)}},
+	})
+
+	_, err := agents.Run(t.Context(), triage, "hello")
+	var behaviorErr agents.ModelBehaviorError
+	assert.ErrorAs(t, err, &behaviorErr)
+}
+
+func TestHandoffReturnsToCallerStreamed(t *testing.T) {
+	model := agentstesting.NewFakeModel(nil)
+	specialist := agents.New("specialist").WithModelInstance(model)
+	triage := agents.New("triage").WithModelInstance(model).WithHandoffs(returningHandoff(specialist))
+
+	model.AddMultipleTurnOutputs([]agentstesting.FakeModelTurnOutput{
+		{Value: []agents.TResponseOutputItem{agentstesting.GetHandoffToolCall(specialist, "", "")}},
+		{Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("specialist result")}},
+		{Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("all done")}},
+	})
+
+	result, err := agents.RunStreamed(t.Context(), triage, "hello")
+	require.NoError(t, err)
+
+	type update struct {
+		agent string
+		depth int
+	}
+	var updates []update
+	err = result.StreamEvents(func(event agents.StreamEvent) error {
+		if e, ok := event.(agents.AgentUpdatedStreamEvent); ok {
+			updates = append(updates, update{e.NewAgent.Name, e.CallStackDepth})
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []update{{"triage", 0}, {"specialist", 1}, {"triage", 0}}, updates)
+	assert.Equal(t, "all done", result.FinalOutput())
+	assert.Same(t, triage, result.LastAgent())
+	assert.Equal(t, 0, result.CallStackDepth())
+	assert.Equal(t, 1, result.RunContext().MaxCallStackDepth())
+}
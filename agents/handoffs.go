@@ -72,6 +72,25 @@ type Handoff struct {
 	// true, as it increases the likelihood of correct JSON input.
 	// Defaults to true if omitted.
 	StrictJSONSchema param.Opt[bool]
+
+	// Whether the new agent returns control to the agent which invoked the handoff.
+	//
+	// When true, the handoff pushes a call frame on the run's call stack (see
+	// RunContextWrapper.CallStack). When the new agent produces a final output, or calls
+	// the auto-generated "return_to_<caller>" tool, the frame is popped and the run resumes
+	// with the calling agent, which sees the result in the conversation history: as the
+	// final output message, or as the output of the tool, naming the agent returning.
+	ReturnToCaller bool
+
+	// Optional flag reporting whether the handoff is enabled. It is evaluated at every
+	// turn, and a disabled handoff is not offered to the model for that turn.
+	// It can be either a boolean or a function which allows you to dynamically enable/disable
+	// a handoff based on the run context and the conversation history.
+	// Default value, if omitted: true.
+	IsEnabled HandoffEnabler
+
+	// Whether this is the auto-generated handoff returning to the caller.
+	returnsToCaller bool
 }
 
 func (h Handoff) GetTransferMessage(agent *Agent) string {
@@ -94,6 +113,51 @@ func DefaultHandoffToolDescription(agent *Agent) string {
 	)
 }
 
+// HandoffEnabler reports whether a Handoff is enabled for the next turn of an agent.
+//
+// The run context can be obtained from ctx with RunContextFromContext. The history
+// contains the input of the run followed by all the items generated so far.
+type HandoffEnabler interface {
+	IsEnabled(ctx context.Context, agent *Agent, history []TResponseInputItem) (bool, error)
+}
+
+// HandoffEnabledFlag is a static HandoffEnabler which always returns the configured flag value.
+type HandoffEnabledFlag struct {
+	isEnabled bool
+}
+
+func (f HandoffEnabledFlag) IsEnabled(context.Context, *Agent, []TResponseInputItem) (bool, error) {
+	return f.isEnabled, nil
+}
+
+// NewHandoffEnabledFlag returns a HandoffEnabledFlag which always returns the configured flag value.
+func NewHandoffEnabledFlag(isEnabled bool) HandoffEnabledFlag {
+	return HandoffEnabledFlag{isEnabled: isEnabled}
+}
+
+// HandoffEnabled returns a static HandoffEnabler which always returns true.
+func HandoffEnabled() HandoffEnabler {
+	return NewHandoffEnabledFlag(true)
+}
+
+// HandoffDisabled returns a static HandoffEnabler which always returns false.
+func HandoffDisabled() HandoffEnabler {
+	return NewHandoffEnabledFlag(false)
+}
+
+// HandoffEnablerFunc can wrap a function to implement HandoffEnabler interface.
+type HandoffEnablerFunc func(ctx context.Context, agent *Agent, history []TResponseInputItem) (bool, error)
+
+func (f HandoffEnablerFunc) IsEnabled(ctx context.Context, agent *Agent, history []TResponseInputItem) (bool, error) {
+	return f(ctx, agent, history)
+}
+
+// DynamicHandoffsFunc computes the handoffs of an agent for its next turn.
+//
+// The run context can be obtained from ctx with RunContextFromContext. The history
+// contains the input of the run followed by all the items generated so far.
+type DynamicHandoffsFunc func(ctx context.Context, agent *Agent, history []TResponseInputItem) ([]Handoff, error)
+
 // HandoffInputFilter is a function that filters the input data passed to the next agent.
 type HandoffInputFilter = func(context.Context, HandoffInputData) (HandoffInputData, error)
 
@@ -141,6 +205,12 @@ type HandoffFromAgentParams struct {
 
 	// Optional function that filters the inputs that are passed to the next agent.
 	InputFilter HandoffInputFilter
+
+	// Whether the agent returns control to the caller when done (see Handoff.ReturnToCaller).
+	ReturnToCaller bool
+
+	// Optional flag reporting whether the handoff is enabled (see Handoff.IsEnabled).
+	IsEnabled HandoffEnabler
 }
 
 // HandoffFromAgent creates a Handoff from an Agent. It panics in case of problems.
@@ -240,5 +310,76 @@ func SafeHandoffFromAgent(params HandoffFromAgentParams) (*Handoff, error) {
 		AgentName:        params.Agent.Name,
 		InputFilter:      params.InputFilter,
 		StrictJSONSchema: param.NewOpt(true),
+		ReturnToCaller:   params.ReturnToCaller,
+		IsEnabled:        params.IsEnabled,
 	}, nil
 }
+
+// DefaultReturnToCallerToolName returns the name of the auto-generated tool
+// returning control to the given caller (see Handoff.ReturnToCaller).
+func DefaultReturnToCallerToolName(caller *Agent) string {
+	return transforms.TransformStringFunctionStyle("return_to_" + caller.Name)
+}
+
+// DefaultReturnToCallerToolDescription returns the description of the
+// auto-generated tool returning control to the given caller.
+func DefaultReturnToCallerToolDescription(caller *Agent) string {
+	return fmt.Sprintf(
+		"Return control to the %s agent, which delegated the current task to you, "+
+			"together with the result of your work.",
+		caller.Name,
+	)
+}
+
+// returnToCallerHandoff creates the handoff returning control to the caller of the
+// top frame of the call stack.
+func returnToCallerHandoff(caller *Agent) Handoff {
+	return Handoff{
+		ToolName:        DefaultReturnToCallerToolName(caller),
+		ToolDescription: DefaultReturnToCallerToolDescription(caller),
+		InputJSONSchema: map[string]any{
+			"type":                 "object",
+			"additionalProperties": false,
+			"properties": map[string]any{
+				"result": map[string]any{
+					"type":        "string",
+					"description": "The result of the delegated task.",
+				},
+			},
+			"required": []string{"result"},
+		},
+		OnInvokeHandoff: func(_ context.Context, jsonInput string) (*Agent, error) {
+			_, err := returnToCallerResult(jsonInput)
+			return caller, err
+		},
+		AgentName:        caller.Name,
+		StrictJSONSchema: param.NewOpt(true),
+		returnsToCaller:  true,
+	}
+}
+
+// returnToCallerResult returns the result given to the auto-generated tool
+// returning control to the caller.
+func returnToCallerResult(jsonInput string) (string, error) {
+	var args struct {
+		Result string `json:"result"`
+	}
+	if err := json.Unmarshal([]byte(jsonInput), &args); err != nil {
+		return "", ModelBehaviorErrorf("invalid JSON input for return to caller: %w", err)
+	}
+	return args.Result, nil
+}
+
+// returnToCallerMessage is the tool output of the auto-generated tool returning
+// control to the caller: it hands the result of the callee over to the caller.
+func returnToCallerMessage(caller, callee *Agent, result string) string {
+	b, err := json.Marshal(map[string]any{
+		"assistant":     caller.Name,
+		"returned_from": callee.Name,
+		"result":        result,
+	})
+	if err != nil {
+		panic(err) // this should never happen
+	}
+	return string(b)
+}
@@ -0,0 +1,257 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"text/template"
+	"text/template/parse"
+	"time"
+)
+
+// InstructionsTemplate satisfies InstructionsGetter rendering a text/template
+// with the user-defined run context value of type T (see RunConfig.Context
+// and ContextValue) as data.
+//
+// Besides the standard template functions, the following helpers are available:
+//   - now: the current time, as time.Time
+//   - date: the current date, formatted as "2006-01-02"
+//   - agentName: the name of the agent
+//   - handoffs: the handoffs of the agent, as []InstructionsTemplateHandoff
+//   - tools: the enabled tools of the agent, as []InstructionsTemplateTool
+//
+// Referencing a missing map key is an error.
+type InstructionsTemplate[T any] struct {
+	tmpl *template.Template
+}
+
+// InstructionsTemplateHandoff describes a handoff in an InstructionsTemplate.
+type InstructionsTemplateHandoff struct {
+	// The name of the agent being handed off to.
+	Name string
+	// The description of the handoff.
+	Description string
+}
+
+// InstructionsTemplateTool describes a tool in an InstructionsTemplate.
+type InstructionsTemplateTool struct {
+	// The name of the tool.
+	Name string
+	// The description of the tool, if any.
+	Description string
+}
+
+// NewInstructionsTemplate parses the template text and validates it against
+// the type T: references to fields which do not exist in T are reported as
+// errors. Keys of maps can only be checked when the template is rendered.
+func NewInstructionsTemplate[T any](text string) (*InstructionsTemplate[T], error) {
+	tmpl, err := template.New("instructions").
+		Option("missingkey=error").
+		Funcs(instructionsTemplateFuncs(context.Background(), nil)).
+		Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse instructions template: %w", err)
+	}
+
+	dataType := reflect.TypeFor[T]()
+	for _, t := range tmpl.Templates() {
+		if t.Tree == nil || t.Root == nil {
+			continue
+		}
+		if err = validateTemplateNode(t.Root, dataType, dataType); err != nil {
+			return nil, fmt.Errorf("invalid instructions template: %w", err)
+		}
+	}
+
+	return &InstructionsTemplate[T]{tmpl: tmpl}, nil
+}
+
+// MustInstructionsTemplate is like NewInstructionsTemplate but panics on error.
+func MustInstructionsTemplate[T any](text string) *InstructionsTemplate[T] {
+	t, err := NewInstructionsTemplate[T](text)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// GetInstructions renders the template with the run context value of type T
+// carried by ctx.
+func (t *InstructionsTemplate[T]) GetInstructions(ctx context.Context, a *Agent) (string, error) {
+	data, ok := ContextValue[T](ctx)
+	if !ok {
+		return "", UserErrorf("instructions template requires a run context value of type %s", reflect.TypeFor[T]())
+	}
+	return t.Render(ctx, a, data)
+}
+
+// Render renders the template with the given data.
+func (t *InstructionsTemplate[T]) Render(ctx context.Context, a *Agent, data T) (string, error) {
+	tmpl, err := t.tmpl.Clone()
+	if err != nil {
+		return "", err
+	}
+	tmpl.Funcs(instructionsTemplateFuncs(ctx, a))
+
+	var sb strings.Builder
+	if err = tmpl.Execute(&sb, data); err != nil {
+		return "", fmt.Errorf("failed to render instructions template: %w", err)
+	}
+	return sb.String(), nil
+}
+
+func instructionsTemplateFuncs(ctx context.Context, a *Agent) template.FuncMap {
+	return template.FuncMap{
+		"now": time.Now,
+		"date": func() string {
+			return time.Now().Format(time.DateOnly)
+		},
+		"agentName": func() string {
+			if a == nil {
+				return ""
+			}
+			return a.Name
+		},
+		"handoffs": func() []InstructionsTemplateHandoff {
+			if a == nil {
+				return nil
+			}
+			var result []InstructionsTemplateHandoff
+			for _, h := range a.Handoffs {
+				result = append(result, InstructionsTemplateHandoff{Name: h.AgentName, Description: h.ToolDescription})
+			}
+			for _, ha := range a.AgentHandoffs {
+				result = append(result, InstructionsTemplateHandoff{Name: ha.Name, Description: ha.HandoffDescription})
+			}
+			return result
+		},
+		"tools": func() ([]InstructionsTemplateTool, error) {
+			if a == nil {
+				return nil, nil
+			}
+			tools, err := a.GetAllTools(ctx)
+			if err != nil {
+				return nil, err
+			}
+			result := make([]InstructionsTemplateTool, len(tools))
+			for i, tool := range tools {
+				result[i] = InstructionsTemplateTool{Name: tool.ToolName()}
+				if ft, ok := tool.(FunctionTool); ok {
+					result[i].Description = ft.Description
+				}
+			}
+			return result, nil
+		},
+	}
+}
+
+// validateTemplateNode checks field references against the template data type.
+// dot is the type of "." in the current scope, or nil when it is unknown;
+// root is the type of "$".
+func validateTemplateNode(node parse.Node, dot, root reflect.Type) error {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return nil
+		}
+		for _, child := range n.Nodes {
+			if err := validateTemplateNode(child, dot, root); err != nil {
+				return err
+			}
+		}
+	case *parse.ActionNode:
+		return validateTemplateNode(n.Pipe, dot, root)
+	case *parse.PipeNode:
+		if n == nil {
+			return nil
+		}
+		for _, cmd := range n.Cmds {
+			if err := validateTemplateNode(cmd, dot, root); err != nil {
+				return err
+			}
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			if err := validateTemplateNode(arg, dot, root); err != nil {
+				return err
+			}
+		}
+	case *parse.FieldNode:
+		if dot != nil {
+			return validateFieldChain(dot, n.Ident)
+		}
+	case *parse.VariableNode:
+		if len(n.Ident) > 1 && n.Ident[0] == "$" {
+			return validateFieldChain(root, n.Ident[1:])
+		}
+	case *parse.IfNode:
+		return validateBranch(&n.BranchNode, dot, dot, root)
+	case *parse.RangeNode:
+		// The type of "." inside the range body is not tracked.
+		return validateBranch(&n.BranchNode, dot, nil, root)
+	case *parse.WithNode:
+		// The type of "." inside the with body is not tracked.
+		return validateBranch(&n.BranchNode, dot, nil, root)
+	case *parse.TemplateNode:
+		return validateTemplateNode(n.Pipe, dot, root)
+	}
+	return nil
+}
+
+func validateBranch(n *parse.BranchNode, dot, bodyDot, root reflect.Type) error {
+	if err := validateTemplateNode(n.Pipe, dot, root); err != nil {
+		return err
+	}
+	if err := validateTemplateNode(n.List, bodyDot, root); err != nil {
+		return err
+	}
+	return validateTemplateNode(n.ElseList, dot, root)
+}
+
+func validateFieldChain(t reflect.Type, idents []string) error {
+	for _, ident := range idents {
+		if t == nil {
+			return nil
+		}
+		if _, ok := t.MethodByName(ident); ok {
+			return nil
+		}
+		if t.Kind() != reflect.Pointer {
+			if _, ok := reflect.PointerTo(t).MethodByName(ident); ok {
+				return nil
+			}
+		}
+		for t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		switch t.Kind() {
+		case reflect.Struct:
+			field, ok := t.FieldByName(ident)
+			if !ok || !field.IsExported() {
+				return fmt.Errorf("can't evaluate field %s in type %s", ident, t)
+			}
+			t = field.Type
+		case reflect.Map, reflect.Interface:
+			// Map keys and dynamic values can only be checked at execution time.
+			return nil
+		default:
+			return fmt.Errorf("can't evaluate field %s in type %s", ident, t)
+		}
+	}
+	return nil
+}
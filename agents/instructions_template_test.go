@@ -0,0 +1,139 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/agentstesting"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type templateUser struct {
+	Name string
+}
+
+type templateContext struct {
+	User   *templateUser
+	Tenant string
+	Extra  map[string]string
+}
+
+func (templateContext) Greeting() string { return "Hello" }
+
+func TestInstructionsTemplate(t *testing.T) {
+	tmpl, err := agents.NewInstructionsTemplate[templateContext](
+		`{{.Greeting}} {{.User.Name}} from {{.Tenant}}, I am {{agentName}}.` +
+			`{{range handoffs}} [{{.Name}}: {{.Description}}]{{end}}` +
+			`{{range tools}} <{{.Name}}>{{end}} {{.Extra.plan}}`,
+	)
+	require.NoError(t, err)
+
+	billing := agents.New("billing").WithHandoffDescription("billing questions")
+	agent := agents.New("triage").
+		WithInstructionsGetter(tmpl).
+		WithAgentHandoffs(billing).
+		WithTools(agentstesting.GetFunctionTool("lookup", "x"))
+
+	ctx := agents.ContextWithValue(t.Context(), templateContext{
+		User:   &templateUser{Name: "Ada"},
+		Tenant: "Acme",
+		Extra:  map[string]string{"plan": "pro"},
+	})
+	prompt, err := agent.GetSystemPrompt(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "Hello Ada from Acme, I am triage. [billing: billing questions] <lookup> pro", prompt.Value)
+
+	t.Run("missing map key", func(t *testing.T) {
+		ctx := agents.ContextWithValue(t.Context(), templateContext{User: &templateUser{}})
+		_, err := agent.GetSystemPrompt(ctx)
+		assert.ErrorContains(t, err, `map has no entry for key "plan"`)
+	})
+
+	t.Run("missing context value", func(t *testing.T) {
+		_, err := agent.GetSystemPrompt(t.Context())
+		assert.ErrorAs(t, err, &agents.UserError{})
+	})
+}
+
+func TestInstructionsTemplateValidation(t *testing.T) {
+	for name, text := range map[string]string{
+		"unknown field":        `{{.Tenantt}}`,
+		"unknown nested field": `{{.User.Email}}`,
+		"unknown root field":   `{{with .User}}{{$.Nope}}{{end}}`,
+		"unknown function":     `{{nope}}`,
+		"syntax error":         `{{.Tenant`,
+	} {
+		_, err := agents.NewInstructionsTemplate[templateContext](text)
+		assert.Error(t, err, name)
+	}
+
+	for name, text := range map[string]string{
+		"method":        `{{.Greeting}}`,
+		"map key":       `{{.Extra.anything}}`,
+		"with body":     `{{with .User}}{{.Name}}{{end}}`,
+		"date helper":   `{{date}} {{now.Year}}`,
+		"map data type": `{{.anything}}`,
+	} {
+		var err error
+		if name == "map data type" {
+			_, err = agents.NewInstructionsTemplate[map[string]any](text)
+		} else {
+			_, err = agents.NewInstructionsTemplate[templateContext](text)
+		}
+		assert.NoError(t, err, name)
+	}
+
+	assert.Panics(t, func() { agents.MustInstructionsTemplate[templateContext](`{{.Nope}}`) })
+}
+
+func TestInstructionsTemplateDateHelper(t *testing.T) {
+	tmpl := agents.MustInstructionsTemplate[struct{}](`{{date}}`)
+	out, err := tmpl.Render(t.Context(), agents.New("a"), struct{}{})
+	require.NoError(t, err)
+	assert.Equal(t, time.Now().Format(time.DateOnly), out)
+}
+
+func TestRunConfigContext(t *testing.T) {
+	type runCtx struct{ UserID string }
+
+	var toolSawUserID string
+	tool := agents.NewFunctionTool("whoami", "", func(ctx context.Context, _ struct{}) (string, error) {
+		v, _ := agents.ContextValue[runCtx](ctx)
+		toolSawUserID = v.UserID
+		return v.UserID, nil
+	})
+
+	model := agentstesting.NewFakeModel(nil)
+	agent := agents.New("test").
+		WithModelInstance(model).
+		WithInstructionsGetter(agents.MustInstructionsTemplate[runCtx](`Serving {{.UserID}}.`)).
+		WithTools(tool)
+
+	model.AddMultipleTurnOutputs([]agentstesting.FakeModelTurnOutput{
+		{Value: []agents.TResponseOutputItem{agentstesting.GetFunctionToolCall("whoami", `{}`)}},
+		{Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("done")}},
+	})
+
+	result, err := agents.Runner{Config: agents.RunConfig{Context: runCtx{UserID: "u-42"}}}.Run(t.Context(), agent, "hi")
+	require.NoError(t, err)
+	assert.Equal(t, "done", result.FinalOutput)
+	assert.Equal(t, "u-42", toolSawUserID)
+	assert.Equal(t, "Serving u-42.", model.LastTurnArgs.SystemInstructions.Value)
+}
@@ -232,3 +232,48 @@ func (ReasoningItem) isRunItem() {}
 func (item ReasoningItem) ToInputItem() TResponseInputItem {
 	return openaitypes.ResponseInputItemUnionParamFromResponseReasoningItem(item.RawItem)
 }
+
+// GuardrailFeedbackItem represents the feedback of an output guardrail which rejected a final
+// output and asked the runner to run the agent again (see GuardrailRetry). It is sent to the
+// model as a user message.
+type GuardrailFeedbackItem struct {
+	// The agent whose final output was rejected.
+	Agent *Agent
+
+	// The name of the guardrail which rejected the output.
+	GuardrailName string
+
+	// The raw user message carrying the feedback.
+	RawItem responses.EasyInputMessageParam
+
+	// Always `guardrail_feedback_item`.
+	Type string
+}
+
+func (GuardrailFeedbackItem) isRunItem() {}
+
+func (item GuardrailFeedbackItem) ToInputItem() TResponseInputItem {
+	return TResponseInputItem{OfMessage: &item.RawItem}
+}
+
+// OutputRepairItem represents the request, sent to the model as a user message, to repair a
+// final output which failed the validation of the output schema (see Agent.OutputRepairRetries).
+type OutputRepairItem struct {
+	// The agent whose final output was invalid.
+	Agent *Agent
+
+	// The validation error of the output.
+	Error string
+
+	// The raw user message carrying the repair request.
+	RawItem responses.EasyInputMessageParam
+
+	// Always `output_repair_item`.
+	Type string
+}
+
+func (OutputRepairItem) isRunItem() {}
+
+func (item OutputRepairItem) ToInputItem() TResponseInputItem {
+	return TResponseInputItem{OfMessage: &item.RawItem}
+}
@@ -72,7 +72,14 @@ func (chatCmplConverter) ConvertResponseFormat(
 	}, true
 }
 
-func (chatCmplConverter) MessageToOutputItems(message openai.ChatCompletionMessage) ([]TResponseOutputItem, error) {
+func (conv chatCmplConverter) MessageToOutputItems(message openai.ChatCompletionMessage) ([]TResponseOutputItem, error) {
+	return conv.MessageToOutputItemsWithTools(message, nil)
+}
+
+// MessageToOutputItemsWithTools is like MessageToOutputItems, given the tools the model
+// was called with: if a LocalShellTool is among them, calls to the synthetic function
+// tool standing for it become local shell calls (see FunctionCallToOutputItem).
+func (chatCmplConverter) MessageToOutputItemsWithTools(message openai.ChatCompletionMessage, tools []Tool) ([]TResponseOutputItem, error) {
 	items := make([]TResponseOutputItem, 0)
 
 	messageItem := responses.ResponseOutputItemUnion{
@@ -104,18 +111,102 @@ func (chatCmplConverter) MessageToOutputItems(message openai.ChatCompletionMessa
 	}
 
 	for _, toolCall := range message.ToolCalls {
-		items = append(items, responses.ResponseOutputItemUnion{
+		item, err := ChatCmplConverter().FunctionCallToOutputItem(responses.ResponseOutputItemUnion{
 			ID:        FakeResponsesID,
 			CallID:    toolCall.ID,
 			Arguments: toolCall.Function.Arguments,
 			Name:      toolCall.Function.Name,
 			Type:      "function_call",
-		})
+		}, tools)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
 	}
 
 	return items, nil
 }
 
+// localShellFunctionArgs are the arguments of the synthetic function tool
+// standing for the local shell tool (see LocalShellFunctionParameters).
+type localShellFunctionArgs struct {
+	Command          []string          `json:"command"`
+	Env              map[string]string `json:"env,omitempty"`
+	WorkingDirectory string            `json:"working_directory,omitempty"`
+	TimeoutMs        int64             `json:"timeout_ms,omitempty"`
+	User             string            `json:"user,omitempty"`
+}
+
+// LocalShellFunctionParameters returns the JSON schema of the parameters of the
+// synthetic function tool standing for the local shell tool with the ChatCompletions API.
+// The parameters mirror the fields of the action of a local shell call.
+func (chatCmplConverter) LocalShellFunctionParameters() openai.FunctionParameters {
+	return openai.FunctionParameters{
+		"type": "object",
+		"properties": map[string]any{
+			"command": map[string]any{
+				"type":        "array",
+				"items":       map[string]any{"type": "string"},
+				"description": `The command to run, as a list of arguments, such as ["bash", "-lc", "ls -la"].`,
+			},
+			"env": map[string]any{
+				"type":                 "object",
+				"additionalProperties": map[string]any{"type": "string"},
+				"description":          "Environment variables to set for the command.",
+			},
+			"working_directory": map[string]any{
+				"type":        "string",
+				"description": "Optional working directory to run the command in.",
+			},
+			"timeout_ms": map[string]any{
+				"type":        "integer",
+				"description": "Optional timeout in milliseconds for the command.",
+			},
+			"user": map[string]any{
+				"type":        "string",
+				"description": "Optional user to run the command as.",
+			},
+		},
+		"required":             []string{"command"},
+		"additionalProperties": false,
+	}
+}
+
+// FunctionCallToOutputItem converts a function call of the ChatCompletions API to
+// the corresponding output item: if a LocalShellTool is among the given tools, calls
+// to the synthetic function tool standing for it become local shell calls.
+// Other calls are returned as they are.
+func (chatCmplConverter) FunctionCallToOutputItem(
+	call responses.ResponseOutputItemUnion,
+	tools []Tool,
+) (responses.ResponseOutputItemUnion, error) {
+	if call.Name != (LocalShellTool{}).ToolName() || !slices.ContainsFunc(tools, isLocalShellTool) {
+		return call, nil
+	}
+
+	var args localShellFunctionArgs
+	if err := json.Unmarshal([]byte(call.Arguments), &args); err != nil {
+		return call, ModelBehaviorErrorf("invalid arguments for the local shell tool: %w", err)
+	}
+	if len(args.Command) == 0 {
+		return call, NewModelBehaviorError("invalid arguments for the local shell tool: empty command")
+	}
+	return responses.ResponseOutputItemUnion{ // responses.ResponseOutputItemLocalShellCall
+		ID:     call.ID,
+		CallID: call.CallID,
+		Action: responses.ResponseOutputItemUnionAction{
+			Command:          args.Command,
+			Env:              args.Env,
+			Type:             "exec",
+			TimeoutMs:        args.TimeoutMs,
+			User:             args.User,
+			WorkingDirectory: args.WorkingDirectory,
+		},
+		Status: "completed",
+		Type:   "local_shell_call",
+	}, nil
+}
+
 func (conv chatCmplConverter) ExtractTextContentFromEasyInputMessageContentUnionParam(
 	content responses.EasyInputMessageContentUnionParam,
 ) (param.Opt[string], []openai.ChatCompletionContentPartTextParam, error) {
@@ -468,6 +559,43 @@ func (conv chatCmplConverter) itemsToMessages(items []TResponseInputItem) ([]ope
 			}
 			toolCalls = append(toolCalls, newToolCall)
 			asst.ToolCalls = toolCalls
+		} else if shellCall := item.OfLocalShellCall; !param.IsOmitted(shellCall) { // local shell calls => synthetic function calls
+			asst := ensureAssistantMessage()
+			toolCalls := slices.Clone(asst.ToolCalls)
+
+			jsonArguments, err := json.Marshal(localShellFunctionArgs{
+				Command:          shellCall.Action.Command,
+				Env:              shellCall.Action.Env,
+				WorkingDirectory: shellCall.Action.WorkingDirectory.Value,
+				TimeoutMs:        shellCall.Action.TimeoutMs.Value,
+				User:             shellCall.Action.User.Value,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to JSON-marshal local shell call arguments: %w", err)
+			}
+
+			newToolCall := openai.ChatCompletionMessageToolCallParam{
+				ID: shellCall.CallID,
+				Function: openai.ChatCompletionMessageToolCallFunctionParam{
+					Name:      LocalShellTool{}.ToolName(),
+					Arguments: string(jsonArguments),
+				},
+				Type: constant.ValueOf[constant.Function](),
+			}
+			toolCalls = append(toolCalls, newToolCall)
+			asst.ToolCalls = toolCalls
+		} else if shellOutput := item.OfLocalShellCallOutput; !param.IsOmitted(shellOutput) { // local shell call output => tool message
+			flushAssistantMessage()
+			msg := openai.ChatCompletionMessageParamUnion{
+				OfTool: &openai.ChatCompletionToolMessageParam{
+					Content: openai.ChatCompletionToolMessageParamContentUnion{
+						OfString: param.NewOpt(shellOutput.Output),
+					},
+					ToolCallID: shellOutput.ID,
+					Role:       constant.ValueOf[constant.Tool](),
+				},
+			}
+			result = append(result, msg)
 		} else if funcOutput := item.OfFunctionCallOutput; !param.IsOmitted(funcOutput) { // 5) function call output => tool message
 			flushAssistantMessage()
 			msg := openai.ChatCompletionMessageParamUnion{
@@ -491,7 +619,27 @@ func (conv chatCmplConverter) itemsToMessages(items []TResponseInputItem) ([]ope
 	return result, nil
 }
 
-func (chatCmplConverter) ToolToOpenai(tool Tool) (*openai.ChatCompletionToolParam, error) {
+func isLocalShellTool(tool Tool) bool {
+	_, ok := tool.(LocalShellTool)
+	return ok
+}
+
+// ToolToOpenai converts a tool to a function tool of the ChatCompletions API.
+// Hosted tools are not supported, except for LocalShellTool, which is exposed
+// as a synthetic function tool (see LocalShellFunctionParameters) whose calls are
+// converted back to local shell calls by FunctionCallToOutputItem.
+func (conv chatCmplConverter) ToolToOpenai(tool Tool) (*openai.ChatCompletionToolParam, error) {
+	if localShellTool, ok := tool.(LocalShellTool); ok {
+		return &openai.ChatCompletionToolParam{
+			Function: openai.FunctionDefinitionParam{
+				Name:        localShellTool.ToolName(),
+				Description: param.NewOpt("Run a command on the local shell."),
+				Parameters:  conv.LocalShellFunctionParameters(),
+			},
+			Type: constant.ValueOf[constant.Function](),
+		}, nil
+	}
+
 	functionTool, ok := tool.(FunctionTool)
 	if !ok {
 		return nil, UserErrorf("hosted tools are not supported with the ChatCompletions API. Got tool %#v", tool)
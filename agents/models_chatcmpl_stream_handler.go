@@ -66,9 +66,20 @@ type chatCmplStreamHandler struct{}
 
 func ChatCmplStreamHandler() chatCmplStreamHandler { return chatCmplStreamHandler{} }
 
-func (chatCmplStreamHandler) HandleStream(
+func (h chatCmplStreamHandler) HandleStream(
 	response responses.Response,
 	stream *ssestream.Stream[openai.ChatCompletionChunk],
+) iter.Seq2[*TResponseStreamEvent, error] {
+	return h.HandleStreamWithTools(response, stream, nil)
+}
+
+// HandleStreamWithTools is like HandleStream, given the tools the model was called with:
+// if a LocalShellTool is among them, calls to the synthetic function tool standing for it
+// become local shell calls (see ChatCmplConverter().FunctionCallToOutputItem).
+func (chatCmplStreamHandler) HandleStreamWithTools(
+	response responses.Response,
+	stream *ssestream.Stream[openai.ChatCompletionChunk],
+	tools []Tool,
 ) iter.Seq2[*TResponseStreamEvent, error] {
 	return func(yield func(*TResponseStreamEvent, error) bool) {
 		defer func() { _ = stream.Close() }()
@@ -288,8 +299,42 @@ func (chatCmplStreamHandler) HandleStream(
 			}
 		}
 
+		// Calls to the synthetic function tool standing for the local shell tool
+		// become local shell calls. Invalid calls are reported as errors
+		// (ModelBehaviorError) and left as they are, without ending the stream.
+		for _, functionCall := range state.FunctionCalls {
+			item, err := ChatCmplConverter().FunctionCallToOutputItem(*functionCall, tools)
+			if err != nil {
+				if !yield(nil, err) {
+					return
+				}
+				continue
+			}
+			*functionCall = item
+		}
+
 		// Actually send events for the function calls
 		for _, functionCall := range state.FunctionCalls {
+			if functionCall.Type == "local_shell_call" {
+				if !yield(&TResponseStreamEvent{ // responses.ResponseOutputItemAddedEvent
+					Item:           *functionCall,
+					OutputIndex:    functionCallStartingIndex,
+					Type:           "response.output_item.added",
+					SequenceNumber: sequenceNumber.GetAndIncrement(),
+				}, nil) {
+					return
+				}
+				if !yield(&TResponseStreamEvent{ // responses.ResponseOutputItemDoneEvent
+					Item:           *functionCall,
+					OutputIndex:    functionCallStartingIndex,
+					Type:           "response.output_item.done",
+					SequenceNumber: sequenceNumber.GetAndIncrement(),
+				}, nil) {
+					return
+				}
+				continue
+			}
+
 			// First, a ResponseOutputItemAdded for the function call
 			if !yield(&TResponseStreamEvent{ // responses.ResponseOutputItemAddedEvent
 				Item: responses.ResponseOutputItemUnion{ // responses.ResponseFunctionToolCall
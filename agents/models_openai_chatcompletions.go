@@ -97,7 +97,7 @@ func (m OpenAIChatCompletionsModel) GetResponse(
 		}
 	}
 
-	items, err := ChatCmplConverter().MessageToOutputItems(message)
+	items, err := ChatCmplConverter().MessageToOutputItemsWithTools(message, params.Tools)
 	if err != nil {
 		return nil, err
 	}
@@ -147,7 +147,7 @@ func (m OpenAIChatCompletionsModel) StreamResponse(
 		Reasoning:         openaitypes.ReasoningFromParam(params.ModelSettings.Reasoning),
 	}
 
-	return ChatCmplStreamHandler().HandleStream(response, stream), nil
+	return ChatCmplStreamHandler().HandleStreamWithTools(response, stream, params.Tools), nil
 }
 
 func (m OpenAIChatCompletionsModel) prepareRequest(
@@ -187,6 +187,22 @@ func (m OpenAIChatCompletionsModel) prepareRequest(
 	toolChoice, _ := ChatCmplConverter().ConvertToolChoice(modelSettings.ToolChoice)
 	responseFormat, _ := ChatCmplConverter().ConvertResponseFormat(outputSchema)
 
+	// The name of the synthetic function tool standing for the local shell tool
+	// must not be taken by other tools, or their calls would be mistaken for it.
+	if slices.ContainsFunc(tools, isLocalShellTool) {
+		localShellName := LocalShellTool{}.ToolName()
+		for _, tool := range tools {
+			if functionTool, ok := tool.(FunctionTool); ok && functionTool.Name == localShellName {
+				return nil, nil, UserErrorf("function tool name %q conflicts with the local shell tool", localShellName)
+			}
+		}
+		for _, handoff := range handoffs {
+			if handoff.ToolName == localShellName {
+				return nil, nil, UserErrorf("handoff tool name %q conflicts with the local shell tool", localShellName)
+			}
+		}
+	}
+
 	var convertedTools []openai.ChatCompletionToolParam
 	for _, tool := range tools {
 		v, err := ChatCmplConverter().ToolToOpenai(tool)
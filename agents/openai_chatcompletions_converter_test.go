@@ -685,3 +685,100 @@ func TestAssistantMessagesInHistory(t *testing.T) {
 		},
 	}, v)
 }
+
+func TestMessageToOutputItemsWithLocalShellCall(t *testing.T) {
+	// Calls to the synthetic "local_shell" function are converted to local
+	// shell calls.
+	msg := openai.ChatCompletionMessage{
+		ToolCalls: []openai.ChatCompletionMessageToolCall{{
+			ID: "call1",
+			Function: openai.ChatCompletionMessageToolCallFunction{
+				Name:      "local_shell",
+				Arguments: `{"command": ["ls", "-la"], "env": {"A": "1"}, "working_directory": "src", "timeout_ms": 500}`,
+			},
+			Type: constant.ValueOf[constant.Function](),
+		}},
+		Role: constant.ValueOf[constant.Assistant](),
+	}
+
+	tools := []agents.Tool{agents.LocalShellTool{}}
+	items, err := agents.ChatCmplConverter().MessageToOutputItemsWithTools(msg, tools)
+	require.NoError(t, err)
+	assert.Equal(t, []agents.TResponseOutputItem{{
+		ID:     agents.FakeResponsesID,
+		CallID: "call1",
+		Action: responses.ResponseOutputItemUnionAction{
+			Command:          []string{"ls", "-la"},
+			Env:              map[string]string{"A": "1"},
+			Type:             "exec",
+			TimeoutMs:        500,
+			WorkingDirectory: "src",
+		},
+		Status: "completed",
+		Type:   "local_shell_call",
+	}}, items)
+
+	msg.ToolCalls[0].Function.Arguments = `{"command": []}`
+	_, err = agents.ChatCmplConverter().MessageToOutputItemsWithTools(msg, tools)
+	assert.ErrorAs(t, err, &agents.ModelBehaviorError{})
+
+	// Without the local shell tool, it is a call to a regular function.
+	items, err = agents.ChatCmplConverter().MessageToOutputItems(msg)
+	require.NoError(t, err)
+	assert.Equal(t, []agents.TResponseOutputItem{{
+		ID:        agents.FakeResponsesID,
+		CallID:    "call1",
+		Arguments: `{"command": []}`,
+		Name:      "local_shell",
+		Type:      "function_call",
+	}}, items)
+}
+
+func TestItemsToMessagesWithLocalShellCall(t *testing.T) {
+	// Local shell calls and their outputs are converted to calls to the
+	// synthetic "local_shell" function and to tool messages.
+	v, err := agents.ChatCmplConverter().ItemsToMessages(agents.InputItems{
+		{
+			OfLocalShellCall: &responses.ResponseInputItemLocalShellCallParam{
+				ID:     "1",
+				CallID: "call1",
+				Action: responses.ResponseInputItemLocalShellCallActionParam{
+					Command: []string{"ls"},
+					User:    param.NewOpt("nobody"),
+				},
+				Status: "completed",
+			},
+		},
+		{
+			OfLocalShellCallOutput: &responses.ResponseInputItemLocalShellCallOutputParam{
+				ID:     "call1",
+				Output: `{"stdout": "README.md\n"}`,
+			},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []openai.ChatCompletionMessageParamUnion{
+		{
+			OfAssistant: &openai.ChatCompletionAssistantMessageParam{
+				ToolCalls: []openai.ChatCompletionMessageToolCallParam{{
+					ID: "call1",
+					Function: openai.ChatCompletionMessageToolCallFunctionParam{
+						Name:      "local_shell",
+						Arguments: `{"command":["ls"],"user":"nobody"}`,
+					},
+					Type: constant.ValueOf[constant.Function](),
+				}},
+				Role: constant.ValueOf[constant.Assistant](),
+			},
+		},
+		{
+			OfTool: &openai.ChatCompletionToolMessageParam{
+				Content: openai.ChatCompletionToolMessageParamContentUnion{
+					OfString: param.NewOpt(`{"stdout": "README.md\n"}`),
+				},
+				ToolCallID: "call1",
+				Role:       constant.ValueOf[constant.Tool](),
+			},
+		},
+	}, v)
+}
@@ -0,0 +1,103 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/openai/openai-go"
+	"github.com/openai/openai-go/option"
+	"github.com/openai/openai-go/packages/param"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalShellToolWithChatCompletions(t *testing.T) {
+	type m = map[string]any
+	completion := func(msg m) m {
+		return m{ // ChatCompletion
+			"id":      "resp-id",
+			"created": 0,
+			"model":   "fake",
+			"object":  "chat.completion",
+			"choices": []any{m{"index": 0, "finish_reason": "stop", "message": msg}},
+		}
+	}
+	replies := []m{
+		completion(m{"role": "assistant", "tool_calls": []any{m{
+			"id":       "call-id",
+			"type":     "function",
+			"function": m{"name": "local_shell", "arguments": `{"command": ["echo", "hi"], "working_directory": "/tmp"}`},
+		}}}),
+		completion(m{"role": "assistant", "content": "It said hi."}),
+	}
+
+	var requests []m
+	client := agents.OpenaiClient{
+		BaseURL: param.NewOpt("https://fake"),
+		Client: openai.NewClient(
+			option.WithMiddleware(func(req *http.Request, _ option.MiddlewareNext) (*http.Response, error) {
+				var request m
+				if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+					return nil, err
+				}
+				requests = append(requests, request)
+				body, err := json.Marshal(replies[len(requests)-1])
+				if err != nil {
+					return nil, err
+				}
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(bytes.NewReader(body)),
+					Header:     http.Header{"Content-Type": []string{"application/json"}},
+				}, nil
+			}),
+		),
+	}
+	model := agents.NewOpenAIChatCompletionsModel("gpt-4", client)
+
+	var executed []string
+	agent := agents.New("test").WithModelInstance(model).WithTools(agents.LocalShellTool{
+		Executor: func(_ context.Context, request agents.LocalShellCommandRequest) (string, error) {
+			action := request.Data.Action
+			executed = append(executed, action.WorkingDirectory+"$ "+strings.Join(action.Command, " "))
+			return "hi\n", nil
+		},
+	})
+
+	result, err := agents.Run(t.Context(), agent, "Say hi")
+	require.NoError(t, err)
+	assert.Equal(t, "It said hi.", result.FinalOutput)
+	assert.Equal(t, []string{"/tmp$ echo hi"}, executed)
+
+	require.Len(t, requests, 2)
+	tools := requests[0]["tools"].([]any)
+	require.Len(t, tools, 1)
+	assert.Equal(t, "local_shell", tools[0].(m)["function"].(m)["name"])
+
+	messages := requests[1]["messages"].([]any)
+	require.Len(t, messages, 3)
+	toolCall := messages[1].(m)["tool_calls"].([]any)[0].(m)
+	assert.Equal(t, "call-id", toolCall["id"])
+	assert.JSONEq(t, `{"command": ["echo", "hi"], "working_directory": "/tmp"}`, toolCall["function"].(m)["arguments"].(string))
+	assert.Equal(t, m{"role": "tool", "tool_call_id": "call-id", "content": "hi\n"}, messages[2])
+}
@@ -311,3 +311,98 @@ func TestStreamResponseYieldsEventsForToolCall(t *testing.T) {
 	assert.Equal(t, "response.output_item.done", outputEvents[3].Type)
 	assert.Equal(t, "response.completed", outputEvents[4].Type)
 }
+
+func TestStreamResponseYieldsLocalShellCall(t *testing.T) {
+	// Calls to the synthetic "local_shell" function are streamed as local
+	// shell calls, without argument deltas.
+	type m = map[string]any
+	chunk := m{ // ChatCompletionChunk
+		"id":      "chunk-id",
+		"created": 1,
+		"model":   "fake",
+		"object":  "chat.completion.chunk",
+		"choices": []m{{"index": 0, "delta": m{"tool_calls": []m{{ // Choice / ChoiceDelta / ChoiceDeltaToolCall
+			"index":    0,
+			"id":       "call-id",
+			"function": m{"name": "local_shell", "arguments": `{"command": ["pwd"]}`},
+			"type":     "function",
+		}}}}},
+	}
+
+	dummyClient := makeOpenaiClientWithStreamResponse(t, chunk)
+	provider := agents.NewOpenAIProvider(agents.OpenAIProviderParams{
+		OpenaiClient: &dummyClient,
+		UseResponses: param.NewOpt(false),
+	})
+	model, err := provider.GetModel("gpt-4")
+	require.NoError(t, err)
+
+	stream, err := model.StreamResponse(t.Context(), agents.ModelResponseParams{
+		Input: agents.InputString(""),
+		Tools: []agents.Tool{agents.LocalShellTool{}},
+	})
+	require.NoError(t, err)
+
+	var outputEvents []agents.TResponseStreamEvent
+	for event, err := range stream {
+		require.NoError(t, err)
+		outputEvents = append(outputEvents, *event)
+	}
+
+	require.Len(t, outputEvents, 4)
+	assert.Equal(t, "response.output_item.added", outputEvents[1].Type)
+	assert.Equal(t, "local_shell_call", outputEvents[1].Item.Type)
+	assert.Equal(t, "response.output_item.done", outputEvents[2].Type)
+	assert.Equal(t, []string{"pwd"}, outputEvents[2].Item.Action.Command)
+	assert.Equal(t, "response.completed", outputEvents[3].Type)
+	require.Len(t, outputEvents[3].Response.Output, 1)
+	assert.Equal(t, "call-id", outputEvents[3].Response.Output[0].CallID)
+	assert.Equal(t, "local_shell_call", outputEvents[3].Response.Output[0].Type)
+}
+
+func TestStreamResponseReportsInvalidLocalShellCall(t *testing.T) {
+	// Invalid arguments for the synthetic "local_shell" function are reported
+	// as a ModelBehaviorError, and the stream still completes.
+	type m = map[string]any
+	chunk := m{ // ChatCompletionChunk
+		"id":      "chunk-id",
+		"created": 1,
+		"model":   "fake",
+		"object":  "chat.completion.chunk",
+		"choices": []m{{"index": 0, "delta": m{"tool_calls": []m{{ // Choice / ChoiceDelta / ChoiceDeltaToolCall
+			"index":    0,
+			"id":       "call-id",
+			"function": m{"name": "local_shell", "arguments": `{"command": []}`},
+			"type":     "function",
+		}}}}},
+	}
+
+	dummyClient := makeOpenaiClientWithStreamResponse(t, chunk)
+	provider := agents.NewOpenAIProvider(agents.OpenAIProviderParams{
+		OpenaiClient: &dummyClient,
+		UseResponses: param.NewOpt(false),
+	})
+	model, err := provider.GetModel("gpt-4")
+	require.NoError(t, err)
+
+	stream, err := model.StreamResponse(t.Context(), agents.ModelResponseParams{
+		Input: agents.InputString(""),
+		Tools: []agents.Tool{agents.LocalShellTool{}},
+	})
+	require.NoError(t, err)
+
+	var outputEvents []agents.TResponseStreamEvent
+	var errs []error
+	for event, err := range stream {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		outputEvents = append(outputEvents, *event)
+	}
+
+	require.Len(t, errs, 1)
+	assert.ErrorAs(t, errs[0], &agents.ModelBehaviorError{})
+	require.NotEmpty(t, outputEvents)
+	assert.Equal(t, "response.completed", outputEvents[len(outputEvents)-1].Type)
+}
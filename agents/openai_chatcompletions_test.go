@@ -306,3 +306,26 @@ func TestStoreParam(t *testing.T) {
 		})
 	})
 }
+
+func TestPrepareRequestRejectsLocalShellNameConflicts(t *testing.T) {
+	// Other tools cannot be named like the synthetic function tool standing
+	// for the local shell tool.
+	dummyClient := makeOpenaiClientWithResponse(t, nil)
+	model := NewOpenAIChatCompletionsModel("gpt-4", dummyClient)
+
+	localShell := LocalShellTool{}
+	function := FunctionTool{Name: "local_shell", ParamsJSONSchema: map[string]any{"type": "object"}}
+
+	_, _, err := model.prepareRequest(param.Null[string](), InputString("hi"), modelsettings.ModelSettings{},
+		[]Tool{localShell, function}, nil, nil, false)
+	assert.ErrorAs(t, err, &UserError{})
+
+	_, _, err = model.prepareRequest(param.Null[string](), InputString("hi"), modelsettings.ModelSettings{},
+		[]Tool{localShell}, nil, []Handoff{{ToolName: "local_shell"}}, false)
+	assert.ErrorAs(t, err, &UserError{})
+
+	// Without the local shell tool, the name is available.
+	_, _, err = model.prepareRequest(param.Null[string](), InputString("hi"), modelsettings.ModelSettings{},
+		[]Tool{function}, nil, nil, false)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,141 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	"github.com/openai/openai-go/packages/param"
+	"github.com/openai/openai-go/responses"
+)
+
+var markdownFenceRegexp = regexp.MustCompile("(?s)```[A-Za-z0-9_-]*[ \t]*\n?(.*?)```")
+
+// ExtractJSON leniently extracts a JSON value from a model response, for
+// models which do not support strict structured outputs. It returns, in order
+// of preference:
+//   - the whole text, if it is valid JSON;
+//   - the content of the first markdown code fence which is valid JSON;
+//   - the first balanced JSON object or array embedded in the text.
+//
+// It reports false if no JSON value is found.
+func ExtractJSON(text string) (string, bool) {
+	text = strings.TrimSpace(text)
+	if json.Valid([]byte(text)) {
+		return text, true
+	}
+
+	for _, m := range markdownFenceRegexp.FindAllStringSubmatch(text, -1) {
+		if inner := strings.TrimSpace(m[1]); json.Valid([]byte(inner)) {
+			return inner, true
+		}
+	}
+
+	for start := 0; start < len(text); start++ {
+		if text[start] != '{' && text[start] != '[' {
+			continue
+		}
+		if end, ok := balancedJSONEnd(text, start); ok && json.Valid([]byte(text[start:end])) {
+			return text[start:end], true
+		}
+	}
+	return "", false
+}
+
+// balancedJSONEnd returns the end offset of the object or array starting at
+// text[start], skipping brackets inside strings.
+func balancedJSONEnd(text string, start int) (int, bool) {
+	depth := 0
+	inString := false
+	for i := start; i < len(text); i++ {
+		c := text[i]
+		if inString {
+			switch c {
+			case '\\':
+				i++
+			case '"':
+				inString = false
+			}
+			continue
+		}
+		switch c {
+		case '"':
+			inString = true
+		case '{', '[':
+			depth++
+		case '}', ']':
+			depth--
+			if depth == 0 {
+				return i + 1, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// validateFinalOutput validates the final output text against the output
+// schema. If lenient is true and the text is not valid as it is, the JSON
+// value extracted from it by ExtractJSON is tried too.
+func validateFinalOutput(outputSchema AgentOutputSchemaInterface, text string, lenient bool) (any, error) {
+	output, err := outputSchema.ValidateJSON(text)
+	if err == nil || !lenient {
+		return output, err
+	}
+	if extracted, ok := ExtractJSON(text); ok && extracted != text {
+		if output, extractedErr := outputSchema.ValidateJSON(extracted); extractedErr == nil {
+			return output, nil
+		}
+	}
+	return nil, err
+}
+
+// takeOutputRepair consumes one of the output repairs allowed in the run,
+// reporting whether one was left. Final outputs and tool arguments have
+// separate budgets.
+func takeOutputRepair(ctx context.Context, agent *Agent, runConfig RunConfig, toolArguments bool) bool {
+	maxRepairs := agent.OutputRepairRetries.Or(runConfig.OutputRepairRetries)
+	runContext, ok := RunContextFromContext(ctx)
+	if !ok || maxRepairs <= 0 {
+		return false
+	}
+	if toolArguments {
+		return takeRepair(&runContext.toolArgumentRepairs, maxRepairs)
+	}
+	return takeRepair(&runContext.finalOutputRepairs, maxRepairs)
+}
+
+func newOutputRepairItem(agent *Agent, validationErr error) OutputRepairItem {
+	message := "Your last response could not be parsed as the final output: " + validationErr.Error() +
+		"\nReply again with only a JSON value matching the required output schema."
+	return OutputRepairItem{
+		Agent: agent,
+		Error: validationErr.Error(),
+		RawItem: responses.EasyInputMessageParam{
+			Content: responses.EasyInputMessageContentUnionParam{OfString: param.NewOpt(message)},
+			Role:    responses.EasyInputMessageRoleUser,
+		},
+		Type: "output_repair_item",
+	}
+}
+
+// toolArgumentsRepairMessage returns the tool output asking the model to
+// call the tool again with valid arguments.
+func toolArgumentsRepairMessage(toolName string, err error) string {
+	return "Invalid arguments for tool " + toolName + ": " + err.Error() +
+		"\nCall the tool again with arguments matching its parameters schema."
+}
@@ -0,0 +1,159 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/agentstesting"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractJSON(t *testing.T) {
+	testCases := []struct {
+		text string
+		want string
+		ok   bool
+	}{
+		{` {"a": 1} `, `{"a": 1}`, true},
+		{"Here you go:\n```json\n{\"a\": 1}\n```\nBye.", `{"a": 1}`, true},
+		{"```\n[1, 2]\n```", `[1, 2]`, true},
+		{`The answer is {"a": "}{", "b": [1]} as requested.`, `{"a": "}{", "b": [1]}`, true},
+		{`[note] then {"a": 1}`, `{"a": 1}`, true},
+		{`no json here`, "", false},
+		{`{"a": 1`, "", false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.text, func(t *testing.T) {
+			got, ok := agents.ExtractJSON(tc.text)
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestStructuredOutputFromMarkdownFence(t *testing.T) {
+	model := agentstesting.NewFakeModel(nil)
+	reply := func(text string) {
+		model.SetNextOutput(agentstesting.FakeModelTurnOutput{
+			Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage(text)},
+		})
+	}
+	agent := agents.New("test").WithModelInstance(model).WithOutputSchema(AgentRunnerTestFooSchema{})
+
+	// Extraction is strict by default.
+	reply("```json\n{\"bar\": \"baz\"}\n```")
+	_, err := agents.Run(t.Context(), agent, "hello")
+	assert.ErrorContains(t, err, "final output schema validation failed")
+
+	runner := agents.Runner{Config: agents.RunConfig{LenientJSONOutput: true}}
+	reply("```json\n{\"bar\": \"baz\"}\n```")
+	result, err := runner.Run(t.Context(), agent, "hello")
+	require.NoError(t, err)
+	assert.Equal(t, AgentRunnerTestFoo{Bar: "baz"}, result.FinalOutput)
+
+	// The agent option overrides the run config.
+	agent.WithLenientJSONOutput(false)
+	reply("```json\n{\"bar\": \"baz\"}\n```")
+	_, err = runner.Run(t.Context(), agent, "hello")
+	assert.ErrorContains(t, err, "final output schema validation failed")
+
+	agent.WithLenientJSONOutput(true)
+	reply(`Sure: {"bar": "qux"}`)
+	result, err = agents.Run(t.Context(), agent, "hello")
+	require.NoError(t, err)
+	assert.Equal(t, AgentRunnerTestFoo{Bar: "qux"}, result.FinalOutput)
+}
+
+func TestOutputRepairRetries(t *testing.T) {
+	newModel := func() *agentstesting.FakeModel {
+		model := agentstesting.NewFakeModel(nil)
+		model.AddMultipleTurnOutputs([]agentstesting.FakeModelTurnOutput{
+			{Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage(`{"bar": 42}`)}},
+			{Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage(`{"bar": "baz"}`)}},
+		})
+		return model
+	}
+
+	// Without repairs, the run fails.
+	agent := agents.New("test").WithModelInstance(newModel()).WithOutputSchema(AgentRunnerTestFooSchema{})
+	_, err := agents.Run(t.Context(), agent, "hello")
+	assert.ErrorContains(t, err, "final output schema validation failed")
+
+	model := newModel()
+	agent = agents.New("test").WithModelInstance(model).WithOutputSchema(AgentRunnerTestFooSchema{})
+	runner := agents.Runner{Config: agents.RunConfig{OutputRepairRetries: 1}}
+	result, err := runner.Run(t.Context(), agent, "hello")
+	require.NoError(t, err)
+	assert.Equal(t, AgentRunnerTestFoo{Bar: "baz"}, result.FinalOutput)
+
+	require.Len(t, result.NewItems, 3)
+	repair, ok := result.NewItems[1].(agents.OutputRepairItem)
+	require.True(t, ok)
+	assert.Contains(t, repair.Error, "cannot unmarshal number")
+
+	// The model saw the validation error.
+	input := model.LastTurnArgs.Input.(agents.InputItems)
+	assert.Contains(t, input[len(input)-1].OfMessage.Content.OfString.Value, repair.Error)
+
+	// The agent option overrides the run config.
+	agent = agents.New("test").
+		WithModelInstance(newModel()).
+		WithOutputSchema(AgentRunnerTestFooSchema{}).
+		WithOutputRepairRetries(0)
+	_, err = runner.Run(t.Context(), agent, "hello")
+	assert.Error(t, err)
+}
+
+func TestToolArgumentsRepair(t *testing.T) {
+	type args struct {
+		City string `json:"city"`
+	}
+	var cities []string
+	tool := agents.NewFunctionTool("weather", "", func(_ context.Context, a args) (string, error) {
+		cities = append(cities, a.City)
+		return "sunny", nil
+	})
+
+	newModel := func() *agentstesting.FakeModel {
+		model := agentstesting.NewFakeModel(nil)
+		model.AddMultipleTurnOutputs([]agentstesting.FakeModelTurnOutput{
+			{Value: []agents.TResponseOutputItem{agentstesting.GetFunctionToolCall("weather", `{"city": 42}`)}},
+			{Value: []agents.TResponseOutputItem{agentstesting.GetFunctionToolCall("weather", `{"city": "Rome"}`)}},
+			{Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("done")}},
+		})
+		return model
+	}
+
+	agent := agents.New("test").WithModelInstance(newModel()).WithTools(tool)
+	_, err := agents.Run(t.Context(), agent, "hello")
+	var behaviorErr agents.ModelBehaviorError
+	require.ErrorAs(t, err, &behaviorErr)
+	assert.Empty(t, cities)
+
+	agent = agents.New("test").WithModelInstance(newModel()).WithTools(tool).WithOutputRepairRetries(1)
+	result, err := agents.Run(t.Context(), agent, "hello")
+	require.NoError(t, err)
+	assert.Equal(t, "done", result.FinalOutput)
+	assert.Equal(t, []string{"Rome"}, cities)
+
+	outputs := toolOutputs(result.NewItems)
+	require.Len(t, outputs, 2)
+	assert.Contains(t, outputs[0], "Invalid arguments for tool weather")
+	assert.Equal(t, "sunny", outputs[1])
+}
@@ -15,6 +15,7 @@
 package agents_test
 
 import (
+	"context"
 	"encoding/json"
 	"strings"
 	"testing"
@@ -181,3 +182,30 @@ func TestPrettyRunResultStreamingStructuredOutput(t *testing.T) {
 - 0 output guardrail result(s)
 (See `+"`RunResultStreaming`"+` for more details)`, v)
 }
+
+type PrettyPrintTestLookupArgs struct {
+	ID int `json:"id"`
+}
+
+func TestPrettyRunItems(t *testing.T) {
+	model := agentstesting.NewFakeModel(nil)
+	model.AddMultipleTurnOutputs([]agentstesting.FakeModelTurnOutput{
+		{Value: []agents.TResponseOutputItem{
+			agentstesting.GetReasoningItem("Looking it up"),
+			agentstesting.GetFunctionToolCall("lookup", `{"id": 1}`),
+		}},
+		{Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("Found")}},
+	})
+	agent := agents.New("test_agent").WithModelInstance(model).WithTools(
+		agents.NewFunctionTool("lookup", "", func(context.Context, PrettyPrintTestLookupArgs) (map[string]int, error) {
+			return map[string]int{"count": 2}, nil
+		}),
+	)
+	result, err := agents.Run(t.Context(), agent, "Hello")
+	require.NoError(t, err)
+
+	assert.Equal(t, `1. [test_agent] reasoning: "Looking it up"
+2. [test_agent] tool call: lookup({"id": 1})
+3. [test_agent] tool output: {"count":2}
+4. [test_agent] message: "Found"`, agents.PrettyPrintRunItems(result.NewItems))
+}
@@ -18,6 +18,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 )
 
@@ -106,6 +107,91 @@ func PrettyPrintRunErrorDetails(d RunErrorDetails) string {
 	return sb.String()
 }
 
+// PrettyPrintRunItems describes the given items, one per line, in the order
+// they were generated, for example:
+//
+//  1. [triage] handoff call: transfer_to_billing({})
+//  2. [triage] handoff: triage -> billing
+//  3. [billing] tool call: refund({"order": 42})
+//  4. [billing] tool output: "done"
+//  5. [billing] message: "Your order was refunded."
+func PrettyPrintRunItems(items []RunItem) string {
+	var sb strings.Builder
+	for i, item := range items {
+		if i > 0 {
+			sb.WriteByte('\n')
+		}
+		_, _ = fmt.Fprintf(&sb, "%d. %s", i+1, prettyRunItem(item))
+	}
+	return sb.String()
+}
+
+func prettyRunItem(item RunItem) string {
+	switch item := item.(type) {
+	case MessageOutputItem:
+		return fmt.Sprintf("[%s] message: %q", agentName(item.Agent), ItemHelpers().TextMessageOutput(item))
+	case HandoffCallItem:
+		return fmt.Sprintf("[%s] handoff call: %s(%s)", agentName(item.Agent), item.RawItem.Name, item.RawItem.Arguments)
+	case HandoffOutputItem:
+		return fmt.Sprintf("[%s] handoff: %s -> %s", agentName(item.Agent), agentName(item.SourceAgent), agentName(item.TargetAgent))
+	case ToolCallItem:
+		return fmt.Sprintf("[%s] tool call: %s", agentName(item.Agent), prettyToolCall(item.RawItem))
+	case ToolCallOutputItem:
+		return fmt.Sprintf("[%s] tool output: %s", agentName(item.Agent), prettyToolOutput(item.Output))
+	case ReasoningItem:
+		var summary []string
+		for _, s := range item.RawItem.Summary {
+			summary = append(summary, s.Text)
+		}
+		return fmt.Sprintf("[%s] reasoning: %q", agentName(item.Agent), strings.Join(summary, " "))
+	case GuardrailFeedbackItem:
+		return fmt.Sprintf("[%s] guardrail feedback (%s): %q", agentName(item.Agent), item.GuardrailName, item.RawItem.Content.OfString.Value)
+	case OutputRepairItem:
+		return fmt.Sprintf("[%s] output repair: %q", agentName(item.Agent), item.Error)
+	default:
+		return fmt.Sprintf("%T", item)
+	}
+}
+
+func prettyToolCall(call ToolCallItemType) string {
+	switch call := call.(type) {
+	case ResponseFunctionToolCall:
+		return fmt.Sprintf("%s(%s)", call.Name, call.Arguments)
+	case ResponseComputerToolCall:
+		return fmt.Sprintf("computer(%s)", call.Action.Type)
+	case ResponseOutputItemLocalShellCall:
+		return fmt.Sprintf("local_shell(%q)", strings.Join(call.Action.Command, " "))
+	case ResponseFileSearchToolCall:
+		return fmt.Sprintf("file_search(%q)", strings.Join(call.Queries, ", "))
+	case ResponseFunctionWebSearch:
+		return "web_search"
+	case ResponseCodeInterpreterToolCall:
+		return "code_interpreter"
+	case ResponseOutputItemImageGenerationCall:
+		return "image_generation"
+	default:
+		return fmt.Sprintf("%T", call)
+	}
+}
+
+func prettyToolOutput(output any) string {
+	if s, ok := output.(string); ok {
+		return strconv.Quote(s)
+	}
+	b, err := json.Marshal(output)
+	if err != nil {
+		return fmt.Sprintf("%+v", output)
+	}
+	return string(b)
+}
+
+func agentName(agent *Agent) string {
+	if agent == nil {
+		return "?"
+	}
+	return agent.Name
+}
+
 func SimplePrettyJSONMarshal(v any) string {
 	s, err := PrettyJSONMarshal(v)
 	if err != nil {
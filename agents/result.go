@@ -48,6 +48,14 @@ type RunResult struct {
 
 	// The LastAgent that was run.
 	LastAgent *Agent
+
+	// The state shared with all callbacks during the run, including the
+	// user-defined context value.
+	RunContext *RunContextWrapper
+
+	// The maximum depth reached by the call stack of agents returning to
+	// their callers (see Handoff.ReturnToCaller).
+	MaxCallStackDepth int
 }
 
 func (r RunResult) String() string {
@@ -89,6 +97,7 @@ type RunResultStreaming struct {
 	inputGuardrailsTask      *atomic.Pointer[asynctask.Task[error]]
 	outputGuardrailsTask     *atomic.Pointer[asynctask.Task[outputGuardrailsTaskResult]]
 	storedError              *atomic.Pointer[error]
+	runContext               *RunContextWrapper
 }
 
 type outputGuardrailsTaskResult struct {
@@ -97,6 +106,10 @@ type outputGuardrailsTaskResult struct {
 }
 
 func newRunResultStreaming(ctx context.Context) *RunResultStreaming {
+	runContext, ok := RunContextFromContext(ctx)
+	if !ok {
+		runContext = NewRunContextWrapper(nil)
+	}
 	return &RunResultStreaming{
 		context:                  ctx,
 		input:                    newZeroValAtomicPointer[Input](),
@@ -116,6 +129,7 @@ func newRunResultStreaming(ctx context.Context) *RunResultStreaming {
 		inputGuardrailsTask:      new(atomic.Pointer[asynctask.Task[error]]),
 		outputGuardrailsTask:     new(atomic.Pointer[asynctask.Task[outputGuardrailsTaskResult]]),
 		storedError:              newZeroValAtomicPointer[error](),
+		runContext:               runContext,
 	}
 }
 
@@ -164,12 +178,26 @@ func (r *RunResultStreaming) setOutputGuardrailResults(v []OutputGuardrailResult
 }
 
 // CurrentAgent returns the current agent that is running.
-func (r *RunResultStreaming) CurrentAgent() *Agent     { return r.currentAgent.Load() }
-func (r *RunResultStreaming) setCurrentAgent(v *Agent) { r.currentAgent.Store(v) }
+func (r *RunResultStreaming) CurrentAgent() *Agent { return r.currentAgent.Load() }
+func (r *RunResultStreaming) setCurrentAgent(v *Agent) {
+	r.currentAgent.Store(v)
+	r.runContext.setCurrentAgent(v)
+}
 
 // CurrentTurn returns the current turn number.
-func (r *RunResultStreaming) CurrentTurn() uint64     { return r.currentTurn.Load() }
-func (r *RunResultStreaming) setCurrentTurn(v uint64) { r.currentTurn.Store(v) }
+func (r *RunResultStreaming) CurrentTurn() uint64 { return r.currentTurn.Load() }
+func (r *RunResultStreaming) setCurrentTurn(v uint64) {
+	r.currentTurn.Store(v)
+	r.runContext.setCurrentTurn(v)
+}
+
+// RunContext returns the state shared with all callbacks during the run,
+// including the user-defined context value.
+func (r *RunResultStreaming) RunContext() *RunContextWrapper { return r.runContext }
+
+// CallStackDepth returns the current depth of the call stack of agents
+// returning to their callers (see Handoff.ReturnToCaller).
+func (r *RunResultStreaming) CallStackDepth() int { return r.runContext.CallStackDepth() }
 
 // MaxTurns returns the maximum number of turns the agent can run for.
 func (r *RunResultStreaming) MaxTurns() uint64     { return r.maxTurns.Load() }
@@ -245,6 +273,10 @@ func (r *RunResultStreaming) Cancel() {
 	for !r.inputGuardrailQueue.IsEmpty() {
 		_, _ = r.inputGuardrailQueue.GetNoWait()
 	}
+
+	// Wake up StreamEvents, if it is waiting for an event on another
+	// goroutine: the sentinel put by the run might have been discarded above.
+	r.eventQueue.Wake()
 }
 
 // StreamEvents streams deltas for new items as they are generated.
@@ -271,7 +303,11 @@ func (r *RunResultStreaming) StreamEvents(fn func(StreamEvent) error) error {
 			break
 		}
 
-		item := r.eventQueue.Get()
+		item, ok := r.eventQueue.GetUnless(r.IsComplete)
+		if !ok {
+			// Canceled while waiting.
+			break
+		}
 
 		if _, ok := item.(queueCompleteSentinel); ok {
 			// Check for errors, in case the queue was completed due to an error
@@ -331,6 +367,14 @@ func (r *RunResultStreaming) checkErrors() error {
 			tripwireErr := NewInputGuardrailTripwireTriggeredError(guardrailResult)
 			tripwireErr.AgentsError.RunData = r.createErrorDetails()
 			r.setStoredError(tripwireErr)
+		} else if ok && guardrailResult.Output.Behavior != GuardrailAllow {
+			// The model is already streaming a response to the input.
+			userErr := UserErrorf(
+				"input guardrail %s requested behavior %d, but streamed runs only support GuardrailAllow for input guardrails",
+				guardrailResult.Guardrail.Name, guardrailResult.Output.Behavior,
+			)
+			userErr.AgentsError.RunData = r.createErrorDetails()
+			r.setStoredError(userErr)
 		}
 	}
 
@@ -67,6 +67,10 @@ type RunConfig struct {
 	// A list of output guardrails to run on the final output of the run.
 	OutputGuardrails []OutputGuardrail
 
+	// A list of guardrails to run on the partial text output of every agent, while it is
+	// streamed. Only used by streamed runs.
+	StreamingOutputGuardrails []StreamingOutputGuardrail
+
 	// Optional maximum number of turns to run the agent for.
 	// A turn is defined as one AI invocation (including any tool calls that might occur).
 	// Default (when left zero): DefaultMaxTurns.
@@ -78,6 +82,24 @@ type RunConfig struct {
 	// Optional ID of the previous response, if using OpenAI models via the Responses API,
 	// this allows you to skip passing in input from the previous turn.
 	PreviousResponseID string
+
+	// Optional user-defined context value, made available to instructions,
+	// tools, hooks and guardrails through ContextValue.
+	Context any
+
+	// Optional manager of the conversation history sent to the model,
+	// called before each model call.
+	ContextManager ContextManager
+
+	// Optional maximum number of times the model is asked to repair an invalid output in a
+	// run (see Agent.OutputRepairRetries, which overrides it).
+	// Default (when zero): invalid outputs make the run fail with a ModelBehaviorError.
+	OutputRepairRetries int
+
+	// Whether final outputs are leniently extracted with ExtractJSON when they are not valid
+	// JSON for the output schema (see Agent.LenientJSONOutput, which overrides it).
+	// Default (when false): final outputs must be exactly valid JSON.
+	LenientJSONOutput bool
 }
 
 // Run executes startingAgent with the provided input using the DefaultRunner.
@@ -161,12 +183,14 @@ func (r Runner) run(ctx context.Context, startingAgent *Agent, input Input) (_ *
 		outputGuardrailResults []OutputGuardrailResult
 	)
 
-	ctx = usage.NewContext(ctx, usage.NewUsage())
+	ctx, runContext := r.newRunContext(ctx)
+	guardrailRetries := make(map[string]int)
 
 	if startingAgent == nil {
 		return nil, fmt.Errorf("StartingAgent must not be nil")
 	}
 	currentAgent := startingAgent
+	runContext.setCurrentAgent(currentAgent)
 	shouldRunAgentStartHooks := true
 
 	defer func() {
@@ -196,6 +220,7 @@ func (r Runner) run(ctx context.Context, startingAgent *Agent, input Input) (_ *
 		}
 
 		currentTurn += 1
+		runContext.setCurrentTurn(currentTurn)
 		if currentTurn > maxTurns {
 			return nil, MaxTurnsExceededErrorf("max turns %d exceeded", maxTurns)
 		}
@@ -211,15 +236,29 @@ func (r Runner) run(ctx context.Context, startingAgent *Agent, input Input) (_ *
 			var wg sync.WaitGroup
 			wg.Add(2)
 
-			var guardrailsError error
+			// If a guardrail can replace the input, the first turn waits for the
+			// guardrails, so that it never runs on the original input. Other
+			// guardrails run in parallel with it.
+			inputGuardrails := slices.Concat(startingAgent.InputGuardrails, r.Config.InputGuardrails)
+			waitGuardrails := slices.ContainsFunc(inputGuardrails, func(g InputGuardrail) bool { return g.CanRewrite })
+			guardrailsDone := make(chan struct{})
+
+			var (
+				guardrailsError error
+				guardrailAction *InputGuardrailResult
+			)
 			go func() {
 				defer wg.Done()
+				defer close(guardrailsDone)
 				inputGuardrailResults, guardrailsError = r.runInputGuardrails(
 					childCtx,
 					startingAgent,
-					slices.Concat(startingAgent.InputGuardrails, r.Config.InputGuardrails),
+					inputGuardrails,
 					CopyGeneralInput(input),
 				)
+				if guardrailsError == nil {
+					guardrailAction, guardrailsError = inputGuardrailAction(inputGuardrailResults)
+				}
 				if guardrailsError != nil {
 					cancel()
 				}
@@ -228,6 +267,12 @@ func (r Runner) run(ctx context.Context, startingAgent *Agent, input Input) (_ *
 			var turnError error
 			go func() {
 				defer wg.Done()
+				if waitGuardrails {
+					<-guardrailsDone
+					if guardrailsError != nil || guardrailAction != nil {
+						return
+					}
+				}
 				turnResult, turnError = r.runSingleTurn(
 					childCtx,
 					currentAgent,
@@ -246,8 +291,38 @@ func (r Runner) run(ctx context.Context, startingAgent *Agent, input Input) (_ *
 			}()
 
 			wg.Wait()
-			if err = errors.Join(turnError, guardrailsError); err != nil {
-				return nil, err
+			switch {
+			case guardrailsError != nil:
+				return nil, errors.Join(turnError, guardrailsError)
+			case guardrailAction != nil && guardrailAction.Output.Behavior == GuardrailFallback:
+				return &RunResult{
+					Input:                 originalInput,
+					FinalOutput:           guardrailAction.Output.ReplacementOutput,
+					NewItems:              generatedItems,
+					RawResponses:          modelResponses,
+					InputGuardrailResults: inputGuardrailResults,
+					LastAgent:             currentAgent,
+					RunContext:            runContext,
+				}, nil
+			case guardrailAction != nil:
+				originalInput = CopyGeneralInput(guardrailAction.Output.ReplacementInput)
+				turnResult, err = r.runSingleTurn(
+					childCtx,
+					currentAgent,
+					allTools,
+					originalInput,
+					generatedItems,
+					hooks,
+					r.Config,
+					shouldRunAgentStartHooks,
+					toolUseTracker,
+					r.Config.PreviousResponseID,
+				)
+				if err != nil {
+					return nil, err
+				}
+			case turnError != nil:
+				return nil, turnError
 			}
 		} else {
 			turnResult, err = r.runSingleTurn(
@@ -275,7 +350,14 @@ func (r Runner) run(ctx context.Context, startingAgent *Agent, input Input) (_ *
 
 		switch nextStep := turnResult.NextStep.(type) {
 		case NextStepFinalOutput:
-			outputGuardrailResults, err = r.runOutputGuardrails(
+			if frame, ok := runContext.popCallFrame(); ok {
+				// The callee is done: resume the caller.
+				currentAgent = frame.Caller
+				runContext.setCurrentAgent(currentAgent)
+				shouldRunAgentStartHooks = true
+				continue
+			}
+			results, err := r.runOutputGuardrails(
 				childCtx,
 				slices.Concat(currentAgent.OutputGuardrails, r.Config.OutputGuardrails),
 				currentAgent,
@@ -284,17 +366,37 @@ func (r Runner) run(ctx context.Context, startingAgent *Agent, input Input) (_ *
 			if err != nil {
 				return nil, err
 			}
+			// The results of the outputs rejected by a retry are kept too.
+			outputGuardrailResults = append(outputGuardrailResults, results...)
+
+			finalOutput := nextStep.Output
+			action, err := outputGuardrailAction(results, guardrailRetries)
+			if err != nil {
+				return nil, err
+			}
+			if action != nil {
+				if action.Output.Behavior == GuardrailRetry {
+					generatedItems = append(generatedItems, newGuardrailFeedbackItem(currentAgent, *action))
+					continue
+				}
+				finalOutput = action.Output.ReplacementOutput
+			}
+
 			return &RunResult{
 				Input:                  originalInput,
 				NewItems:               generatedItems,
 				RawResponses:           modelResponses,
-				FinalOutput:            nextStep.Output,
+				FinalOutput:            finalOutput,
 				InputGuardrailResults:  inputGuardrailResults,
 				OutputGuardrailResults: outputGuardrailResults,
 				LastAgent:              currentAgent,
+				RunContext:             runContext,
+				MaxCallStackDepth:      runContext.MaxCallStackDepth(),
 			}, nil
 		case NextStepHandoff:
+			updateCallStack(runContext, currentAgent, nextStep)
 			currentAgent = nextStep.NewAgent
+			runContext.setCurrentAgent(currentAgent)
 			shouldRunAgentStartHooks = true
 		case NextStepRunAgain:
 			// Nothing to do
@@ -338,7 +440,7 @@ func (r Runner) runStreamed(ctx context.Context, startingAgent *Agent, input Inp
 	}
 
 	outputSchema := startingAgent.OutputSchema
-	ctx = usage.NewContext(ctx, usage.NewUsage())
+	ctx, _ = r.newRunContext(ctx)
 
 	streamedResult := newRunResultStreaming(ctx)
 	streamedResult.setInput(CopyGeneralInput(input))
@@ -441,6 +543,7 @@ func (r Runner) runStreamedImpl(
 	currentTurn := uint64(0)
 	shouldRunAgentStartHooks := true
 	toolUseTracker := NewAgentToolUseTracker()
+	guardrailRetries := make(map[string]int)
 
 	streamedResult.eventQueue.Put(AgentUpdatedStreamEvent{
 		NewAgent: currentAgent,
@@ -496,6 +599,17 @@ func (r Runner) runStreamedImpl(
 
 		switch nextStep := turnResult.NextStep.(type) {
 		case NextStepFinalOutput:
+			if frame, ok := streamedResult.runContext.popCallFrame(); ok {
+				// The callee is done: resume the caller.
+				currentAgent = frame.Caller
+				shouldRunAgentStartHooks = true
+				streamedResult.eventQueue.Put(AgentUpdatedStreamEvent{
+					NewAgent:       currentAgent,
+					CallStackDepth: streamedResult.runContext.CallStackDepth(),
+					Type:           "agent_updated_stream_event",
+				})
+				continue
+			}
 			streamedResult.createOutputGuardrailsTask(ctx, func(ctx context.Context) outputGuardrailsTaskResult {
 				result, err := r.runOutputGuardrails(
 					ctx,
@@ -517,16 +631,35 @@ func (r Runner) runStreamedImpl(
 				outputGuardrailResults = taskResult.Result.Result
 			}
 
-			streamedResult.setOutputGuardrailResults(outputGuardrailResults)
-			streamedResult.setFinalOutput(nextStep.Output)
+			// The results of the outputs rejected by a retry are kept too.
+			streamedResult.setOutputGuardrailResults(slices.Concat(streamedResult.OutputGuardrailResults(), outputGuardrailResults))
+
+			finalOutput := nextStep.Output
+			action, err := outputGuardrailAction(outputGuardrailResults, guardrailRetries)
+			if err != nil {
+				return err
+			}
+			if action != nil {
+				if action.Output.Behavior == GuardrailRetry {
+					feedback := newGuardrailFeedbackItem(currentAgent, *action)
+					streamedResult.setNewItems(append(streamedResult.NewItems(), feedback))
+					RunImpl().StreamStepResultToQueue(SingleStepResult{NewStepItems: []RunItem{feedback}}, streamedResult.eventQueue)
+					continue
+				}
+				finalOutput = action.Output.ReplacementOutput
+			}
+
+			streamedResult.setFinalOutput(finalOutput)
 			streamedResult.markAsComplete()
 			streamedResult.eventQueue.Put(queueCompleteSentinel{})
 		case NextStepHandoff:
+			updateCallStack(streamedResult.runContext, currentAgent, nextStep)
 			currentAgent = nextStep.NewAgent
 			shouldRunAgentStartHooks = true
 			streamedResult.eventQueue.Put(AgentUpdatedStreamEvent{
-				NewAgent: currentAgent,
-				Type:     "agent_updated_stream_event",
+				NewAgent:       currentAgent,
+				CallStackDepth: streamedResult.runContext.CallStackDepth(),
+				Type:           "agent_updated_stream_event",
 			})
 		case NextStepRunAgain:
 			// Nothing to do
@@ -596,11 +729,6 @@ func (r Runner) runSingleTurnStreamed(
 		return nil, err
 	}
 
-	handoffs, err := r.getHandoffs(agent)
-	if err != nil {
-		return nil, err
-	}
-
 	model, err := r.getModel(agent, runConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get model: %w", err)
@@ -615,8 +743,35 @@ func (r Runner) runSingleTurnStreamed(
 		input = append(input, item.ToInputItem())
 	}
 
+	handoffs, err := r.getEnabledHandoffs(ctx, agent, input)
+	if err != nil {
+		return nil, err
+	}
+
+	input, err = r.manageContext(ctx, agent, systemPrompt, input)
+	if err != nil {
+		return nil, err
+	}
+
+	// The stream events are held back until checked by the streaming output
+	// guardrails, and the stream is canceled early if one of them trips.
+	streamCtx, cancelStream := context.WithCancel(ctx)
+	defer cancelStream()
+	guardrailChecker := newStreamingGuardrailChecker(
+		ctx,
+		agent,
+		slices.Concat(agent.StreamingOutputGuardrails, runConfig.StreamingOutputGuardrails),
+		cancelStream,
+		func(event TResponseStreamEvent) {
+			streamedResult.eventQueue.Put(RawResponsesStreamEvent{
+				Data: event,
+				Type: "raw_response_event",
+			})
+		},
+	)
+
 	// 1. Stream the output events
-	stream, err := model.StreamResponse(ctx, ModelResponseParams{
+	stream, err := model.StreamResponse(streamCtx, ModelResponseParams{
 		SystemInstructions: systemPrompt,
 		Input:              InputItems(input),
 		ModelSettings:      modelSettings,
@@ -632,6 +787,9 @@ func (r Runner) runSingleTurnStreamed(
 
 	eventErrors := make([]error, 0)
 	for event, eventErr := range stream {
+		if guardrailChecker.tripped() != nil {
+			break
+		}
 		if eventErr != nil {
 			eventErrors = append(eventErrors, eventErr)
 			continue
@@ -657,10 +815,10 @@ func (r Runner) runSingleTurnStreamed(
 				contextUsage.Add(u)
 			}
 		}
-		streamedResult.eventQueue.Put(RawResponsesStreamEvent{
-			Data: *event,
-			Type: "raw_response_event",
-		})
+		guardrailChecker.addEvent(*event)
+	}
+	if err = guardrailChecker.finish(); err != nil {
+		return nil, err
 	}
 	if err = errors.Join(eventErrors...); err != nil {
 		return nil, fmt.Errorf("stream event errors: %w", err)
@@ -746,14 +904,19 @@ func (r Runner) runSingleTurn(
 		return nil, err
 	}
 
-	handoffs, err := r.getHandoffs(agent)
+	input := ItemHelpers().InputToNewInputList(originalInput)
+	for _, generatedItem := range generatedItems {
+		input = append(input, generatedItem.ToInputItem())
+	}
+
+	handoffs, err := r.getEnabledHandoffs(ctx, agent, input)
 	if err != nil {
 		return nil, err
 	}
 
-	input := ItemHelpers().InputToNewInputList(originalInput)
-	for _, generatedItem := range generatedItems {
-		input = append(input, generatedItem.ToInputItem())
+	input, err = r.manageContext(ctx, agent, systemPrompt, input)
+	if err != nil {
+		return nil, err
 	}
 
 	newResponse, err := r.getNewResponse(
@@ -963,6 +1126,76 @@ func (Runner) runOutputGuardrails(
 	return guardrailResults, nil
 }
 
+// inputGuardrailAction returns the first input guardrail result, in order,
+// requesting GuardrailRewrite or GuardrailFallback, if any. Only guardrails
+// setting InputGuardrail.CanRewrite, which the first turn waits for, can
+// request them.
+func inputGuardrailAction(results []InputGuardrailResult) (*InputGuardrailResult, error) {
+	for i := range results {
+		result := &results[i]
+		switch result.Output.Behavior {
+		case GuardrailAllow:
+			continue
+		case GuardrailRewrite:
+			if !result.Guardrail.CanRewrite {
+				return nil, UserErrorf("input guardrail %s requested a rewrite without setting CanRewrite", result.Guardrail.Name)
+			}
+			if result.Output.ReplacementInput == nil {
+				return nil, UserErrorf("input guardrail %s requested a rewrite without a replacement input", result.Guardrail.Name)
+			}
+			return result, nil
+		case GuardrailFallback:
+			if !result.Guardrail.CanRewrite {
+				return nil, UserErrorf("input guardrail %s requested a fallback without setting CanRewrite", result.Guardrail.Name)
+			}
+			return result, nil
+		case GuardrailRetry:
+			return nil, UserErrorf("input guardrail %s requested a retry, which is only supported by output guardrails", result.Guardrail.Name)
+		default:
+			return nil, UserErrorf("input guardrail %s returned unknown behavior %d", result.Guardrail.Name, result.Output.Behavior)
+		}
+	}
+	return nil, nil
+}
+
+// outputGuardrailAction returns the first output guardrail result, in order,
+// requesting a behavior other than GuardrailAllow, if any. A retry is counted
+// in retries; once the guardrail has no retries left, it is turned into an
+// OutputGuardrailTripwireTriggeredError.
+func outputGuardrailAction(results []OutputGuardrailResult, retries map[string]int) (*OutputGuardrailResult, error) {
+	for i := range results {
+		result := &results[i]
+		switch result.Output.Behavior {
+		case GuardrailAllow:
+			continue
+		case GuardrailRewrite, GuardrailFallback:
+			return result, nil
+		case GuardrailRetry:
+			maxRetries := result.Guardrail.MaxRetries.Or(DefaultOutputGuardrailMaxRetries)
+			if retries[result.Guardrail.Name] >= maxRetries {
+				return nil, NewOutputGuardrailTripwireTriggeredError(*result)
+			}
+			retries[result.Guardrail.Name]++
+			return result, nil
+		default:
+			return nil, UserErrorf("output guardrail %s returned unknown behavior %d", result.Guardrail.Name, result.Output.Behavior)
+		}
+	}
+	return nil, nil
+}
+
+func newGuardrailFeedbackItem(agent *Agent, result OutputGuardrailResult) GuardrailFeedbackItem {
+	return GuardrailFeedbackItem{
+		Agent:         agent,
+		GuardrailName: result.Guardrail.Name,
+		RawItem: responses.EasyInputMessageParam{
+			Content: responses.EasyInputMessageContentUnionParam{OfString: param.NewOpt(result.Output.Feedback)},
+			Role:    responses.EasyInputMessageRoleUser,
+		},
+		Type: "guardrail_feedback_item",
+	}
+}
+
 func (r Runner) getNewResponse(
 	ctx context.Context,
 	agent *Agent,
@@ -1020,6 +1253,85 @@ func (Runner) getHandoffs(agent *Agent) ([]Handoff, error) {
 	return handoffs, nil
 }
 
+// getEnabledHandoffs returns the handoffs offered to the agent for its next
+// turn: the static and dynamic handoffs which are enabled for the given
+// history, followed by the handoff returning to the caller, if any.
+func (r Runner) getEnabledHandoffs(ctx context.Context, agent *Agent, history []TResponseInputItem) ([]Handoff, error) {
+	handoffs, err := r.getHandoffs(agent)
+	if err != nil {
+		return nil, err
+	}
+	if agent.DynamicHandoffs != nil {
+		dynamicHandoffs, err := agent.DynamicHandoffs(ctx, agent, history)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get dynamic handoffs: %w", err)
+		}
+		handoffs = append(handoffs, dynamicHandoffs...)
+	}
+
+	isEnabledResults := make([]bool, len(handoffs))
+	isEnabledErrors := make([]error, len(handoffs))
+
+	childCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	wg.Add(len(handoffs))
+
+	for i, handoff := range handoffs {
+		go func() {
+			defer wg.Done()
+
+			if handoff.IsEnabled == nil {
+				isEnabledResults[i] = true
+				return
+			}
+
+			isEnabledResults[i], isEnabledErrors[i] = handoff.IsEnabled.IsEnabled(childCtx, agent, history)
+			if isEnabledErrors[i] != nil {
+				cancel()
+			}
+		}()
+	}
+
+	wg.Wait()
+	if err := errors.Join(isEnabledErrors...); err != nil {
+		return nil, err
+	}
+
+	enabledHandoffs := make([]Handoff, 0, len(handoffs)+1)
+	for i, handoff := range handoffs {
+		if isEnabledResults[i] {
+			enabledHandoffs = append(enabledHandoffs, handoff)
+		}
+	}
+	return appendReturnToCallerHandoff(ctx, enabledHandoffs), nil
+}
+
+// appendReturnToCallerHandoff adds the handoff returning to the caller of the
+// top call frame, if any.
+func appendReturnToCallerHandoff(ctx context.Context, handoffs []Handoff) []Handoff {
+	runContext, ok := RunContextFromContext(ctx)
+	if !ok {
+		return handoffs
+	}
+	frame, ok := runContext.topCallFrame()
+	if !ok {
+		return handoffs
+	}
+	return append(handoffs, returnToCallerHandoff(frame.Caller))
+}
+
+// updateCallStack pushes or pops a call frame, as requested by a handoff step.
+func updateCallStack(runContext *RunContextWrapper, currentAgent *Agent, nextStep NextStepHandoff) {
+	switch {
+	case nextStep.PopCallFrame:
+		runContext.popCallFrame()
+	case nextStep.PushCallFrame:
+		runContext.pushCallFrame(CallFrame{Caller: currentAgent, Callee: nextStep.NewAgent})
+	}
+}
+
 func (Runner) getAllTools(ctx context.Context, agent *Agent) ([]Tool, error) {
 	return agent.GetAllTools(ctx)
 }
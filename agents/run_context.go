@@ -0,0 +1,205 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"sync/atomic"
+
+	"github.com/nlpodyssey/openai-agents-go/usage"
+)
+
+// RunContextWrapper holds the state of an agent run which is shared with all
+// callbacks: instructions, tools, hooks and guardrails.
+//
+// The Runner attaches a RunContextWrapper to the Context of every run. It can
+// be retrieved with RunContextFromContext, while the user-defined context
+// value can be accessed directly with ContextValue.
+type RunContextWrapper struct {
+	// The user-defined context value (see RunConfig.Context).
+	// It is not used by the SDK itself.
+	Context any
+
+	// The usage of the agent run so far. It is updated live after each
+	// model response.
+	Usage *usage.Usage
+
+	currentAgent atomic.Pointer[Agent]
+	currentTurn  atomic.Uint64
+
+	callStackMu       sync.Mutex
+	callStack         []CallFrame
+	maxCallStackDepth int
+
+	finalOutputRepairs  atomic.Int64
+	toolArgumentRepairs atomic.Int64
+}
+
+// CallFrame is a frame of the call stack of a run, pushed by a handoff with
+// Handoff.ReturnToCaller set.
+type CallFrame struct {
+	// The agent which invoked the handoff, and to which control returns.
+	Caller *Agent
+
+	// The agent which received control.
+	Callee *Agent
+}
+
+// NewRunContextWrapper creates a new RunContextWrapper for the given
+// user-defined context value, with empty usage.
+func NewRunContextWrapper(v any) *RunContextWrapper {
+	return &RunContextWrapper{
+		Context: v,
+		Usage:   usage.NewUsage(),
+	}
+}
+
+// CurrentAgent returns the agent which is currently running.
+func (w *RunContextWrapper) CurrentAgent() *Agent { return w.currentAgent.Load() }
+
+// CurrentTurn returns the current turn number, starting from 1.
+func (w *RunContextWrapper) CurrentTurn() uint64 { return w.currentTurn.Load() }
+
+func (w *RunContextWrapper) setCurrentAgent(a *Agent) { w.currentAgent.Store(a) }
+func (w *RunContextWrapper) setCurrentTurn(v uint64)  { w.currentTurn.Store(v) }
+
+// CallStack returns a copy of the call stack of the run, from the bottom
+// to the top frame.
+func (w *RunContextWrapper) CallStack() []CallFrame {
+	w.callStackMu.Lock()
+	defer w.callStackMu.Unlock()
+	return slices.Clone(w.callStack)
+}
+
+// CallStackDepth returns the number of frames in the call stack of the run.
+// It is zero when no agent is expected to return to a caller.
+func (w *RunContextWrapper) CallStackDepth() int {
+	w.callStackMu.Lock()
+	defer w.callStackMu.Unlock()
+	return len(w.callStack)
+}
+
+// MaxCallStackDepth returns the maximum depth reached by the call stack
+// during the run.
+func (w *RunContextWrapper) MaxCallStackDepth() int {
+	w.callStackMu.Lock()
+	defer w.callStackMu.Unlock()
+	return w.maxCallStackDepth
+}
+
+func (w *RunContextWrapper) pushCallFrame(f CallFrame) {
+	w.callStackMu.Lock()
+	defer w.callStackMu.Unlock()
+	w.callStack = append(w.callStack, f)
+	w.maxCallStackDepth = max(w.maxCallStackDepth, len(w.callStack))
+}
+
+func (w *RunContextWrapper) popCallFrame() (CallFrame, bool) {
+	w.callStackMu.Lock()
+	defer w.callStackMu.Unlock()
+	if len(w.callStack) == 0 {
+		return CallFrame{}, false
+	}
+	f := w.callStack[len(w.callStack)-1]
+	w.callStack = w.callStack[:len(w.callStack)-1]
+	return f, true
+}
+
+func (w *RunContextWrapper) topCallFrame() (CallFrame, bool) {
+	w.callStackMu.Lock()
+	defer w.callStackMu.Unlock()
+	if len(w.callStack) == 0 {
+		return CallFrame{}, false
+	}
+	return w.callStack[len(w.callStack)-1], true
+}
+
+// takeRepair consumes one of the maxRepairs repairs allowed by counter,
+// reporting whether one was left.
+func takeRepair(counter *atomic.Int64, maxRepairs int) bool {
+	for {
+		n := counter.Load()
+		if n >= int64(maxRepairs) {
+			return false
+		}
+		if counter.CompareAndSwap(n, n+1) {
+			return true
+		}
+	}
+}
+
+// RunContextValue returns the user-defined context value of the
+// RunContextWrapper, if it is of type T.
+func RunContextValue[T any](w *RunContextWrapper) (T, bool) {
+	if w == nil {
+		var zero T
+		return zero, false
+	}
+	v, ok := w.Context.(T)
+	return v, ok
+}
+
+// runContextKey is the key type for RunContextWrapper values in Contexts.
+type runContextKey struct{}
+
+// ContextWithRunContext returns a new Context that carries the given RunContextWrapper.
+func ContextWithRunContext(ctx context.Context, w *RunContextWrapper) context.Context {
+	return context.WithValue(ctx, runContextKey{}, w)
+}
+
+// RunContextFromContext returns the RunContextWrapper stored in ctx, if any.
+func RunContextFromContext(ctx context.Context) (*RunContextWrapper, bool) {
+	w, ok := ctx.Value(runContextKey{}).(*RunContextWrapper)
+	return w, ok
+}
+
+// ContextWithValue returns a new Context that carries the given user-defined
+// run context value.
+//
+// The Runner calls it automatically when RunConfig.Context is set. When
+// RunConfig.Context is not set, a run inherits the value carried by the
+// Context it is started with.
+func ContextWithValue(ctx context.Context, v any) context.Context {
+	w := &RunContextWrapper{Context: v}
+	if u, ok := usage.FromContext(ctx); ok {
+		w.Usage = u
+	} else {
+		w.Usage = usage.NewUsage()
+	}
+	return ContextWithRunContext(ctx, w)
+}
+
+// ContextValue returns the user-defined run context value stored in ctx,
+// if any, and if it is of type T.
+func ContextValue[T any](ctx context.Context) (T, bool) {
+	w, _ := RunContextFromContext(ctx)
+	return RunContextValue[T](w)
+}
+
+// newRunContext creates the RunContextWrapper of a new run, attaching it to
+// the returned Context together with its usage.
+func (r Runner) newRunContext(ctx context.Context) (context.Context, *RunContextWrapper) {
+	value := r.Config.Context
+	if value == nil {
+		if parent, ok := RunContextFromContext(ctx); ok {
+			value = parent.Context
+		}
+	}
+	w := NewRunContextWrapper(value)
+	ctx = usage.NewContext(ctx, w.Usage)
+	return ContextWithRunContext(ctx, w), w
+}
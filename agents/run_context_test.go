@@ -0,0 +1,139 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/agentstesting"
+	"github.com/nlpodyssey/openai-agents-go/usage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type tenantContext struct {
+	TenantID string
+}
+
+type runContextSnapshot struct {
+	tenant    string
+	agentName string
+	turn      uint64
+	requests  uint64
+}
+
+func snapshotRunContext(ctx context.Context) runContextSnapshot {
+	rc, ok := agents.RunContextFromContext(ctx)
+	if !ok {
+		return runContextSnapshot{}
+	}
+	tenant, _ := agents.ContextValue[tenantContext](ctx)
+	return runContextSnapshot{
+		tenant:    tenant.TenantID,
+		agentName: rc.CurrentAgent().Name,
+		turn:      rc.CurrentTurn(),
+		requests:  rc.Usage.Requests,
+	}
+}
+
+func newRunContextTestSetup() (*agentstesting.FakeModel, *agents.Agent, *[]runContextSnapshot) {
+	var snapshots []runContextSnapshot
+	tool := agents.NewFunctionTool("probe", "", func(ctx context.Context, _ struct{}) (string, error) {
+		snapshots = append(snapshots, snapshotRunContext(ctx))
+		return "ok", nil
+	})
+
+	model := agentstesting.NewFakeModel(nil)
+	model.SetHardcodedUsage(usage.Usage{Requests: 1, InputTokens: 1, TotalTokens: 1})
+
+	specialist := agents.New("specialist").WithModelInstance(model).WithTools(tool)
+	triage := agents.New("triage").
+		WithModelInstance(model).
+		WithTools(tool).
+		WithAgentHandoffs(specialist).
+		WithInputGuardrails([]agents.InputGuardrail{{
+			Name: "probe",
+			GuardrailFunction: func(ctx context.Context, _ *agents.Agent, _ agents.Input) (agents.GuardrailFunctionOutput, error) {
+				v, _ := agents.ContextValue[tenantContext](ctx)
+				return agents.GuardrailFunctionOutput{OutputInfo: v.TenantID}, nil
+			},
+		}})
+
+	model.AddMultipleTurnOutputs([]agentstesting.FakeModelTurnOutput{
+		{Value: []agents.TResponseOutputItem{agentstesting.GetFunctionToolCall("probe", `{}`)}},
+		{Value: []agents.TResponseOutputItem{agentstesting.GetHandoffToolCall(specialist, "", "")}},
+		{Value: []agents.TResponseOutputItem{agentstesting.GetFunctionToolCall("probe", `{}`)}},
+		{Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("done")}},
+	})
+
+	return model, triage, &snapshots
+}
+
+func TestRunContext(t *testing.T) {
+	_, triage, snapshots := newRunContextTestSetup()
+
+	runner := agents.Runner{Config: agents.RunConfig{Context: tenantContext{TenantID: "acme"}}}
+	result, err := runner.Run(t.Context(), triage, "hi")
+	require.NoError(t, err)
+
+	assert.Equal(t, []runContextSnapshot{
+		{tenant: "acme", agentName: "triage", turn: 1, requests: 1},
+		{tenant: "acme", agentName: "specialist", turn: 3, requests: 3},
+	}, *snapshots)
+
+	require.NotNil(t, result.RunContext)
+	assert.Equal(t, tenantContext{TenantID: "acme"}, result.RunContext.Context)
+	assert.Same(t, result.LastAgent, result.RunContext.CurrentAgent())
+	assert.Equal(t, uint64(4), result.RunContext.CurrentTurn())
+	assert.Equal(t, uint64(4), result.RunContext.Usage.Requests)
+	require.Len(t, result.InputGuardrailResults, 1)
+	assert.Equal(t, "acme", result.InputGuardrailResults[0].Output.OutputInfo)
+
+	v, ok := agents.RunContextValue[tenantContext](result.RunContext)
+	assert.True(t, ok)
+	assert.Equal(t, "acme", v.TenantID)
+	_, ok = agents.RunContextValue[string](result.RunContext)
+	assert.False(t, ok)
+}
+
+func TestRunContextStreamed(t *testing.T) {
+	_, triage, snapshots := newRunContextTestSetup()
+
+	// Without RunConfig.Context, the value carried by the Context is inherited.
+	ctx := agents.ContextWithValue(t.Context(), tenantContext{TenantID: "globex"})
+	result, err := agents.Runner{}.RunStreamed(ctx, triage, "hi")
+	require.NoError(t, err)
+	require.NoError(t, result.StreamEvents(func(agents.StreamEvent) error { return nil }))
+
+	assert.Equal(t, []runContextSnapshot{
+		{tenant: "globex", agentName: "triage", turn: 1, requests: 1},
+		{tenant: "globex", agentName: "specialist", turn: 3, requests: 3},
+	}, *snapshots)
+
+	rc := result.RunContext()
+	assert.Equal(t, tenantContext{TenantID: "globex"}, rc.Context)
+	assert.Equal(t, "specialist", rc.CurrentAgent().Name)
+	assert.Equal(t, uint64(4), rc.Usage.Requests)
+}
+
+func TestContextValueWithoutRun(t *testing.T) {
+	_, ok := agents.ContextValue[tenantContext](t.Context())
+	assert.False(t, ok)
+
+	_, ok = agents.RunContextFromContext(t.Context())
+	assert.False(t, ok)
+}
@@ -118,6 +118,14 @@ type NextStep interface {
 
 type NextStepHandoff struct {
 	NewAgent *Agent
+
+	// Whether the handoff pushes a call frame, so that the new agent returns
+	// control to the current one when done (see Handoff.ReturnToCaller).
+	PushCallFrame bool
+
+	// Whether the handoff returns control to the caller, popping the top
+	// call frame.
+	PopCallFrame bool
 }
 
 func (NextStepHandoff) isNextStep() {}
@@ -181,12 +189,13 @@ func (ri runImpl) ExecuteToolsAndSideEffects(
 	childCtx, cancel := context.WithCancel(ctx)
 	defer cancel()
 	var (
-		functionResults []FunctionToolResult
-		computerResults []RunItem
-		toolErrors      [2]error
-		wg              sync.WaitGroup
+		functionResults   []FunctionToolResult
+		computerResults   []RunItem
+		localShellResults []RunItem
+		toolErrors        [3]error
+		wg                sync.WaitGroup
 	)
-	wg.Add(2)
+	wg.Add(3)
 	go func() {
 		defer wg.Done()
 		functionResults, toolErrors[0] = ri.ExecuteFunctionToolCalls(
@@ -194,6 +203,7 @@ func (ri runImpl) ExecuteToolsAndSideEffects(
 			agent,
 			processedResponse.Functions,
 			hooks,
+			runConfig,
 		)
 	}()
 	go func() {
@@ -205,6 +215,15 @@ func (ri runImpl) ExecuteToolsAndSideEffects(
 			hooks,
 		)
 	}()
+	go func() {
+		defer wg.Done()
+		localShellResults, toolErrors[2] = ri.ExecuteLocalShellCalls(
+			childCtx,
+			agent,
+			processedResponse.LocalShellCalls,
+			hooks,
+		)
+	}()
 	wg.Wait()
 	if err := errors.Join(toolErrors[:]...); err != nil {
 		return nil, err
@@ -214,6 +233,7 @@ func (ri runImpl) ExecuteToolsAndSideEffects(
 		newStepItems = append(newStepItems, result.RunItem)
 	}
 	newStepItems = append(newStepItems, computerResults...)
+	newStepItems = append(newStepItems, localShellResults...)
 
 	// Next, check if there are any handoffs
 	if runHandoffs := processedResponse.Handoffs; len(runHandoffs) > 0 {
@@ -273,9 +293,20 @@ func (ri runImpl) ExecuteToolsAndSideEffects(
 	// 1. Structured output schema => always leads to a final output
 	// 2. Plain text output schema => only leads to a final output if there are no tool calls
 	if outputSchema != nil && !outputSchema.IsPlainText() && potentialFinalOutputText != "" {
-		finalOutput, err := outputSchema.ValidateJSON(potentialFinalOutputText)
+		lenient := agent.LenientJSONOutput.Or(runConfig.LenientJSONOutput)
+		finalOutput, err := validateFinalOutput(outputSchema, potentialFinalOutputText, lenient)
 		if err != nil {
-			return nil, fmt.Errorf("final output schema validation failed: %w", err)
+			if !takeOutputRepair(ctx, agent, runConfig, false) {
+				return nil, fmt.Errorf("final output schema validation failed: %w", err)
+			}
+			// Ask the model to try again.
+			return &SingleStepResult{
+				OriginalInput: originalInput,
+				ModelResponse: newResponse,
+				PreStepItems:  preStepItems,
+				NewStepItems:  append(newStepItems, newOutputRepairItem(agent, err)),
+				NextStep:      NextStepRunAgain{},
+			}, nil
 		}
 		return ri.ExecuteFinalOutput(
 			ctx,
@@ -555,12 +586,27 @@ func (runImpl) ExecuteFunctionToolCalls(
 	agent *Agent,
 	toolRuns []ToolRunFunction,
 	hooks RunHooks,
+	runConfig RunConfig,
 ) ([]FunctionToolResult, error) {
 	runSingleTool := func(
 		ctx context.Context,
 		funcTool FunctionTool,
 		toolCall ResponseFunctionToolCall,
 	) (any, error) {
+		guardrailData := ToolInputGuardrailData{
+			Agent:     agent,
+			Tool:      funcTool,
+			ToolCall:  toolCall,
+			Arguments: toolCall.Arguments,
+		}
+		message, rejected, err := runToolInputGuardrails(ctx, funcTool.ToolInputGuardrails, guardrailData)
+		if err != nil {
+			return nil, err
+		}
+		if rejected {
+			return message, nil
+		}
+
 		var (
 			hooksErrors [2]error
 			toolError   error
@@ -598,7 +644,9 @@ func (runImpl) ExecuteFunctionToolCalls(
 		go func() {
 			defer wg.Done()
 			result, toolError = funcTool.OnInvokeTool(childCtx, toolCall.Arguments)
-			if toolError != nil {
+			// Invalid arguments might be repaired: the other tasks are not canceled.
+			var behaviorErr ModelBehaviorError
+			if toolError != nil && !errors.As(toolError, &behaviorErr) {
 				cancel()
 			}
 		}()
@@ -608,7 +656,24 @@ func (runImpl) ExecuteFunctionToolCalls(
 		if err := errors.Join(hooksErrors[:]...); err != nil {
 			return nil, err
 		}
-		if toolError != nil {
+
+		var behaviorErr ModelBehaviorError
+		switch {
+		case toolError == nil:
+			message, rejected, err = runToolOutputGuardrails(childCtx, funcTool.ToolOutputGuardrails, ToolOutputGuardrailData{
+				ToolInputGuardrailData: guardrailData,
+				Output:                 result,
+			})
+			if err != nil {
+				return nil, err
+			}
+			if rejected {
+				result = message
+			}
+		case errors.As(toolError, &behaviorErr) && takeOutputRepair(ctx, agent, runConfig, true):
+			// Invalid arguments: ask the model to call the tool again.
+			result = toolArgumentsRepairMessage(funcTool.Name, toolError)
+		default:
 			return nil, fmt.Errorf("error running tool %s: %w", funcTool.Name, toolError)
 		}
 
@@ -773,11 +838,17 @@ func (runImpl) ExecuteHandoffs(
 		return nil, fmt.Errorf("failed to invoke handoff: %w", err)
 	}
 
-	// Append a tool output item for the handoff
-	toolCallOutputItem := ItemHelpers().ToolCallOutputItem(
-		actualHandoff.ToolCall,
-		handoff.GetTransferMessage(newAgent),
-	)
+	// Append a tool output item for the handoff. When returning to the caller,
+	// it carries the result of the current agent.
+	transferMessage := handoff.GetTransferMessage(newAgent)
+	if handoff.returnsToCaller {
+		result, err := returnToCallerResult(actualHandoff.ToolCall.Arguments)
+		if err != nil {
+			return nil, fmt.Errorf("failed to invoke handoff: %w", err)
+		}
+		transferMessage = returnToCallerMessage(newAgent, agent, result)
+	}
+	toolCallOutputItem := ItemHelpers().ToolCallOutputItem(actualHandoff.ToolCall, transferMessage)
 	newStepItems = append(newStepItems, HandoffOutputItem{
 		Agent: agent,
 		RawItem: TResponseInputItem{
@@ -849,7 +920,11 @@ func (runImpl) ExecuteHandoffs(
 		ModelResponse: newResponse,
 		PreStepItems:  preStepItems,
 		NewStepItems:  newStepItems,
-		NextStep:      NextStepHandoff{NewAgent: newAgent},
+		NextStep: NextStepHandoff{
+			NewAgent:      newAgent,
+			PushCallFrame: handoff.ReturnToCaller,
+			PopCallFrame:  handoff.returnsToCaller,
+		},
 	}, nil
 }
 
@@ -976,6 +1051,18 @@ func (runImpl) StreamStepResultToQueue(stepResult SingleStepResult, queue *async
 				Item: item,
 				Type: "run_item_stream_event",
 			}
+		case GuardrailFeedbackItem:
+			event = RunItemStreamEvent{
+				Name: StreamEventGuardrailFeedback,
+				Item: item,
+				Type: "run_item_stream_event",
+			}
+		case OutputRepairItem:
+			event = RunItemStreamEvent{
+				Name: StreamEventOutputRepair,
+				Item: item,
+				Type: "run_item_stream_event",
+			}
 		default:
 			// This would be an unrecoverable implementation bug, so a panic is appropriate.
 			panic(fmt.Errorf("unexpected RunItem type %T", item))
@@ -1158,6 +1245,20 @@ func (localShellAction) Execute(
 	call ToolRunLocalShellCall,
 	hooks RunHooks,
 ) (RunItem, error) {
+	guardrailData := ToolInputGuardrailData{
+		Agent:     agent,
+		Tool:      call.LocalShellTool,
+		ToolCall:  ResponseOutputItemLocalShellCall(call.ToolCall),
+		Arguments: localShellCallArguments(ResponseOutputItemLocalShellCall(call.ToolCall)),
+	}
+	message, rejected, err := runToolInputGuardrails(ctx, call.LocalShellTool.ToolInputGuardrails, guardrailData)
+	if err != nil {
+		return nil, err
+	}
+	if rejected {
+		return localShellCallOutputItem(agent, call, message), nil
+	}
+
 	var hooksErrors [2]error
 
 	childCtx, cancel := context.WithCancel(ctx)
@@ -1199,6 +1300,17 @@ func (localShellAction) Execute(
 		return nil, err
 	}
 
+	message, rejected, err = runToolOutputGuardrails(ctx, call.LocalShellTool.ToolOutputGuardrails, ToolOutputGuardrailData{
+		ToolInputGuardrailData: guardrailData,
+		Output:                 result,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if rejected {
+		result = message
+	}
+
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
@@ -1226,15 +1338,19 @@ func (localShellAction) Execute(
 		return nil, err
 	}
 
+	return localShellCallOutputItem(agent, call, result), nil
+}
+
+func localShellCallOutputItem(agent *Agent, call ToolRunLocalShellCall, output string) ToolCallOutputItem {
 	return ToolCallOutputItem{
 		Agent: agent,
 		RawItem: ResponseInputItemLocalShellCallOutputParam{
 			ID:     call.ToolCall.CallID,
-			Output: result,
+			Output: output,
 			Status: "",
 			Type:   constant.ValueOf[constant.LocalShellCallOutput](),
 		},
-		Output: result,
+		Output: output,
 		Type:   "tool_call_output_item",
-	}, nil
+	}
 }
@@ -0,0 +1,532 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/nlpodyssey/openai-agents-go/usage"
+	"github.com/openai/openai-go/packages/param"
+	"github.com/openai/openai-go/responses"
+)
+
+// ParallelOutcome is the outcome of one of the runs of RunParallel.
+type ParallelOutcome struct {
+	// The position of the starting agent in the list given to RunParallel.
+	Index int
+
+	// The starting agent of the run.
+	Agent *Agent
+
+	// The result of the run, if it succeeded.
+	Result *RunResult
+
+	// The error of the run, if it failed or was canceled.
+	Err error
+}
+
+// Succeeded reports whether the run completed without errors.
+func (o ParallelOutcome) Succeeded() bool {
+	return o.Err == nil && o.Result != nil
+}
+
+// ParallelAggregation is the combined output of parallel runs, produced by
+// a ParallelAggregator.
+type ParallelAggregation struct {
+	// The final output.
+	FinalOutput any
+
+	// The index of the selected run, or -1 if the output does not come from
+	// a single run.
+	Selected int
+
+	// Optional usage of the aggregation itself, for example when it runs a
+	// judge agent.
+	Usage *usage.Usage
+}
+
+// ParallelAggregateParams holds the parameters given to
+// ParallelAggregator.Aggregate.
+type ParallelAggregateParams struct {
+	// The runner used for the parallel runs.
+	Runner Runner
+
+	// The input of the parallel runs.
+	Input Input
+
+	// The outcomes of all runs, sorted by index. Runs canceled because the
+	// aggregator was done early have a non-nil Err.
+	Outcomes []ParallelOutcome
+
+	// The outcomes of the runs which completed before the aggregator was
+	// done, in completion order.
+	Completed []ParallelOutcome
+}
+
+// ParallelAggregator combines the outcomes of parallel runs.
+type ParallelAggregator interface {
+	// Done is called each time a run completes, with the outcomes so far in
+	// completion order and the total number of runs. When it returns true,
+	// the remaining runs are canceled.
+	Done(completed []ParallelOutcome, total int) bool
+
+	// Aggregate combines the outcomes into the final output. On failure,
+	// the returned aggregation should still report the Usage of the work
+	// done so far.
+	Aggregate(ctx context.Context, params ParallelAggregateParams) (ParallelAggregation, error)
+}
+
+// ParallelRunResult is the result of RunParallel.
+type ParallelRunResult struct {
+	// The combined output of the runs.
+	FinalOutput any
+
+	// The index of the run selected by the aggregator, or -1.
+	Selected int
+
+	// The outcomes of all runs, sorted by index.
+	Outcomes []ParallelOutcome
+
+	// The combined usage of all runs, including the failed ones when
+	// available, and of the aggregation.
+	Usage *usage.Usage
+}
+
+// SelectedResult returns the result of the selected run, if any.
+func (r ParallelRunResult) SelectedResult() *RunResult {
+	if r.Selected < 0 || r.Selected >= len(r.Outcomes) {
+		return nil
+	}
+	return r.Outcomes[r.Selected].Result
+}
+
+// RepeatAgent returns a list with n references to the same agent, to run it
+// n times with RunParallel.
+func RepeatAgent(agent *Agent, n int) []*Agent {
+	startingAgents := make([]*Agent, n)
+	for i := range startingAgents {
+		startingAgents[i] = agent
+	}
+	return startingAgents
+}
+
+// RunParallel runs each of the starting agents concurrently with the same input,
+// using the DefaultRunner, and combines their outcomes with the aggregator.
+func RunParallel(ctx context.Context, startingAgents []*Agent, input string, aggregator ParallelAggregator) (*ParallelRunResult, error) {
+	return DefaultRunner.RunParallel(ctx, startingAgents, input, aggregator)
+}
+
+// RunParallelResponseInputs runs each of the starting agents concurrently with
+// the same input items, using the DefaultRunner, and combines their outcomes
+// with the aggregator.
+func RunParallelResponseInputs(ctx context.Context, startingAgents []*Agent, input []TResponseInputItem, aggregator ParallelAggregator) (*ParallelRunResult, error) {
+	return DefaultRunner.RunParallelResponseInputs(ctx, startingAgents, input, aggregator)
+}
+
+// RunParallel runs each of the starting agents concurrently with the same input,
+// and combines their outcomes with the aggregator.
+//
+// Each run is independent, with its own RunContextWrapper and usage. As soon
+// as the aggregator is done, the remaining runs are canceled.
+//
+// If the aggregation fails, the error is returned together with a result
+// holding the outcomes and the combined usage, without final output.
+func (r Runner) RunParallel(ctx context.Context, startingAgents []*Agent, input string, aggregator ParallelAggregator) (*ParallelRunResult, error) {
+	return r.runParallel(ctx, startingAgents, InputString(input), aggregator)
+}
+
+// RunParallelResponseInputs runs each of the starting agents concurrently with
+// the same input items, and combines their outcomes with the aggregator.
+func (r Runner) RunParallelResponseInputs(ctx context.Context, startingAgents []*Agent, input []TResponseInputItem, aggregator ParallelAggregator) (*ParallelRunResult, error) {
+	return r.runParallel(ctx, startingAgents, InputItems(input), aggregator)
+}
+
+func (r Runner) runParallel(ctx context.Context, startingAgents []*Agent, input Input, aggregator ParallelAggregator) (*ParallelRunResult, error) {
+	if len(startingAgents) == 0 {
+		return nil, NewUserError("RunParallel requires at least one agent")
+	}
+	if aggregator == nil {
+		return nil, NewUserError("RunParallel requires an aggregator")
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	outcomes := make([]ParallelOutcome, len(startingAgents))
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		completed []ParallelOutcome
+		done      bool
+	)
+	for i, agent := range startingAgents {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result, err := r.run(runCtx, agent, CopyGeneralInput(input))
+			outcome := ParallelOutcome{Index: i, Agent: agent, Result: result, Err: err}
+
+			mu.Lock()
+			defer mu.Unlock()
+			outcomes[i] = outcome
+			if done {
+				return
+			}
+			completed = append(completed, outcome)
+			if aggregator.Done(completed, len(startingAgents)) {
+				done = true
+				cancel()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	aggregation, err := aggregator.Aggregate(ctx, ParallelAggregateParams{
+		Runner:    r,
+		Input:     input,
+		Outcomes:  outcomes,
+		Completed: completed,
+	})
+
+	combinedUsage := usage.NewUsage()
+	for _, o := range outcomes {
+		if u := parallelOutcomeUsage(o); u != nil {
+			combinedUsage.Add(u)
+		}
+	}
+	if aggregation.Usage != nil {
+		combinedUsage.Add(aggregation.Usage)
+	}
+
+	if err != nil {
+		return &ParallelRunResult{Selected: -1, Outcomes: outcomes, Usage: combinedUsage}, err
+	}
+
+	return &ParallelRunResult{
+		FinalOutput: aggregation.FinalOutput,
+		Selected:    aggregation.Selected,
+		Outcomes:    outcomes,
+		Usage:       combinedUsage,
+	}, nil
+}
+
+func parallelOutcomeUsage(o ParallelOutcome) *usage.Usage {
+	if o.Result != nil && o.Result.RunContext != nil {
+		return o.Result.RunContext.Usage
+	}
+	var agentsErr *AgentsError
+	if errors.As(o.Err, &agentsErr) && agentsErr.RunData != nil && agentsErr.RunData.Context != nil {
+		if u, ok := usage.FromContext(agentsErr.RunData.Context); ok {
+			return u
+		}
+	}
+	return nil
+}
+
+// errNoParallelSuccess returns an error joining the errors of all runs.
+func errNoParallelSuccess(outcomes []ParallelOutcome) error {
+	errs := make([]error, 0, len(outcomes))
+	for _, o := range outcomes {
+		if o.Err != nil {
+			errs = append(errs, fmt.Errorf("run %d: %w", o.Index, o.Err))
+		}
+	}
+	return fmt.Errorf("all parallel runs failed: %w", errors.Join(errs...))
+}
+
+func successfulOutcomes(outcomes []ParallelOutcome) []ParallelOutcome {
+	var result []ParallelOutcome
+	for _, o := range outcomes {
+		if o.Succeeded() {
+			result = append(result, o)
+		}
+	}
+	return result
+}
+
+// FirstSuccessAggregator selects the first run to complete successfully,
+// canceling the other ones.
+type FirstSuccessAggregator struct{}
+
+// FirstSuccess returns a ParallelAggregator selecting the first run to
+// complete successfully.
+func FirstSuccess() FirstSuccessAggregator {
+	return FirstSuccessAggregator{}
+}
+
+func (FirstSuccessAggregator) Done(completed []ParallelOutcome, _ int) bool {
+	return completed[len(completed)-1].Succeeded()
+}
+
+func (FirstSuccessAggregator) Aggregate(_ context.Context, params ParallelAggregateParams) (ParallelAggregation, error) {
+	for _, o := range params.Completed {
+		if o.Succeeded() {
+			return ParallelAggregation{
+				FinalOutput: o.Result.FinalOutput,
+				Selected:    o.Index,
+			}, nil
+		}
+	}
+	return ParallelAggregation{}, errNoParallelSuccess(params.Outcomes)
+}
+
+// MajorityVoteAggregator selects the most common final output among the
+// successful runs. Ties are broken in favor of the output of the run with
+// the lowest index.
+//
+// As soon as an output is produced by more than half of all the runs, the
+// remaining runs are canceled.
+type MajorityVoteAggregator struct {
+	// Optional function computing the key identifying equivalent outputs.
+	// Default: DefaultVoteKey.
+	Key func(output any) (string, error)
+}
+
+// MajorityVote returns a ParallelAggregator selecting the most common final
+// output, using DefaultVoteKey to compare outputs.
+func MajorityVote() MajorityVoteAggregator {
+	return MajorityVoteAggregator{}
+}
+
+// DefaultVoteKey identifies strings by their trimmed text, and structured
+// outputs by their JSON serialization.
+func DefaultVoteKey(output any) (string, error) {
+	if s, ok := output.(string); ok {
+		return strings.TrimSpace(s), nil
+	}
+	b, err := json.Marshal(output)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func (a MajorityVoteAggregator) key(output any) (string, error) {
+	if a.Key != nil {
+		return a.Key(output)
+	}
+	return DefaultVoteKey(output)
+}
+
+func (a MajorityVoteAggregator) Done(completed []ParallelOutcome, total int) bool {
+	counts := make(map[string]int)
+	for _, o := range completed {
+		if !o.Succeeded() {
+			continue
+		}
+		k, err := a.key(o.Result.FinalOutput)
+		if err != nil {
+			continue
+		}
+		counts[k]++
+		if counts[k]*2 > total {
+			return true
+		}
+	}
+	return false
+}
+
+func (a MajorityVoteAggregator) Aggregate(_ context.Context, params ParallelAggregateParams) (ParallelAggregation, error) {
+	successful := successfulOutcomes(params.Outcomes)
+	if len(successful) == 0 {
+		return ParallelAggregation{}, errNoParallelSuccess(params.Outcomes)
+	}
+
+	counts := make(map[string]int)
+	first := make(map[string]int)
+	var keys []string
+	for _, o := range successful {
+		k, err := a.key(o.Result.FinalOutput)
+		if err != nil {
+			return ParallelAggregation{}, fmt.Errorf("failed to compute vote key of run %d: %w", o.Index, err)
+		}
+		if _, ok := first[k]; !ok {
+			first[k] = o.Index
+			keys = append(keys, k)
+		}
+		counts[k]++
+	}
+
+	best := keys[0]
+	for _, k := range keys[1:] {
+		if counts[k] > counts[best] {
+			best = k
+		}
+	}
+	selected := first[best]
+	return ParallelAggregation{
+		FinalOutput: params.Outcomes[selected].Result.FinalOutput,
+		Selected:    selected,
+	}, nil
+}
+
+// JudgeAggregator runs a judge agent to select the best final output among
+// the successful runs.
+//
+// The judge receives the original input, followed by a message listing the
+// numbered candidates, and must reply with the number of the best one, as a
+// structured output: {"candidate": n}. The OutputSchema of the judge is
+// replaced for the run.
+type JudgeAggregator struct {
+	// The agent selecting the best candidate.
+	Judge *Agent
+
+	// Optional function formatting a candidate output for the judge.
+	// Default: the text messages of the run.
+	Format func(outcome ParallelOutcome) string
+}
+
+// JudgeSelection returns a ParallelAggregator using the given judge agent
+// to select the best output.
+func JudgeSelection(judge *Agent) JudgeAggregator {
+	return JudgeAggregator{Judge: judge}
+}
+
+func (JudgeAggregator) Done([]ParallelOutcome, int) bool { return false }
+
+// judgeChoice is the structured output of a JudgeAggregator judge.
+type judgeChoice struct {
+	Candidate int `json:"candidate"`
+}
+
+type judgeChoiceSchema struct{}
+
+func (judgeChoiceSchema) IsPlainText() bool        { return false }
+func (judgeChoiceSchema) Name() string             { return "judge_choice" }
+func (judgeChoiceSchema) IsStrictJSONSchema() bool { return true }
+func (judgeChoiceSchema) JSONSchema() map[string]any {
+	return map[string]any{
+		"type":                 "object",
+		"required":             []string{"candidate"},
+		"additionalProperties": false,
+		"properties": map[string]any{
+			"candidate": map[string]any{
+				"type":        "integer",
+				"description": "The number of the best candidate.",
+			},
+		},
+	}
+}
+
+func (judgeChoiceSchema) ValidateJSON(jsonStr string) (any, error) {
+	var v judgeChoice
+	if err := json.Unmarshal([]byte(jsonStr), &v); err != nil {
+		return nil, ModelBehaviorErrorf("invalid judge choice: %w", err)
+	}
+	return v, nil
+}
+
+func (a JudgeAggregator) Aggregate(ctx context.Context, params ParallelAggregateParams) (ParallelAggregation, error) {
+	successful := successfulOutcomes(params.Outcomes)
+	switch len(successful) {
+	case 0:
+		return ParallelAggregation{}, errNoParallelSuccess(params.Outcomes)
+	case 1:
+		return ParallelAggregation{
+			FinalOutput: successful[0].Result.FinalOutput,
+			Selected:    successful[0].Index,
+		}, nil
+	}
+
+	format := a.Format
+	if format == nil {
+		format = func(o ParallelOutcome) string {
+			return ItemHelpers().TextMessageOutputs(o.Result.NewItems)
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString("Select the best of the following candidate responses, and reply with its number.\n")
+	for i, o := range successful {
+		_, _ = fmt.Fprintf(&sb, "\nCandidate %d:\n%s\n", i+1, format(o))
+	}
+
+	input := ItemHelpers().InputToNewInputList(params.Input)
+	input = append(input, TResponseInputItem{
+		OfMessage: &responses.EasyInputMessageParam{
+			Content: responses.EasyInputMessageContentUnionParam{OfString: param.NewOpt(sb.String())},
+			Role:    responses.EasyInputMessageRoleUser,
+			Type:    responses.EasyInputMessageTypeMessage,
+		},
+	})
+
+	judge := *a.Judge
+	judge.OutputSchema = judgeChoiceSchema{}
+
+	// The judge usage is reported even if its run fails or its selection is invalid.
+	result, err := params.Runner.RunResponseInputs(ctx, &judge, input)
+	if err != nil {
+		aggregation := ParallelAggregation{Selected: -1, Usage: parallelOutcomeUsage(ParallelOutcome{Err: err})}
+		return aggregation, fmt.Errorf("judge run failed: %w", err)
+	}
+	aggregation := ParallelAggregation{Selected: -1, Usage: result.RunContext.Usage}
+
+	choice, ok := result.FinalOutput.(judgeChoice)
+	if !ok || choice.Candidate < 1 || choice.Candidate > len(successful) {
+		return aggregation, ModelBehaviorErrorf("judge selected an invalid candidate: %v", result.FinalOutput)
+	}
+	selected := successful[choice.Candidate-1]
+	aggregation.FinalOutput = selected.Result.FinalOutput
+	aggregation.Selected = selected.Index
+	return aggregation, nil
+}
+
+// MergeAggregator merges the final outputs of all the successful runs.
+type MergeAggregator struct {
+	// Optional function merging the successful outcomes, sorted by index.
+	// Default: the text messages of each run, separated by blank lines.
+	Merge func(ctx context.Context, successful []ParallelOutcome) (any, error)
+}
+
+// MergeOutputs returns a ParallelAggregator merging the outputs with the
+// given function, or with the default merge if nil.
+func MergeOutputs(merge func(ctx context.Context, successful []ParallelOutcome) (any, error)) MergeAggregator {
+	return MergeAggregator{Merge: merge}
+}
+
+func (MergeAggregator) Done([]ParallelOutcome, int) bool { return false }
+
+func (a MergeAggregator) Aggregate(ctx context.Context, params ParallelAggregateParams) (ParallelAggregation, error) {
+	successful := successfulOutcomes(params.Outcomes)
+	if len(successful) == 0 {
+		return ParallelAggregation{}, errNoParallelSuccess(params.Outcomes)
+	}
+
+	if a.Merge != nil {
+		output, err := a.Merge(ctx, successful)
+		if err != nil {
+			return ParallelAggregation{}, err
+		}
+		return ParallelAggregation{FinalOutput: output, Selected: -1}, nil
+	}
+
+	texts := make([]string, len(successful))
+	for i, o := range successful {
+		texts[i] = ItemHelpers().TextMessageOutputs(o.Result.NewItems)
+	}
+	return ParallelAggregation{
+		FinalOutput: strings.Join(texts, "\n\n"),
+		Selected:    -1,
+	}, nil
+}
@@ -0,0 +1,205 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents_test
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"strings"
+	"testing"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/agentstesting"
+	"github.com/nlpodyssey/openai-agents-go/usage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// parallelTextAgent returns an agent with its own model, answering text once.
+func parallelTextAgent(name, text string) *agents.Agent {
+	model := agentstesting.NewFakeModel(&agentstesting.FakeModelTurnOutput{
+		Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage(text)},
+	})
+	model.SetHardcodedUsage(usage.Usage{Requests: 1, InputTokens: 10, OutputTokens: 5, TotalTokens: 15})
+	return agents.New(name).WithModelInstance(model)
+}
+
+func parallelFailingAgent(name string) *agents.Agent {
+	model := agentstesting.NewFakeModel(&agentstesting.FakeModelTurnOutput{
+		Error: errors.New("model failure"),
+	})
+	return agents.New(name).WithModelInstance(model)
+}
+
+// blockingModel blocks until the context is canceled.
+type blockingModel struct{}
+
+func (blockingModel) GetResponse(ctx context.Context, _ agents.ModelResponseParams) (*agents.ModelResponse, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func (blockingModel) StreamResponse(context.Context, agents.ModelResponseParams) (iter.Seq2[*agents.TResponseStreamEvent, error], error) {
+	return nil, errors.New("not implemented")
+}
+
+func TestRunParallelFirstSuccess(t *testing.T) {
+	blocking := agents.New("blocking").WithModelInstance(blockingModel{})
+	startingAgents := []*agents.Agent{blocking, parallelFailingAgent("failing"), parallelTextAgent("fast", "hola")}
+
+	result, err := agents.RunParallel(t.Context(), startingAgents, "hello", agents.FirstSuccess())
+	require.NoError(t, err)
+	assert.Equal(t, "hola", result.FinalOutput)
+	assert.Equal(t, 2, result.Selected)
+	assert.Same(t, result.Outcomes[2].Result, result.SelectedResult())
+
+	// The blocking run was canceled.
+	assert.ErrorIs(t, result.Outcomes[0].Err, context.Canceled)
+	assert.Error(t, result.Outcomes[1].Err)
+	assert.Equal(t, uint64(1), result.Usage.Requests)
+	assert.Equal(t, uint64(15), result.Usage.TotalTokens)
+}
+
+func TestRunParallelAllFailed(t *testing.T) {
+	startingAgents := []*agents.Agent{parallelFailingAgent("a"), parallelFailingAgent("b")}
+	_, err := agents.RunParallel(t.Context(), startingAgents, "hello", agents.FirstSuccess())
+	assert.ErrorContains(t, err, "all parallel runs failed")
+	assert.ErrorContains(t, err, "model failure")
+
+	_, err = agents.RunParallel(t.Context(), nil, "hello", agents.FirstSuccess())
+	var userErr agents.UserError
+	assert.ErrorAs(t, err, &userErr)
+}
+
+func TestRunParallelMajorityVote(t *testing.T) {
+	startingAgents := []*agents.Agent{
+		parallelTextAgent("a", "Paris"),
+		parallelTextAgent("b", "Lyon"),
+		parallelTextAgent("c", " Lyon "),
+		parallelFailingAgent("d"),
+		parallelTextAgent("e", "Paris"),
+	}
+	result, err := agents.RunParallel(t.Context(), startingAgents, "capital?", agents.MajorityVote())
+	require.NoError(t, err)
+	// A tie: the answer of the lowest index wins.
+	assert.Equal(t, "Paris", result.FinalOutput)
+	assert.Equal(t, 0, result.Selected)
+
+	vote := agents.MajorityVoteAggregator{
+		Key: func(output any) (string, error) { return strings.ToLower(output.(string)), nil },
+	}
+	startingAgents = []*agents.Agent{
+		parallelTextAgent("a", "Rome"),
+		parallelTextAgent("b", "ROME"),
+		parallelTextAgent("c", "Milan"),
+	}
+	result, err = agents.RunParallel(t.Context(), startingAgents, "capital?", vote)
+	require.NoError(t, err)
+	assert.Equal(t, "rome", strings.ToLower(result.FinalOutput.(string)))
+}
+
+func TestRunParallelMajorityVoteCancelsOnMajority(t *testing.T) {
+	blocking := agents.New("blocking").WithModelInstance(blockingModel{})
+	startingAgents := []*agents.Agent{
+		parallelTextAgent("a", "yes"),
+		blocking,
+		parallelTextAgent("b", "yes"),
+	}
+	result, err := agents.RunParallel(t.Context(), startingAgents, "?", agents.MajorityVote())
+	require.NoError(t, err)
+	assert.Equal(t, "yes", result.FinalOutput)
+	assert.ErrorIs(t, result.Outcomes[1].Err, context.Canceled)
+}
+
+func TestRunParallelJudgeSelection(t *testing.T) {
+	judgeModel := agentstesting.NewFakeModel(&agentstesting.FakeModelTurnOutput{
+		Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage(`{"candidate": 2}`)},
+	})
+	judgeModel.SetHardcodedUsage(usage.Usage{Requests: 1, TotalTokens: 7})
+	judge := agents.New("judge").WithModelInstance(judgeModel)
+
+	startingAgents := []*agents.Agent{
+		parallelTextAgent("a", "uno"),
+		parallelFailingAgent("b"),
+		parallelTextAgent("c", "dos"),
+	}
+	result, err := agents.RunParallel(t.Context(), startingAgents, "translate", agents.JudgeSelection(judge))
+	require.NoError(t, err)
+	assert.Equal(t, "dos", result.FinalOutput)
+	assert.Equal(t, 2, result.Selected)
+	assert.Equal(t, uint64(3), result.Usage.Requests)
+	assert.Equal(t, uint64(37), result.Usage.TotalTokens)
+
+	judgeInput, ok := judgeModel.LastTurnArgs.Input.(agents.InputItems)
+	require.True(t, ok)
+	require.Len(t, judgeInput, 2)
+	prompt := judgeInput[1].OfMessage.Content.OfString.Value
+	assert.Contains(t, prompt, "Candidate 1:\nuno")
+	assert.Contains(t, prompt, "Candidate 2:\ndos")
+
+	assert.Equal(t, "judge_choice", judgeModel.LastTurnArgs.OutputSchema.Name())
+	assert.Nil(t, judge.OutputSchema)
+
+	// The usage of the judge is kept when its choice is out of range, and
+	// when its reply is not a valid structured output.
+	for _, reply := range []string{`{"candidate": 7}`, "Of the 3 candidates, #2 is best"} {
+		badJudgeModel := agentstesting.NewFakeModel(&agentstesting.FakeModelTurnOutput{
+			Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage(reply)},
+		})
+		badJudgeModel.SetHardcodedUsage(usage.Usage{Requests: 1, TotalTokens: 7})
+		badJudge := agents.New("judge").WithModelInstance(badJudgeModel)
+		startingAgents = []*agents.Agent{parallelTextAgent("a", "uno"), parallelTextAgent("b", "dos")}
+		result, err = agents.RunParallel(t.Context(), startingAgents, "translate", agents.JudgeSelection(badJudge))
+		var behaviorErr agents.ModelBehaviorError
+		assert.ErrorAs(t, err, &behaviorErr, reply)
+		require.NotNil(t, result, reply)
+		assert.Nil(t, result.FinalOutput, reply)
+		assert.Equal(t, -1, result.Selected, reply)
+		assert.Len(t, result.Outcomes, 2, reply)
+		assert.Equal(t, uint64(3), result.Usage.Requests, reply)
+		assert.Equal(t, uint64(37), result.Usage.TotalTokens, reply)
+	}
+}
+
+func TestRunParallelMerge(t *testing.T) {
+	startingAgents := []*agents.Agent{
+		parallelTextAgent("a", "first"),
+		parallelFailingAgent("b"),
+		parallelTextAgent("c", "second"),
+	}
+	result, err := agents.RunParallel(t.Context(), startingAgents, "go", agents.MergeOutputs(nil))
+	require.NoError(t, err)
+	assert.Equal(t, "first\n\nsecond", result.FinalOutput)
+	assert.Equal(t, -1, result.Selected)
+	assert.Nil(t, result.SelectedResult())
+
+	count := agents.MergeOutputs(func(_ context.Context, successful []agents.ParallelOutcome) (any, error) {
+		return len(successful), nil
+	})
+	startingAgents = []*agents.Agent{parallelTextAgent("a", "x"), parallelTextAgent("b", "y")}
+	result, err = agents.RunParallel(t.Context(), startingAgents, "go", count)
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.FinalOutput)
+}
+
+func TestRepeatAgent(t *testing.T) {
+	agent := agents.New("a")
+	repeated := agents.RepeatAgent(agent, 3)
+	require.Len(t, repeated, 3)
+	for _, a := range repeated {
+		assert.Same(t, agent, a)
+	}
+}
@@ -56,6 +56,8 @@ const (
 	StreamEventToolCalled           RunItemStreamEventName = "tool_called"
 	StreamEventToolOutput           RunItemStreamEventName = "tool_output"
 	StreamEventReasoningItemCreated RunItemStreamEventName = "reasoning_item_created"
+	StreamEventGuardrailFeedback    RunItemStreamEventName = "guardrail_feedback"
+	StreamEventOutputRepair         RunItemStreamEventName = "output_repair"
 )
 
 // AgentUpdatedStreamEvent is an event that notifies that there is a new agent running.
@@ -63,6 +65,10 @@ type AgentUpdatedStreamEvent struct {
 	// The new agent.
 	NewAgent *Agent
 
+	// The depth of the call stack after the update, i.e. the number of
+	// callers waiting for an agent to return (see Handoff.ReturnToCaller).
+	CallStackDepth int
+
 	// Always `agent_updated_stream_event`.
 	Type string
 }
@@ -80,3 +80,19 @@ func TestConvertHandoffTool(t *testing.T) {
 		Type: constant.ValueOf[constant.Function](),
 	}, result)
 }
+
+func TestToOpenaiWithLocalShellTool(t *testing.T) {
+	result, err := agents.ChatCmplConverter().ToolToOpenai(agents.LocalShellTool{})
+	require.NoError(t, err)
+	assert.Equal(t, &openai.ChatCompletionToolParam{
+		Function: openai.FunctionDefinitionParam{
+			Name:        "local_shell",
+			Description: param.NewOpt("Run a command on the local shell."),
+			Parameters:  agents.ChatCmplConverter().LocalShellFunctionParameters(),
+		},
+		Type: constant.ValueOf[constant.Function](),
+	}, result)
+
+	_, err = agents.ChatCmplConverter().ToolToOpenai(agents.WebSearchTool{})
+	assert.ErrorAs(t, err, &agents.UserError{})
+}
@@ -17,7 +17,6 @@ package agents
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 	"reflect"
 
 	"github.com/invopop/jsonschema"
@@ -56,6 +55,12 @@ type FunctionTool struct {
 	// enable/disable a tool based on your context/state.
 	// Default value, if omitted: true.
 	IsEnabled FunctionToolEnabler
+
+	// Optional checks that run before the tool is invoked.
+	ToolInputGuardrails []ToolInputGuardrail
+
+	// Optional checks that run on the output of the tool, before it is sent to the model.
+	ToolOutputGuardrails []ToolOutputGuardrail
 }
 
 func (t FunctionTool) ToolName() string {
@@ -142,6 +147,9 @@ func (f FunctionToolEnablerFunc) IsEnabled(ctx context.Context, agent *Agent) (b
 //	// Create tool with auto-generated schema
 //	tool := NewFunctionTool("get_weather", "Get current weather", getWeather)
 //
+// Arguments which cannot be parsed make the tool return a ModelBehaviorError,
+// which the runner can send back to the model (see Agent.OutputRepairRetries).
+//
 // For more control over the schema, create a FunctionTool manually instead.
 func NewFunctionTool[T, R any](name string, description string, handler func(ctx context.Context, args T) (R, error)) FunctionTool {
 	reflector := &jsonschema.Reflector{
@@ -183,7 +191,7 @@ func NewFunctionTool[T, R any](name string, description string, handler func(ctx
 		OnInvokeTool: func(ctx context.Context, arguments string) (any, error) {
 			var args T
 			if err := json.Unmarshal([]byte(arguments), &args); err != nil {
-				return nil, fmt.Errorf("failed to parse arguments: %w", err)
+				return nil, ModelBehaviorErrorf("failed to parse arguments: %w", err)
 			}
 			return handler(ctx, args)
 		},
@@ -0,0 +1,238 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// ToolGuardrailBehavior is the decision of a tool guardrail.
+type ToolGuardrailBehavior uint8
+
+const (
+	// ToolGuardrailAllow lets the tool call proceed normally.
+	ToolGuardrailAllow ToolGuardrailBehavior = iota
+
+	// ToolGuardrailRejectContent replaces the tool output seen by the model with
+	// ToolGuardrailFunctionOutput.Message. For an input guardrail, the tool is not invoked.
+	ToolGuardrailRejectContent
+
+	// ToolGuardrailRaiseTripwire halts the agent execution with a
+	// ToolInputGuardrailTripwireTriggeredError or ToolOutputGuardrailTripwireTriggeredError.
+	ToolGuardrailRaiseTripwire
+)
+
+// ToolGuardrailFunctionOutput is the output of a tool guardrail function.
+type ToolGuardrailFunctionOutput struct {
+	// Optional information about the guardrail's output. For example, the guardrail could include
+	// information about the checks it performed and granular results.
+	OutputInfo any
+
+	// What to do with the tool call. Default: ToolGuardrailAllow.
+	Behavior ToolGuardrailBehavior
+
+	// The message sent to the model as the tool output, when Behavior is ToolGuardrailRejectContent.
+	// An output guardrail can use it to send a redacted version of the output.
+	Message string
+}
+
+// ToolGuardrailAllowCall returns a ToolGuardrailFunctionOutput allowing the tool call.
+func ToolGuardrailAllowCall(outputInfo any) ToolGuardrailFunctionOutput {
+	return ToolGuardrailFunctionOutput{OutputInfo: outputInfo, Behavior: ToolGuardrailAllow}
+}
+
+// ToolGuardrailRejectCall returns a ToolGuardrailFunctionOutput replacing the tool output
+// with the given message.
+func ToolGuardrailRejectCall(message string, outputInfo any) ToolGuardrailFunctionOutput {
+	return ToolGuardrailFunctionOutput{OutputInfo: outputInfo, Behavior: ToolGuardrailRejectContent, Message: message}
+}
+
+// ToolGuardrailTripwire returns a ToolGuardrailFunctionOutput halting the agent execution.
+func ToolGuardrailTripwire(outputInfo any) ToolGuardrailFunctionOutput {
+	return ToolGuardrailFunctionOutput{OutputInfo: outputInfo, Behavior: ToolGuardrailRaiseTripwire}
+}
+
+// ToolInputGuardrailData is the data passed to a ToolInputGuardrail.
+type ToolInputGuardrailData struct {
+	// The agent which requested the tool call.
+	Agent *Agent
+
+	// The tool being called, a FunctionTool or a LocalShellTool.
+	Tool Tool
+
+	// The raw tool call: ResponseFunctionToolCall or ResponseOutputItemLocalShellCall.
+	ToolCall ToolCallItemType
+
+	// The arguments of the call, as a JSON string. For a local shell call, this is the JSON
+	// representation of the action, including the command to execute.
+	Arguments string
+}
+
+// ToolOutputGuardrailData is the data passed to a ToolOutputGuardrail.
+type ToolOutputGuardrailData struct {
+	ToolInputGuardrailData
+
+	// The output returned by the tool.
+	Output any
+}
+
+// A ToolInputGuardrail is a check that runs before a tool is invoked.
+//
+// Tool input guardrails can be used to do things like:
+//   - Block dangerous shell commands
+//   - Reject calls with arguments outside of the allowed ranges
+//
+// The guardrails of a tool are run in order, and the first one not allowing the call decides
+// its fate.
+type ToolInputGuardrail struct {
+	// A function that receives the tool call data and the context, and returns a
+	// ToolGuardrailFunctionOutput.
+	GuardrailFunction ToolInputGuardrailFunction
+
+	// The name of the guardrail, used for error reporting and debugging.
+	Name string
+}
+
+type ToolInputGuardrailFunction = func(context.Context, ToolInputGuardrailData) (ToolGuardrailFunctionOutput, error)
+
+func (g ToolInputGuardrail) Run(ctx context.Context, data ToolInputGuardrailData) (ToolInputGuardrailResult, error) {
+	output, err := g.GuardrailFunction(ctx, data)
+	result := ToolInputGuardrailResult{
+		Guardrail: g,
+		Data:      data,
+		Output:    output,
+	}
+	return result, err
+}
+
+// ToolInputGuardrailResult is the result of a tool input guardrail run.
+type ToolInputGuardrailResult struct {
+	// The guardrail that was run.
+	Guardrail ToolInputGuardrail
+
+	// The tool call data checked by the guardrail.
+	Data ToolInputGuardrailData
+
+	// The output of the guardrail function.
+	Output ToolGuardrailFunctionOutput
+}
+
+// A ToolOutputGuardrail is a check that runs on the output of a tool, before it is sent
+// to the model.
+//
+// Tool output guardrails can be used to do things like:
+//   - Redact secrets from tool outputs
+//   - Detect prompt injection attempts in fetched content
+//
+// The guardrails of a tool are run in order, and the first one not allowing the output decides
+// its fate.
+type ToolOutputGuardrail struct {
+	// A function that receives the tool call data with its output and the context, and returns a
+	// ToolGuardrailFunctionOutput.
+	GuardrailFunction ToolOutputGuardrailFunction
+
+	// The name of the guardrail, used for error reporting and debugging.
+	Name string
+}
+
+type ToolOutputGuardrailFunction = func(context.Context, ToolOutputGuardrailData) (ToolGuardrailFunctionOutput, error)
+
+func (g ToolOutputGuardrail) Run(ctx context.Context, data ToolOutputGuardrailData) (ToolOutputGuardrailResult, error) {
+	output, err := g.GuardrailFunction(ctx, data)
+	result := ToolOutputGuardrailResult{
+		Guardrail: g,
+		Data:      data,
+		Output:    output,
+	}
+	return result, err
+}
+
+// ToolOutputGuardrailResult is the result of a tool output guardrail run.
+type ToolOutputGuardrailResult struct {
+	// The guardrail that was run.
+	Guardrail ToolOutputGuardrail
+
+	// The tool call data and output checked by the guardrail.
+	Data ToolOutputGuardrailData
+
+	// The output of the guardrail function.
+	Output ToolGuardrailFunctionOutput
+}
+
+// runToolInputGuardrails runs the input guardrails of a tool in order. It
+// returns the message replacing the tool output if the call is rejected, or
+// an error if a tripwire is triggered.
+func runToolInputGuardrails(
+	ctx context.Context,
+	guardrails []ToolInputGuardrail,
+	data ToolInputGuardrailData,
+) (message string, rejected bool, err error) {
+	for _, guardrail := range guardrails {
+		result, err := guardrail.Run(ctx, data)
+		if err != nil {
+			return "", false, fmt.Errorf("failed to run tool input guardrail %s: %w", guardrail.Name, err)
+		}
+		switch result.Output.Behavior {
+		case ToolGuardrailAllow:
+			continue
+		case ToolGuardrailRejectContent:
+			return result.Output.Message, true, nil
+		case ToolGuardrailRaiseTripwire:
+			return "", false, NewToolInputGuardrailTripwireTriggeredError(result)
+		default:
+			return "", false, UserErrorf("tool input guardrail %s returned unknown behavior %d", guardrail.Name, result.Output.Behavior)
+		}
+	}
+	return "", false, nil
+}
+
+// runToolOutputGuardrails runs the output guardrails of a tool in order. It
+// returns the message replacing the tool output if the output is rejected,
+// or an error if a tripwire is triggered.
+func runToolOutputGuardrails(
+	ctx context.Context,
+	guardrails []ToolOutputGuardrail,
+	data ToolOutputGuardrailData,
+) (message string, rejected bool, err error) {
+	for _, guardrail := range guardrails {
+		result, err := guardrail.Run(ctx, data)
+		if err != nil {
+			return "", false, fmt.Errorf("failed to run tool output guardrail %s: %w", guardrail.Name, err)
+		}
+		switch result.Output.Behavior {
+		case ToolGuardrailAllow:
+			continue
+		case ToolGuardrailRejectContent:
+			return result.Output.Message, true, nil
+		case ToolGuardrailRaiseTripwire:
+			return "", false, NewToolOutputGuardrailTripwireTriggeredError(result)
+		default:
+			return "", false, UserErrorf("tool output guardrail %s returned unknown behavior %d", guardrail.Name, result.Output.Behavior)
+		}
+	}
+	return "", false, nil
+}
+
+// localShellCallArguments returns the JSON representation of the action of
+// a local shell call.
+func localShellCallArguments(call ResponseOutputItemLocalShellCall) string {
+	b, err := json.Marshal(call.Action)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
@@ -0,0 +1,169 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/agentstesting"
+	"github.com/openai/openai-go/responses"
+	"github.com/openai/openai-go/shared/constant"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func toolOutputs(items []agents.RunItem) []any {
+	var outputs []any
+	for _, item := range items {
+		if v, ok := item.(agents.ToolCallOutputItem); ok {
+			outputs = append(outputs, v.Output)
+		}
+	}
+	return outputs
+}
+
+func TestToolInputGuardrailRejectsCall(t *testing.T) {
+	invoked := false
+	tool := agentstesting.GetFunctionTool("delete", "deleted")
+	onInvoke := tool.OnInvokeTool
+	tool.OnInvokeTool = func(ctx context.Context, arguments string) (any, error) {
+		invoked = true
+		return onInvoke(ctx, arguments)
+	}
+	tool.ToolInputGuardrails = []agents.ToolInputGuardrail{{
+		Name: "no_root",
+		GuardrailFunction: func(_ context.Context, data agents.ToolInputGuardrailData) (agents.ToolGuardrailFunctionOutput, error) {
+			if strings.Contains(data.Arguments, `"/"`) {
+				return agents.ToolGuardrailRejectCall("deleting / is not allowed", nil), nil
+			}
+			return agents.ToolGuardrailAllowCall(nil), nil
+		},
+	}}
+
+	model := agentstesting.NewFakeModel(nil)
+	model.AddMultipleTurnOutputs([]agentstesting.FakeModelTurnOutput{
+		{Value: []agents.TResponseOutputItem{agentstesting.GetFunctionToolCall("delete", `{"path":"/"}`)}},
+		{Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("done")}},
+	})
+	agent := agents.New("test").WithModelInstance(model).WithTools(tool)
+
+	result, err := agents.Run(t.Context(), agent, "hello")
+	require.NoError(t, err)
+	assert.False(t, invoked)
+	assert.Equal(t, []any{"deleting / is not allowed"}, toolOutputs(result.NewItems))
+}
+
+func TestToolOutputGuardrailRedactsOutput(t *testing.T) {
+	tool := agentstesting.GetFunctionTool("read_config", "user=admin password=hunter2")
+	tool.ToolOutputGuardrails = []agents.ToolOutputGuardrail{{
+		Name: "redact_secrets",
+		GuardrailFunction: func(_ context.Context, data agents.ToolOutputGuardrailData) (agents.ToolGuardrailFunctionOutput, error) {
+			output := data.Output.(string)
+			if i := strings.Index(output, "password="); i >= 0 {
+				return agents.ToolGuardrailRejectCall(output[:i]+"password=[REDACTED]", nil), nil
+			}
+			return agents.ToolGuardrailAllowCall(nil), nil
+		},
+	}}
+
+	model := agentstesting.NewFakeModel(nil)
+	model.AddMultipleTurnOutputs([]agentstesting.FakeModelTurnOutput{
+		{Value: []agents.TResponseOutputItem{agentstesting.GetFunctionToolCall("read_config", `{}`)}},
+		{Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("done")}},
+	})
+	agent := agents.New("test").WithModelInstance(model).WithTools(tool)
+
+	result, err := agents.Run(t.Context(), agent, "hello")
+	require.NoError(t, err)
+	assert.Equal(t, []any{"user=admin password=[REDACTED]"}, toolOutputs(result.NewItems))
+
+	// The model sees the redacted output.
+	input := model.LastTurnArgs.Input.(agents.InputItems)
+	assert.Equal(t, "user=admin password=[REDACTED]", input[len(input)-1].OfFunctionCallOutput.Output)
+}
+
+func TestToolGuardrailTripwire(t *testing.T) {
+	tool := agentstesting.GetFunctionTool("tool", "secret")
+	tool.ToolOutputGuardrails = []agents.ToolOutputGuardrail{{
+		Name: "leak",
+		GuardrailFunction: func(context.Context, agents.ToolOutputGuardrailData) (agents.ToolGuardrailFunctionOutput, error) {
+			return agents.ToolGuardrailTripwire("leak detected"), nil
+		},
+	}}
+
+	model := agentstesting.NewFakeModel(&agentstesting.FakeModelTurnOutput{
+		Value: []agents.TResponseOutputItem{agentstesting.GetFunctionToolCall("tool", `{}`)},
+	})
+	agent := agents.New("test").WithModelInstance(model).WithTools(tool)
+
+	_, err := agents.Run(t.Context(), agent, "hello")
+	var tripwireErr agents.ToolOutputGuardrailTripwireTriggeredError
+	require.ErrorAs(t, err, &tripwireErr)
+	assert.Equal(t, "leak", tripwireErr.GuardrailResult.Guardrail.Name)
+	assert.Equal(t, "leak detected", tripwireErr.GuardrailResult.Output.OutputInfo)
+	assert.Equal(t, "secret", tripwireErr.GuardrailResult.Data.Output)
+}
+
+func getLocalShellRunCall(tool agents.LocalShellTool, command ...string) agents.ToolRunLocalShellCall {
+	return agents.ToolRunLocalShellCall{
+		ToolCall: responses.ResponseOutputItemLocalShellCall{
+			ID:     "1",
+			CallID: "call_1",
+			Type:   constant.ValueOf[constant.LocalShellCall](),
+			Status: "completed",
+			Action: responses.ResponseOutputItemLocalShellCallAction{
+				Type:    constant.ValueOf[constant.Exec](),
+				Command: command,
+			},
+		},
+		LocalShellTool: tool,
+	}
+}
+
+func TestLocalShellToolInputGuardrail(t *testing.T) {
+	var executed [][]string
+	shell := agents.LocalShellTool{
+		Executor: func(_ context.Context, req agents.LocalShellCommandRequest) (string, error) {
+			executed = append(executed, req.Data.Action.Command)
+			return "ok", nil
+		},
+		ToolInputGuardrails: []agents.ToolInputGuardrail{{
+			Name: "no_rm_rf",
+			GuardrailFunction: func(_ context.Context, data agents.ToolInputGuardrailData) (agents.ToolGuardrailFunctionOutput, error) {
+				call := data.ToolCall.(agents.ResponseOutputItemLocalShellCall)
+				if strings.Contains(strings.Join(call.Action.Command, " "), "rm -rf") {
+					return agents.ToolGuardrailTripwire(data.Arguments), nil
+				}
+				return agents.ToolGuardrailAllowCall(nil), nil
+			},
+		}},
+	}
+	agent := agents.New("test").WithTools(shell)
+	hooks := agents.NoOpRunHooks{}
+
+	item, err := agents.LocalShellAction().Execute(t.Context(), agent, getLocalShellRunCall(shell, "ls", "-l"), hooks)
+	require.NoError(t, err)
+	assert.Equal(t, "ok", item.(agents.ToolCallOutputItem).Output)
+
+	_, err = agents.LocalShellAction().Execute(t.Context(), agent, getLocalShellRunCall(shell, "rm", "-rf", "/"), hooks)
+	var tripwireErr agents.ToolInputGuardrailTripwireTriggeredError
+	require.ErrorAs(t, err, &tripwireErr)
+	assert.Equal(t, [][]string{{"ls", "-l"}}, executed)
+	assert.Contains(t, tripwireErr.GuardrailResult.Output.OutputInfo, `"command":["rm","-rf","/"]`)
+	assert.Equal(t, "local_shell", tripwireErr.GuardrailResult.Data.Tool.ToolName())
+}
@@ -33,6 +33,12 @@ type LocalShellExecutor = func(context.Context, LocalShellCommandRequest) (strin
 type LocalShellTool struct {
 	// A function that executes a command on a shell.
 	Executor LocalShellExecutor
+
+	// Optional checks that run before the command is executed.
+	ToolInputGuardrails []ToolInputGuardrail
+
+	// Optional checks that run on the output of the command, before it is sent to the model.
+	ToolOutputGuardrails []ToolOutputGuardrail
 }
 
 func (t LocalShellTool) ToolName() string {
@@ -99,3 +99,37 @@ func GetFinalOutputMessage(args string) responses.ResponseOutputItemUnion {
 		Status: string(responses.ResponseOutputMessageStatusCompleted),
 	}
 }
+
+func GetReasoningItem(summary string) responses.ResponseOutputItemUnion {
+	return responses.ResponseOutputItemUnion{ // responses.ResponseReasoningItem
+		ID:   "1",
+		Type: "reasoning",
+		Summary: []responses.ResponseReasoningItemSummary{{
+			Text: summary,
+			Type: constant.ValueOf[constant.SummaryText](),
+		}},
+	}
+}
+
+func GetComputerToolCall(action responses.ResponseOutputItemUnionAction) responses.ResponseOutputItemUnion {
+	return responses.ResponseOutputItemUnion{ // responses.ResponseComputerToolCall
+		ID:     "1",
+		CallID: "2",
+		Type:   "computer_call",
+		Action: action,
+		Status: string(responses.ResponseComputerToolCallStatusCompleted),
+	}
+}
+
+func GetLocalShellCall(command ...string) responses.ResponseOutputItemUnion {
+	return responses.ResponseOutputItemUnion{ // responses.ResponseOutputItemLocalShellCall
+		ID:     "1",
+		CallID: "2",
+		Type:   "local_shell_call",
+		Action: responses.ResponseOutputItemUnionAction{
+			Type:    "exec",
+			Command: command,
+		},
+		Status: "completed",
+	}
+}
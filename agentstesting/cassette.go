@@ -0,0 +1,253 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agentstesting
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/openai/openai-go/responses"
+)
+
+// A Cassette holds recorded model interactions, to be served back by a
+// ReplayModel or a ReplayTransport so that tests run deterministically and
+// without network access.
+//
+// Cassettes are recorded by a RecordingModel, wrapping any agents.Model, or by
+// a RecordingTransport, wrapping the HTTP transport of an OpenAI client.
+type Cassette struct {
+	Interactions     []Interaction     `json:"interactions,omitempty"`
+	HTTPInteractions []HTTPInteraction `json:"http_interactions,omitempty"`
+}
+
+// An Interaction is a single recorded model call.
+type Interaction struct {
+	Request CassetteRequest `json:"request"`
+
+	// The response of a non-streamed call.
+	Response *CassetteResponse `json:"response,omitempty"`
+
+	// The raw events of a streamed call.
+	Stream []json.RawMessage `json:"stream,omitempty"`
+
+	// The error returned by the model, if any.
+	Error string `json:"error,omitempty"`
+}
+
+// CassetteRequest is the normalized form of agents.ModelResponseParams used
+// to match replayed calls with recorded ones. Tools, handoffs and output
+// schemas are identified by name; server-assigned item IDs are dropped from
+// the input, and empty values from all JSON fields.
+type CassetteRequest struct {
+	SystemInstructions string          `json:"system_instructions,omitempty"`
+	Input              json.RawMessage `json:"input,omitempty"`
+	ModelSettings      json.RawMessage `json:"model_settings,omitempty"`
+	Tools              []string        `json:"tools,omitempty"`
+	OutputSchema       string          `json:"output_schema,omitempty"`
+	Handoffs           []string        `json:"handoffs,omitempty"`
+	PreviousResponseID string          `json:"previous_response_id,omitempty"`
+	Prompt             json.RawMessage `json:"prompt,omitempty"`
+	Stream             bool            `json:"stream,omitempty"`
+}
+
+// CassetteResponse is a recorded agents.ModelResponse.
+type CassetteResponse struct {
+	ID     string                  `json:"id,omitempty"`
+	Output []json.RawMessage       `json:"output"`
+	Usage  responses.ResponseUsage `json:"usage"`
+}
+
+// LoadCassette reads a cassette from a JSON file.
+func LoadCassette(path string) (*Cassette, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cassette: %w", err)
+	}
+	var c Cassette
+	if err = json.Unmarshal(b, &c); err != nil {
+		return nil, fmt.Errorf("failed to decode cassette %s: %w", path, err)
+	}
+	return &c, nil
+}
+
+// Save writes the cassette to a JSON file, creating its directory if needed.
+func (c *Cassette) Save(path string) error {
+	b, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode cassette: %w", err)
+	}
+	if err = os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create cassette directory: %w", err)
+	}
+	if err = os.WriteFile(path, append(b, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write cassette: %w", err)
+	}
+	return nil
+}
+
+// NewCassetteRequest normalizes the parameters of a model call.
+func NewCassetteRequest(params agents.ModelResponseParams, stream bool) (CassetteRequest, error) {
+	input, err := normalizeInput(agents.ItemHelpers().InputToNewInputList(params.Input))
+	if err != nil {
+		return CassetteRequest{}, fmt.Errorf("failed to normalize input: %w", err)
+	}
+	modelSettings, err := normalizeJSON(params.ModelSettings)
+	if err != nil {
+		return CassetteRequest{}, fmt.Errorf("failed to normalize model settings: %w", err)
+	}
+	var prompt json.RawMessage
+	if !reflect.ValueOf(params.Prompt).IsZero() {
+		if prompt, err = normalizeJSON(params.Prompt); err != nil {
+			return CassetteRequest{}, fmt.Errorf("failed to normalize prompt: %w", err)
+		}
+	}
+
+	request := CassetteRequest{
+		SystemInstructions: params.SystemInstructions.Value,
+		Input:              input,
+		ModelSettings:      modelSettings,
+		PreviousResponseID: params.PreviousResponseID,
+		Prompt:             prompt,
+		Stream:             stream,
+	}
+	for _, tool := range params.Tools {
+		request.Tools = append(request.Tools, tool.ToolName())
+	}
+	for _, handoff := range params.Handoffs {
+		request.Handoffs = append(request.Handoffs, handoff.ToolName)
+	}
+	if params.OutputSchema != nil && !params.OutputSchema.IsPlainText() {
+		request.OutputSchema = params.OutputSchema.Name()
+	}
+	return request, nil
+}
+
+// Matches reports whether two requests are equivalent, ignoring whether they
+// were streamed and how their JSON fields are formatted.
+func (r CassetteRequest) Matches(other CassetteRequest) bool {
+	a, errA := r.key()
+	b, errB := other.key()
+	return errA == nil && errB == nil && a == b
+}
+
+func (r CassetteRequest) key() (string, error) {
+	r.Stream = false
+	b, err := normalizeJSON(r)
+	return string(b), err
+}
+
+// normalizeInput encodes the input items, dropping their IDs.
+func normalizeInput(items []agents.TResponseInputItem) (json.RawMessage, error) {
+	b, err := json.Marshal(items)
+	if err != nil {
+		return nil, err
+	}
+	var decoded []any
+	if err = json.Unmarshal(b, &decoded); err != nil {
+		return nil, err
+	}
+	for _, item := range decoded {
+		if m, ok := item.(map[string]any); ok {
+			delete(m, "id")
+		}
+	}
+	return normalizeJSON(decoded)
+}
+
+// normalizeJSON encodes v as compact JSON with sorted keys, dropping null and
+// empty values. It returns nil if nothing is left.
+func normalizeJSON(v any) (json.RawMessage, error) {
+	return encodePrunedJSON(v, false)
+}
+
+// encodePrunedJSON is like normalizeJSON, also dropping zero numbers and false
+// values if zeros is true. This is lossless for values built in Go, which are
+// decoded back to the same zero values. With zeros, the variants of unions
+// built in Go, which encoding/json cannot inline, are inlined too.
+func encodePrunedJSON(v any, zeros bool) (json.RawMessage, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var decoded any
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+	if err = decoder.Decode(&decoded); err != nil {
+		return nil, err
+	}
+	decoded = pruneJSON(decoded, zeros)
+	if decoded == nil {
+		return nil, nil
+	}
+	return json.Marshal(decoded)
+}
+
+func pruneJSON(v any, zeros bool) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, item := range v {
+			if item = pruneJSON(item, zeros); item == nil {
+				delete(v, k)
+			} else {
+				v[k] = item
+			}
+		}
+		if len(v) == 0 {
+			return nil
+		}
+		if zeros && len(v) == 1 {
+			for k, item := range v {
+				if isUnionVariant(k) {
+					return item
+				}
+			}
+		}
+	case []any:
+		if len(v) == 0 {
+			return nil
+		}
+		for i, item := range v {
+			v[i] = pruneJSON(item, zeros)
+		}
+	case string:
+		if v == "" {
+			return nil
+		}
+	case json.Number:
+		if zeros {
+			if f, err := v.Float64(); err == nil && f == 0 {
+				return nil
+			}
+		}
+	case bool:
+		if zeros && !v {
+			return nil
+		}
+	}
+	return v
+}
+
+// isUnionVariant reports whether a key is the field name of a union variant,
+// such as OfString.
+func isUnionVariant(key string) bool {
+	return len(key) > 2 && strings.HasPrefix(key, "Of") && unicode.IsUpper(rune(key[2]))
+}
@@ -0,0 +1,175 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agentstesting_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/agentstesting"
+	"github.com/openai/openai-go/option"
+	"github.com/openai/openai-go/packages/param"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func recordWeatherRun(t *testing.T) *agentstesting.Cassette {
+	t.Helper()
+	model := agentstesting.NewFakeModel(nil)
+	model.AddMultipleTurnOutputs([]agentstesting.FakeModelTurnOutput{
+		{Value: []agents.TResponseOutputItem{agentstesting.GetFunctionToolCall("weather", `{"city": "Rome"}`)}},
+		{Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("It is sunny in Rome.")}},
+	})
+	recorder := agentstesting.NewRecordingModel(model)
+	agent := agents.New("test").
+		WithInstructions("You report the weather.").
+		WithModelInstance(recorder).
+		WithTools(agentstesting.GetFunctionTool("weather", "sunny"))
+
+	result, err := agents.Run(t.Context(), agent, "Weather in Rome?")
+	require.NoError(t, err)
+	require.Equal(t, "It is sunny in Rome.", result.FinalOutput)
+	return recorder.Cassette
+}
+
+func TestRecordAndReplayModel(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassettes", "weather.json")
+	require.NoError(t, recordWeatherRun(t).Save(path))
+
+	cassette, err := agentstesting.LoadCassette(path)
+	require.NoError(t, err)
+	require.Len(t, cassette.Interactions, 2)
+	assert.Equal(t, "You report the weather.", cassette.Interactions[0].Request.SystemInstructions)
+	assert.Equal(t, []string{"weather"}, cassette.Interactions[0].Request.Tools)
+
+	replay := agentstesting.NewReplayModel(cassette, agentstesting.ReplayStrict)
+	agent := agents.New("test").
+		WithInstructions("You report the weather.").
+		WithModelInstance(replay).
+		WithTools(agentstesting.GetFunctionTool("weather", "sunny"))
+
+	result, err := agents.Run(t.Context(), agent, "Weather in Rome?")
+	require.NoError(t, err)
+	assert.Equal(t, "It is sunny in Rome.", result.FinalOutput)
+	assert.Len(t, result.NewItems, 3)
+	assert.Zero(t, replay.Remaining())
+
+	// Non-streamed recordings can be served to streamed runs.
+	replay = agentstesting.NewReplayModel(cassette, agentstesting.ReplayStrict)
+	agent = agent.WithModelInstance(replay)
+	streamed, err := agents.RunStreamed(t.Context(), agent, "Weather in Rome?")
+	require.NoError(t, err)
+	require.NoError(t, streamed.StreamEvents(func(agents.StreamEvent) error { return nil }))
+	assert.Equal(t, "It is sunny in Rome.", streamed.FinalOutput())
+}
+
+func TestReplayModelModes(t *testing.T) {
+	cassette := recordWeatherRun(t)
+	newAgent := func(model agents.Model) *agents.Agent {
+		return agents.New("test").
+			WithInstructions("You report the weather, briefly.").
+			WithModelInstance(model).
+			WithTools(agentstesting.GetFunctionTool("weather", "sunny"))
+	}
+
+	_, err := agents.Run(t.Context(), newAgent(agentstesting.NewReplayModel(cassette, agentstesting.ReplayStrict)), "Weather in Rome?")
+	assert.ErrorContains(t, err, "does not match recorded interaction 0")
+
+	replay := agentstesting.NewReplayModel(cassette, agentstesting.ReplayLenient)
+	result, err := agents.Run(t.Context(), newAgent(replay), "Weather in Rome?")
+	require.NoError(t, err)
+	assert.Equal(t, "It is sunny in Rome.", result.FinalOutput)
+
+	_, err = replay.GetResponse(t.Context(), agents.ModelResponseParams{Input: agents.InputString("again")})
+	assert.ErrorContains(t, err, "no more recorded interactions")
+}
+
+func TestRecordAndReplayStream(t *testing.T) {
+	model := agentstesting.NewFakeModel(&agentstesting.FakeModelTurnOutput{
+		Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("streamed")},
+	})
+	recorder := agentstesting.NewRecordingModel(model)
+	result, err := agents.RunStreamed(t.Context(), agents.New("test").WithModelInstance(recorder), "hi")
+	require.NoError(t, err)
+	require.NoError(t, result.StreamEvents(func(agents.StreamEvent) error { return nil }))
+
+	require.Len(t, recorder.Cassette.Interactions, 1)
+	interaction := recorder.Cassette.Interactions[0]
+	assert.True(t, interaction.Request.Stream)
+	assert.Len(t, interaction.Stream, 8)
+	assert.Contains(t, string(interaction.Stream[3]), `"delta":"streamed"`)
+	assert.Nil(t, interaction.Response)
+
+	// Recorded streams can be served to non-streamed runs.
+	replay := agentstesting.NewReplayModel(recorder.Cassette, agentstesting.ReplayStrict)
+	runResult, err := agents.Run(t.Context(), agents.New("test").WithModelInstance(replay), "hi")
+	require.NoError(t, err)
+	assert.Equal(t, "streamed", runResult.FinalOutput)
+}
+
+const transportTestResponse = `{"id":"resp_1","object":"response","created_at":1,"model":"gpt-4o","status":"completed",` +
+	`"output":[{"type":"message","id":"msg_1","role":"assistant","status":"completed",` +
+	`"content":[{"type":"output_text","text":"Hello from the server","annotations":[]}]}],` +
+	`"usage":{"input_tokens":3,"input_tokens_details":{"cached_tokens":0},"output_tokens":4,` +
+	`"output_tokens_details":{"reasoning_tokens":0},"total_tokens":7}}`
+
+func TestRecordAndReplayTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = fmt.Fprintf(w, "event: response.completed\ndata: {\"type\":\"response.completed\",\"sequence_number\":0,\"response\":%s}\n\n", transportTestResponse)
+	}))
+
+	newAgent := func(transport http.RoundTripper) *agents.Agent {
+		client := agents.NewOpenaiClient(
+			param.NewOpt(server.URL),
+			option.WithAPIKey("test"),
+			option.WithHTTPClient(&http.Client{Transport: transport}),
+			option.WithMaxRetries(0),
+		)
+		return agents.New("test").WithModelInstance(agents.NewOpenAIResponsesModel("gpt-4o", client))
+	}
+	runStreamed := func(agent *agents.Agent) *agents.RunResultStreaming {
+		result, err := agents.RunStreamed(t.Context(), agent, "hi")
+		require.NoError(t, err)
+		require.NoError(t, result.StreamEvents(func(agents.StreamEvent) error { return nil }))
+		return result
+	}
+
+	recorder := agentstesting.NewRecordingTransport(nil)
+	result := runStreamed(newAgent(recorder))
+	assert.Equal(t, "Hello from the server", result.FinalOutput())
+	server.Close()
+
+	require.Len(t, recorder.Cassette.HTTPInteractions, 1)
+	interaction := recorder.Cassette.HTTPInteractions[0]
+	assert.Equal(t, "POST", interaction.Method)
+	assert.Equal(t, "/responses", interaction.Path)
+	assert.Equal(t, "text/event-stream", interaction.ContentType)
+
+	// The server is gone: the replay is served by the cassette only.
+	replay := agentstesting.NewReplayTransport(recorder.Cassette, agentstesting.ReplayStrict)
+	result = runStreamed(newAgent(replay))
+	assert.Equal(t, "Hello from the server", result.FinalOutput())
+	assert.Equal(t, uint64(7), result.RawResponses()[0].Usage.TotalTokens)
+
+	// A non-streamed request has a different body.
+	replay = agentstesting.NewReplayTransport(recorder.Cassette, agentstesting.ReplayStrict)
+	_, err := agents.Run(t.Context(), newAgent(replay), "hi")
+	assert.ErrorContains(t, err, "does not match recorded HTTP interaction")
+}
@@ -18,6 +18,9 @@ import (
 	"context"
 	"iter"
 	"reflect"
+	"regexp"
+	"slices"
+	"sync"
 
 	"github.com/nlpodyssey/openai-agents-go/agents"
 	"github.com/nlpodyssey/openai-agents-go/modelsettings"
@@ -26,15 +29,30 @@ import (
 	"github.com/openai/openai-go/responses"
 )
 
+// FakeModel is a Model replying with predefined outputs. It can be used
+// concurrently: while it may be in use, read the recorded calls with
+// GetLastTurnArgs and GetCalls rather than with the fields.
 type FakeModel struct {
-	TurnOutputs    []FakeModelTurnOutput
-	LastTurnArgs   FakeModelLastTurnArgs
+	TurnOutputs  []FakeModelTurnOutput
+	LastTurnArgs FakeModelLastTurnArgs
+	// The arguments of all the calls, in order.
+	Calls          []FakeModelLastTurnArgs
 	HardcodedUsage *usage.Usage
+
+	mu sync.Mutex
 }
 
 type FakeModelTurnOutput struct {
 	Value []agents.TResponseOutputItem
 	Error error
+
+	// Optional conditions the request must satisfy. If any of them is not
+	// met, the turn fails with an error describing the mismatch.
+	Expect []RequestMatcher
+
+	// Optional alternative replies. The first branch whose conditions are
+	// all met replies in place of Value and Error. See Turn.
+	Branches []FakeModelBranch
 }
 
 type FakeModelLastTurnArgs struct {
@@ -43,8 +61,10 @@ type FakeModelLastTurnArgs struct {
 	ModelSettings      modelsettings.ModelSettings
 	Tools              []agents.Tool
 	OutputSchema       agents.AgentOutputSchemaInterface
+	Handoffs           []agents.Handoff
 	// optional
 	PreviousResponseID string
+	Streamed           bool
 }
 
 func NewFakeModel(initialOutput *FakeModelTurnOutput) *FakeModel {
@@ -56,18 +76,26 @@ func NewFakeModel(initialOutput *FakeModelTurnOutput) *FakeModel {
 }
 
 func (m *FakeModel) SetHardcodedUsage(u usage.Usage) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.HardcodedUsage = &u
 }
 
 func (m *FakeModel) SetNextOutput(output FakeModelTurnOutput) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.TurnOutputs = append(m.TurnOutputs, output)
 }
 
 func (m *FakeModel) AddMultipleTurnOutputs(outputs []FakeModelTurnOutput) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.TurnOutputs = append(m.TurnOutputs, outputs...)
 }
 
 func (m *FakeModel) GetNextOutput() FakeModelTurnOutput {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	if len(m.TurnOutputs) == 0 {
 		return FakeModelTurnOutput{}
 	}
@@ -76,23 +104,52 @@ func (m *FakeModel) GetNextOutput() FakeModelTurnOutput {
 	return v
 }
 
-func (m *FakeModel) GetResponse(_ context.Context, params agents.ModelResponseParams) (*agents.ModelResponse, error) {
+// GetLastTurnArgs returns the arguments of the last call.
+func (m *FakeModel) GetLastTurnArgs() FakeModelLastTurnArgs {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.LastTurnArgs
+}
+
+// GetCalls returns a copy of the arguments of all the calls, in order.
+func (m *FakeModel) GetCalls() []FakeModelLastTurnArgs {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return slices.Clone(m.Calls)
+}
+
+func (m *FakeModel) hardcodedUsage() *usage.Usage {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.HardcodedUsage
+}
+
+func (m *FakeModel) recordTurnArgs(params agents.ModelResponseParams, streamed bool) FakeModelLastTurnArgs {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.LastTurnArgs = FakeModelLastTurnArgs{
 		SystemInstructions: params.SystemInstructions,
 		Input:              params.Input,
 		ModelSettings:      params.ModelSettings,
 		Tools:              params.Tools,
 		OutputSchema:       params.OutputSchema,
+		Handoffs:           params.Handoffs,
 		PreviousResponseID: params.PreviousResponseID,
+		Streamed:           streamed,
 	}
+	m.Calls = append(m.Calls, m.LastTurnArgs)
+	return m.LastTurnArgs
+}
 
-	output := m.GetNextOutput()
+func (m *FakeModel) GetResponse(_ context.Context, params agents.ModelResponseParams) (*agents.ModelResponse, error) {
+	args := m.recordTurnArgs(params, false)
+	output := m.GetNextOutput().resolve(args)
 
 	if output.Error != nil {
 		return nil, output.Error
 	}
 
-	u := m.HardcodedUsage
+	u := m.hardcodedUsage()
 	if u == nil {
 		u = usage.NewUsage()
 	}
@@ -104,17 +161,13 @@ func (m *FakeModel) GetResponse(_ context.Context, params agents.ModelResponsePa
 	}, nil
 }
 
+// StreamResponse streams the next output like the OpenAI Responses API: each
+// output item is announced, then text and function call arguments are
+// streamed as deltas, a word at a time, before the final response.
 func (m *FakeModel) StreamResponse(_ context.Context, params agents.ModelResponseParams) (iter.Seq2[*agents.TResponseStreamEvent, error], error) {
-	m.LastTurnArgs = FakeModelLastTurnArgs{
-		SystemInstructions: params.SystemInstructions,
-		Input:              params.Input,
-		ModelSettings:      params.ModelSettings,
-		Tools:              params.Tools,
-		OutputSchema:       params.OutputSchema,
-		PreviousResponseID: params.PreviousResponseID,
-	}
-
-	output := m.GetNextOutput()
+	args := m.recordTurnArgs(params, true)
+	output := m.GetNextOutput().resolve(args)
+	u := m.hardcodedUsage()
 
 	return func(yield func(*agents.TResponseStreamEvent, error) bool) {
 		if output.Error != nil {
@@ -122,18 +175,152 @@ func (m *FakeModel) StreamResponse(_ context.Context, params agents.ModelRespons
 			return
 		}
 
-		u := m.HardcodedUsage
-		if u == nil {
-			u = usage.NewUsage()
+		var sequenceNumber int64
+		emit := func(event agents.TResponseStreamEvent) bool {
+			event.SequenceNumber = sequenceNumber
+			sequenceNumber++
+			return yield(&event, nil)
 		}
 
-		yield(&agents.TResponseStreamEvent{ // responses.ResponseCompletedEvent
-			Response: GetResponseObj(output.Value, "", m.HardcodedUsage),
+		if !emit(agents.TResponseStreamEvent{ // responses.ResponseCreatedEvent
+			Response: GetResponseObj(nil, "", nil),
+			Type:     "response.created",
+		}) {
+			return
+		}
+		for i, item := range output.Value {
+			if !streamOutputItem(emit, int64(i), item) {
+				return
+			}
+		}
+		emit(agents.TResponseStreamEvent{ // responses.ResponseCompletedEvent
+			Response: GetResponseObj(output.Value, "", u),
 			Type:     "response.completed",
-		}, nil)
+		})
 	}, nil
 }
 
+var streamTokenRegexp = regexp.MustCompile(`\s*\S+\s*|\s+`)
+
+// streamTokens splits text into word-sized chunks, as streamed by a model.
+func streamTokens(text string) []string {
+	return streamTokenRegexp.FindAllString(text, -1)
+}
+
+// streamOutputItem emits the events streaming a single output item.
+func streamOutputItem(emit func(agents.TResponseStreamEvent) bool, outputIndex int64, item agents.TResponseOutputItem) bool {
+	added := item
+	switch item.Type {
+	case "message":
+		added.Content = nil
+		added.Status = string(responses.ResponseOutputMessageStatusInProgress)
+	case "function_call":
+		added.Arguments = ""
+		added.Status = string(responses.ResponseFunctionToolCallStatusInProgress)
+	}
+	if !emit(agents.TResponseStreamEvent{ // responses.ResponseOutputItemAddedEvent
+		Item:        added,
+		OutputIndex: outputIndex,
+		Type:        "response.output_item.added",
+	}) {
+		return false
+	}
+
+	switch item.Type {
+	case "message":
+		for contentIndex, content := range item.Content {
+			if !streamContentPart(emit, outputIndex, int64(contentIndex), item.ID, content) {
+				return false
+			}
+		}
+	case "function_call":
+		for _, token := range streamTokens(item.Arguments) {
+			if !emit(agents.TResponseStreamEvent{ // responses.ResponseFunctionCallArgumentsDeltaEvent
+				Delta:       responses.ResponseStreamEventUnionDelta{OfString: token},
+				ItemID:      item.ID,
+				OutputIndex: outputIndex,
+				Type:        "response.function_call_arguments.delta",
+			}) {
+				return false
+			}
+		}
+		if !emit(agents.TResponseStreamEvent{ // responses.ResponseFunctionCallArgumentsDoneEvent
+			Arguments:   responses.ResponseStreamEventUnionArguments{OfString: item.Arguments},
+			ItemID:      item.ID,
+			OutputIndex: outputIndex,
+			Type:        "response.function_call_arguments.done",
+		}) {
+			return false
+		}
+	}
+
+	return emit(agents.TResponseStreamEvent{ // responses.ResponseOutputItemDoneEvent
+		Item:        item,
+		OutputIndex: outputIndex,
+		Type:        "response.output_item.done",
+	})
+}
+
+// streamContentPart emits the events streaming a content part of a message.
+func streamContentPart(
+	emit func(agents.TResponseStreamEvent) bool,
+	outputIndex, contentIndex int64,
+	itemID string,
+	content responses.ResponseOutputMessageContentUnion,
+) bool {
+	text, deltaType, doneType := content.Text, "response.output_text.delta", "response.output_text.done"
+	if content.Type == "refusal" {
+		text, deltaType, doneType = content.Refusal, "response.refusal.delta", "response.refusal.done"
+	}
+
+	if !emit(agents.TResponseStreamEvent{ // responses.ResponseContentPartAddedEvent
+		ContentIndex: contentIndex,
+		ItemID:       itemID,
+		OutputIndex:  outputIndex,
+		Part:         responses.ResponseStreamEventUnionPart{Type: content.Type},
+		Type:         "response.content_part.added",
+	}) {
+		return false
+	}
+	for _, token := range streamTokens(text) {
+		if !emit(agents.TResponseStreamEvent{ // responses.ResponseTextDeltaEvent or responses.ResponseRefusalDeltaEvent
+			ContentIndex: contentIndex,
+			Delta:        responses.ResponseStreamEventUnionDelta{OfString: token},
+			ItemID:       itemID,
+			OutputIndex:  outputIndex,
+			Type:         deltaType,
+		}) {
+			return false
+		}
+	}
+	done := agents.TResponseStreamEvent{ // responses.ResponseTextDoneEvent or responses.ResponseRefusalDoneEvent
+		ContentIndex: contentIndex,
+		ItemID:       itemID,
+		OutputIndex:  outputIndex,
+		Type:         doneType,
+	}
+	if content.Type == "refusal" {
+		done.Refusal = text
+	} else {
+		done.Text = text
+	}
+	if !emit(done) {
+		return false
+	}
+	return emit(agents.TResponseStreamEvent{ // responses.ResponseContentPartDoneEvent
+		ContentIndex: contentIndex,
+		ItemID:       itemID,
+		OutputIndex:  outputIndex,
+		Part: responses.ResponseStreamEventUnionPart{
+			Annotations: content.Annotations,
+			Text:        content.Text,
+			Type:        content.Type,
+			Refusal:     content.Refusal,
+		},
+		Type: "response.content_part.done",
+	})
+}
+
 func GetResponseObj(
 	output []agents.TResponseOutputItem,
 	responseID string,
@@ -0,0 +1,348 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agentstesting
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"reflect"
+	"sync"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/usage"
+	"github.com/openai/openai-go/responses"
+)
+
+// RecordingModel wraps a Model, recording every call into a Cassette.
+// Streamed calls are recorded once the stream has been fully consumed.
+type RecordingModel struct {
+	Model    agents.Model
+	Cassette *Cassette
+	mu       sync.Mutex
+}
+
+// NewRecordingModel returns a RecordingModel wrapping the given model, with an
+// empty cassette.
+func NewRecordingModel(model agents.Model) *RecordingModel {
+	return &RecordingModel{Model: model, Cassette: new(Cassette)}
+}
+
+func (m *RecordingModel) GetResponse(ctx context.Context, params agents.ModelResponseParams) (*agents.ModelResponse, error) {
+	request, err := NewCassetteRequest(params, false)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := m.Model.GetResponse(ctx, params)
+	if err != nil {
+		m.record(Interaction{Request: request, Error: err.Error()})
+		return nil, err
+	}
+
+	recorded, err := newCassetteResponse(response)
+	if err != nil {
+		return nil, err
+	}
+	m.record(Interaction{Request: request, Response: recorded})
+	return response, nil
+}
+
+func (m *RecordingModel) StreamResponse(ctx context.Context, params agents.ModelResponseParams) (iter.Seq2[*agents.TResponseStreamEvent, error], error) {
+	request, err := NewCassetteRequest(params, true)
+	if err != nil {
+		return nil, err
+	}
+
+	stream, err := m.Model.StreamResponse(ctx, params)
+	if err != nil {
+		m.record(Interaction{Request: request, Error: err.Error()})
+		return nil, err
+	}
+
+	return func(yield func(*agents.TResponseStreamEvent, error) bool) {
+		interaction := Interaction{Request: request}
+		defer func() { m.record(interaction) }()
+
+		for event, err := range stream {
+			if err != nil {
+				interaction.Error = err.Error()
+				yield(nil, err)
+				return
+			}
+			raw, err := rawJSON(event)
+			if err != nil {
+				yield(nil, fmt.Errorf("failed to record stream event: %w", err))
+				return
+			}
+			interaction.Stream = append(interaction.Stream, raw)
+			if !yield(event, nil) {
+				return
+			}
+		}
+	}, nil
+}
+
+func (m *RecordingModel) record(interaction Interaction) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.Cassette.Interactions = append(m.Cassette.Interactions, interaction)
+}
+
+// ReplayMode controls how replayed calls are matched with recorded ones.
+type ReplayMode uint8
+
+const (
+	// ReplayStrict serves the interactions in the recorded order, failing
+	// if a call does not match the next recorded request.
+	ReplayStrict ReplayMode = iota
+	// ReplayLenient serves the first unused interaction matching the call,
+	// falling back to the next unused one when none matches. It tolerates
+	// changes to prompts, tools or settings which do not alter the flow.
+	ReplayLenient
+)
+
+// ReplayModel is a Model serving the interactions of a Cassette. A recorded
+// stream can be served to a non-streamed call and vice versa.
+type ReplayModel struct {
+	Cassette *Cassette
+	Mode     ReplayMode
+	mu       sync.Mutex
+	used     []bool
+}
+
+// NewReplayModel returns a ReplayModel serving the given cassette.
+func NewReplayModel(cassette *Cassette, mode ReplayMode) *ReplayModel {
+	return &ReplayModel{Cassette: cassette, Mode: mode}
+}
+
+// LoadReplayModel returns a ReplayModel serving the cassette at the given path.
+func LoadReplayModel(path string, mode ReplayMode) (*ReplayModel, error) {
+	cassette, err := LoadCassette(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewReplayModel(cassette, mode), nil
+}
+
+// Remaining returns the number of interactions which have not been served.
+func (m *ReplayModel) Remaining() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	n := 0
+	for i := range m.Cassette.Interactions {
+		if i >= len(m.used) || !m.used[i] {
+			n++
+		}
+	}
+	return n
+}
+
+func (m *ReplayModel) GetResponse(_ context.Context, params agents.ModelResponseParams) (*agents.ModelResponse, error) {
+	interaction, err := m.next(params, false)
+	if err != nil {
+		return nil, err
+	}
+	if err = interactionError(interaction); err != nil {
+		return nil, err
+	}
+
+	if interaction.Response != nil {
+		return interaction.Response.modelResponse()
+	}
+	for _, raw := range interaction.Stream {
+		var event agents.TResponseStreamEvent
+		if err = json.Unmarshal(raw, &event); err != nil {
+			return nil, fmt.Errorf("failed to decode recorded stream event: %w", err)
+		}
+		if event.Type == "response.completed" {
+			return modelResponseFromResponse(event.Response), nil
+		}
+	}
+	return nil, errors.New("recorded stream has no response.completed event")
+}
+
+func (m *ReplayModel) StreamResponse(_ context.Context, params agents.ModelResponseParams) (iter.Seq2[*agents.TResponseStreamEvent, error], error) {
+	interaction, err := m.next(params, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(yield func(*agents.TResponseStreamEvent, error) bool) {
+		if interaction.Response != nil {
+			response, err := interaction.Response.response()
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			yield(&agents.TResponseStreamEvent{ // responses.ResponseCompletedEvent
+				Response: response,
+				Type:     "response.completed",
+			}, nil)
+			return
+		}
+
+		for _, raw := range interaction.Stream {
+			event := new(agents.TResponseStreamEvent)
+			if err := json.Unmarshal(raw, event); err != nil {
+				yield(nil, fmt.Errorf("failed to decode recorded stream event: %w", err))
+				return
+			}
+			if !yield(event, nil) {
+				return
+			}
+		}
+		if err := interactionError(interaction); err != nil {
+			yield(nil, err)
+		}
+	}, nil
+}
+
+// next marks as used and returns the interaction serving the call.
+func (m *ReplayModel) next(params agents.ModelResponseParams, stream bool) (Interaction, error) {
+	request, err := NewCassetteRequest(params, stream)
+	if err != nil {
+		return Interaction{}, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	interactions := m.Cassette.Interactions
+	if len(m.used) < len(interactions) {
+		m.used = append(m.used, make([]bool, len(interactions)-len(m.used))...)
+	}
+
+	index := -1
+	for i, interaction := range interactions {
+		if m.used[i] {
+			continue
+		}
+		if m.Mode == ReplayStrict {
+			if !interaction.Request.Matches(request) {
+				return Interaction{}, newReplayMismatchError(i, interaction.Request, request)
+			}
+			index = i
+			break
+		}
+		if index == -1 {
+			index = i
+		}
+		if interaction.Request.Matches(request) {
+			index = i
+			break
+		}
+	}
+	if index == -1 {
+		return Interaction{}, errors.New("cassette has no more recorded interactions")
+	}
+
+	m.used[index] = true
+	return interactions[index], nil
+}
+
+func newReplayMismatchError(index int, recorded, actual CassetteRequest) error {
+	want, _ := recorded.key()
+	got, _ := actual.key()
+	return fmt.Errorf("request does not match recorded interaction %d:\n  recorded: %s\n  actual:   %s", index, want, got)
+}
+
+func interactionError(interaction Interaction) error {
+	if interaction.Error == "" {
+		return nil
+	}
+	return errors.New(interaction.Error)
+}
+
+func newCassetteResponse(response *agents.ModelResponse) (*CassetteResponse, error) {
+	r := &CassetteResponse{
+		ID:     response.ResponseID,
+		Output: make([]json.RawMessage, len(response.Output)),
+	}
+	for i, item := range response.Output {
+		raw, err := rawJSON(item)
+		if err != nil {
+			return nil, fmt.Errorf("failed to record output item: %w", err)
+		}
+		r.Output[i] = raw
+	}
+	if u := response.Usage; u != nil {
+		r.Usage = responses.ResponseUsage{
+			InputTokens:         int64(u.InputTokens),
+			InputTokensDetails:  u.InputTokensDetails,
+			OutputTokens:        int64(u.OutputTokens),
+			OutputTokensDetails: u.OutputTokensDetails,
+			TotalTokens:         int64(u.TotalTokens),
+		}
+	}
+	return r, nil
+}
+
+// response returns the recorded response in the Responses API format.
+func (r CassetteResponse) response() (responses.Response, error) {
+	raw, err := json.Marshal(map[string]any{
+		"id":     r.ID,
+		"object": "response",
+		"output": r.Output,
+		"usage":  r.Usage,
+	})
+	if err != nil {
+		return responses.Response{}, err
+	}
+	var response responses.Response
+	if err = json.Unmarshal(raw, &response); err != nil {
+		return responses.Response{}, fmt.Errorf("failed to decode recorded response: %w", err)
+	}
+	return response, nil
+}
+
+func (r CassetteResponse) modelResponse() (*agents.ModelResponse, error) {
+	response, err := r.response()
+	if err != nil {
+		return nil, err
+	}
+	return modelResponseFromResponse(response), nil
+}
+
+// modelResponseFromResponse converts a response in the same way as
+// agents.OpenAIResponsesModel.
+func modelResponseFromResponse(response responses.Response) *agents.ModelResponse {
+	u := usage.NewUsage()
+	if !reflect.ValueOf(response.Usage).IsZero() {
+		*u = usage.Usage{
+			Requests:            1,
+			InputTokens:         uint64(response.Usage.InputTokens),
+			InputTokensDetails:  response.Usage.InputTokensDetails,
+			OutputTokens:        uint64(response.Usage.OutputTokens),
+			OutputTokensDetails: response.Usage.OutputTokensDetails,
+			TotalTokens:         uint64(response.Usage.TotalTokens),
+		}
+	}
+	return &agents.ModelResponse{
+		Output:     response.Output,
+		Usage:      u,
+		ResponseID: response.ID,
+	}
+}
+
+// rawJSON returns the JSON a value was decoded from, or encodes it without
+// zero fields if it was built in Go.
+func rawJSON(v interface{ RawJSON() string }) (json.RawMessage, error) {
+	if raw := v.RawJSON(); raw != "" {
+		return json.RawMessage(raw), nil
+	}
+	return encodePrunedJSON(v, true)
+}
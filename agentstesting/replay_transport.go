@@ -0,0 +1,215 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agentstesting
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// An HTTPInteraction is a single recorded HTTP exchange. Streamed (SSE)
+// responses are recorded in full, as their raw body.
+type HTTPInteraction struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+
+	// The request body, normalized like a CassetteRequest if it is JSON,
+	// or else encoded as a JSON string.
+	RequestBody json.RawMessage `json:"request_body,omitempty"`
+
+	StatusCode   int    `json:"status_code"`
+	ContentType  string `json:"content_type,omitempty"`
+	ResponseBody string `json:"response_body"`
+}
+
+// RecordingTransport is an http.RoundTripper recording every exchange into a
+// Cassette. Use it with an OpenAI client, to record at the HTTP level:
+//
+//	transport := agentstesting.NewRecordingTransport(nil)
+//	client := agents.NewOpenaiClient(param.Opt[string]{}, option.WithHTTPClient(&http.Client{Transport: transport}))
+type RecordingTransport struct {
+	// The transport performing the requests. Default: http.DefaultTransport.
+	Transport http.RoundTripper
+	Cassette  *Cassette
+	mu        sync.Mutex
+}
+
+// NewRecordingTransport returns a RecordingTransport wrapping the given
+// transport, with an empty cassette.
+func NewRecordingTransport(transport http.RoundTripper) *RecordingTransport {
+	return &RecordingTransport{Transport: transport, Cassette: new(Cassette)}
+}
+
+func (t *RecordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	requestBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	transport := t.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	responseBody, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(responseBody))
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.Cassette.HTTPInteractions = append(t.Cassette.HTTPInteractions, HTTPInteraction{
+		Method:       req.Method,
+		Path:         req.URL.Path,
+		RequestBody:  requestBody,
+		StatusCode:   resp.StatusCode,
+		ContentType:  resp.Header.Get("Content-Type"),
+		ResponseBody: string(responseBody),
+	})
+	return resp, nil
+}
+
+// ReplayTransport is an http.RoundTripper serving the HTTP interactions of a
+// Cassette, without network access. Requests are matched by method, path and
+// normalized body, according to the mode.
+type ReplayTransport struct {
+	Cassette *Cassette
+	Mode     ReplayMode
+	mu       sync.Mutex
+	used     []bool
+}
+
+// NewReplayTransport returns a ReplayTransport serving the given cassette.
+func NewReplayTransport(cassette *Cassette, mode ReplayMode) *ReplayTransport {
+	return &ReplayTransport{Cassette: cassette, Mode: mode}
+}
+
+func (t *ReplayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	requestBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	interactions := t.Cassette.HTTPInteractions
+	if len(t.used) < len(interactions) {
+		t.used = append(t.used, make([]bool, len(interactions)-len(t.used))...)
+	}
+
+	index := -1
+	for i, interaction := range interactions {
+		if t.used[i] {
+			continue
+		}
+		sameEndpoint := interaction.Method == req.Method && interaction.Path == req.URL.Path
+		sameBody := sameEndpoint && jsonEqual(interaction.RequestBody, requestBody)
+		if t.Mode == ReplayStrict {
+			if !sameBody {
+				return nil, fmt.Errorf(
+					"request does not match recorded HTTP interaction %d:\n  recorded: %s %s %s\n  actual:   %s %s %s",
+					i, interaction.Method, interaction.Path, interaction.RequestBody,
+					req.Method, req.URL.Path, requestBody,
+				)
+			}
+			index = i
+			break
+		}
+		if sameEndpoint && index == -1 {
+			index = i
+		}
+		if sameBody {
+			index = i
+			break
+		}
+	}
+	if index == -1 {
+		return nil, fmt.Errorf("cassette has no more recorded HTTP interactions for %s %s", req.Method, req.URL.Path)
+	}
+	t.used[index] = true
+
+	interaction := interactions[index]
+	header := make(http.Header)
+	if interaction.ContentType != "" {
+		header.Set("Content-Type", interaction.ContentType)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", interaction.StatusCode, http.StatusText(interaction.StatusCode)),
+		StatusCode:    interaction.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(interaction.ResponseBody)),
+		ContentLength: int64(len(interaction.ResponseBody)),
+		Request:       req,
+	}, nil
+}
+
+// readRequestBody reads and restores the body of the request, returning it
+// in normalized form.
+func readRequestBody(req *http.Request) (json.RawMessage, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	body, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	if len(body) == 0 {
+		return nil, nil
+	}
+	if !json.Valid(body) {
+		return json.Marshal(string(body))
+	}
+
+	var decoded any
+	if err = json.Unmarshal(body, &decoded); err != nil {
+		return nil, err
+	}
+	if m, ok := decoded.(map[string]any); ok {
+		if items, ok := m["input"].([]any); ok {
+			for _, item := range items {
+				if itemMap, ok := item.(map[string]any); ok {
+					delete(itemMap, "id")
+				}
+			}
+		}
+	}
+	return normalizeJSON(decoded)
+}
+
+func jsonEqual(a, b json.RawMessage) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return len(a) == len(b)
+	}
+	na, errA := normalizeJSON(a)
+	nb, errB := normalizeJSON(b)
+	return errors.Join(errA, errB) == nil && bytes.Equal(na, nb)
+}
@@ -0,0 +1,250 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agentstesting
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// A RequestMatcher checks a request received by a FakeModel, returning an
+// error describing the mismatch if the request does not satisfy it.
+type RequestMatcher func(FakeModelLastTurnArgs) error
+
+// FakeModelBranch is an alternative reply of a scripted turn.
+type FakeModelBranch struct {
+	When  []RequestMatcher
+	Value []agents.TResponseOutputItem
+	Error error
+}
+
+// NewScriptedFakeModel returns a FakeModel replying with the given turns, in
+// order. Turns are usually built with Turn.
+func NewScriptedFakeModel(turns ...FakeModelTurnOutput) *FakeModel {
+	return &FakeModel{TurnOutputs: turns}
+}
+
+// FakeTurnBuilder builds a scripted FakeModelTurnOutput.
+type FakeTurnBuilder struct {
+	turn FakeModelTurnOutput
+}
+
+// Turn starts building a scripted turn, for example:
+//
+//	agentstesting.Turn().
+//		Expect(agentstesting.HasTool("weather")).
+//		When(agentstesting.InputContains("Rome"), agentstesting.GetFunctionToolCall("weather", `{"city":"Rome"}`)).
+//		Reply(agentstesting.GetTextMessage("Which city?"))
+//
+// If the turn has branches and none of them matches, an empty Reply makes the
+// turn fail with an error describing why each branch did not match.
+func Turn() *FakeTurnBuilder {
+	return new(FakeTurnBuilder)
+}
+
+// Expect adds conditions the request must satisfy.
+func (b *FakeTurnBuilder) Expect(matchers ...RequestMatcher) *FakeTurnBuilder {
+	b.turn.Expect = append(b.turn.Expect, matchers...)
+	return b
+}
+
+// When adds a branch replying with the given items if the request matches.
+func (b *FakeTurnBuilder) When(matcher RequestMatcher, items ...agents.TResponseOutputItem) *FakeTurnBuilder {
+	b.turn.Branches = append(b.turn.Branches, FakeModelBranch{When: []RequestMatcher{matcher}, Value: items})
+	return b
+}
+
+// WhenFail adds a branch failing with the given error if the request matches.
+func (b *FakeTurnBuilder) WhenFail(matcher RequestMatcher, err error) *FakeTurnBuilder {
+	b.turn.Branches = append(b.turn.Branches, FakeModelBranch{When: []RequestMatcher{matcher}, Error: err})
+	return b
+}
+
+// Reply completes the turn, replying with the given items when no branch matches.
+func (b *FakeTurnBuilder) Reply(items ...agents.TResponseOutputItem) FakeModelTurnOutput {
+	turn := b.turn
+	turn.Value = items
+	return turn
+}
+
+// Fail completes the turn, failing with the given error when no branch matches.
+func (b *FakeTurnBuilder) Fail(err error) FakeModelTurnOutput {
+	turn := b.turn
+	turn.Error = err
+	return turn
+}
+
+// resolve checks the expectations of the turn against the request, and
+// selects the reply.
+func (o FakeModelTurnOutput) resolve(args FakeModelLastTurnArgs) FakeModelTurnOutput {
+	if err := matchAll(o.Expect, args); err != nil {
+		return FakeModelTurnOutput{Error: fmt.Errorf("unexpected model request: %w", err)}
+	}
+	if len(o.Branches) == 0 {
+		return o
+	}
+
+	var errs []error
+	for i, branch := range o.Branches {
+		err := matchAll(branch.When, args)
+		if err == nil {
+			return FakeModelTurnOutput{Value: branch.Value, Error: branch.Error}
+		}
+		errs = append(errs, fmt.Errorf("branch %d: %w", i, err))
+	}
+	if o.Value == nil && o.Error == nil {
+		return FakeModelTurnOutput{Error: fmt.Errorf("no branch matches the model request:\n%w", errors.Join(errs...))}
+	}
+	return FakeModelTurnOutput{Value: o.Value, Error: o.Error}
+}
+
+func matchAll(matchers []RequestMatcher, args FakeModelLastTurnArgs) error {
+	var errs []error
+	for _, match := range matchers {
+		if err := match(args); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// HasTool matches requests offering a tool with the given name.
+func HasTool(name string) RequestMatcher {
+	return func(args FakeModelLastTurnArgs) error {
+		names := make([]string, len(args.Tools))
+		for i, tool := range args.Tools {
+			names[i] = tool.ToolName()
+		}
+		if !slices.Contains(names, name) {
+			return fmt.Errorf("expected tool %q, got tools %q", name, names)
+		}
+		return nil
+	}
+}
+
+// HasHandoff matches requests offering a handoff with the given tool name.
+func HasHandoff(toolName string) RequestMatcher {
+	return func(args FakeModelLastTurnArgs) error {
+		names := make([]string, len(args.Handoffs))
+		for i, handoff := range args.Handoffs {
+			names[i] = handoff.ToolName
+		}
+		if !slices.Contains(names, toolName) {
+			return fmt.Errorf("expected handoff %q, got handoffs %q", toolName, names)
+		}
+		return nil
+	}
+}
+
+// HasOutputSchema matches requests with an output schema of the given name.
+func HasOutputSchema(name string) RequestMatcher {
+	return func(args FakeModelLastTurnArgs) error {
+		got := "<plain text>"
+		if args.OutputSchema != nil && !args.OutputSchema.IsPlainText() {
+			got = args.OutputSchema.Name()
+		}
+		if got != name {
+			return fmt.Errorf("expected output schema %q, got %q", name, got)
+		}
+		return nil
+	}
+}
+
+// InstructionsContain matches requests whose system instructions contain
+// the given text.
+func InstructionsContain(text string) RequestMatcher {
+	return func(args FakeModelLastTurnArgs) error {
+		if !strings.Contains(args.SystemInstructions.Value, text) {
+			return fmt.Errorf("expected instructions containing %q, got %q", text, args.SystemInstructions.Value)
+		}
+		return nil
+	}
+}
+
+// InputContains matches requests with an input item containing the given
+// text, in a message or in a tool call or output.
+func InputContains(text string) RequestMatcher {
+	return func(args FakeModelLastTurnArgs) error {
+		input, err := normalizeInput(agents.ItemHelpers().InputToNewInputList(args.Input))
+		if err != nil {
+			return err
+		}
+		var decoded any
+		if err = json.Unmarshal(input, &decoded); err != nil {
+			return err
+		}
+		if !jsonStringsContain(decoded, text) {
+			return fmt.Errorf("expected input containing %q, got:\n%s", text, indentJSON(input))
+		}
+		return nil
+	}
+}
+
+// InputEquals matches requests with exactly the given input items, ignoring
+// their IDs. On mismatch, the error shows a diff of the inputs.
+func InputEquals(items ...agents.TResponseInputItem) RequestMatcher {
+	return func(args FakeModelLastTurnArgs) error {
+		want, err := normalizeInput(items)
+		if err != nil {
+			return err
+		}
+		got, err := normalizeInput(agents.ItemHelpers().InputToNewInputList(args.Input))
+		if err != nil {
+			return err
+		}
+		if bytes.Equal(want, got) {
+			return nil
+		}
+		diff, _ := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        difflib.SplitLines(indentJSON(want)),
+			B:        difflib.SplitLines(indentJSON(got)),
+			FromFile: "expected input",
+			ToFile:   "actual input",
+			Context:  2,
+		})
+		return fmt.Errorf("input mismatch:\n%s", diff)
+	}
+}
+
+func jsonStringsContain(v any, text string) bool {
+	switch v := v.(type) {
+	case string:
+		return strings.Contains(v, text)
+	case []any:
+		return slices.ContainsFunc(v, func(item any) bool { return jsonStringsContain(item, text) })
+	case map[string]any:
+		for _, item := range v {
+			if jsonStringsContain(item, text) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func indentJSON(raw json.RawMessage) string {
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, raw, "", "  "); err != nil {
+		return string(raw)
+	}
+	return buf.String() + "\n"
+}
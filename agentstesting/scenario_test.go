@@ -0,0 +1,199 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agentstesting_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/agentstesting"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func weatherScript() *agentstesting.FakeModel {
+	return agentstesting.NewScriptedFakeModel(
+		agentstesting.Turn().
+			Expect(agentstesting.HasTool("weather"), agentstesting.InstructionsContain("weather")).
+			When(agentstesting.InputContains("Rome"), agentstesting.GetFunctionToolCall("weather", `{"city": "Rome"}`)).
+			Reply(agentstesting.GetTextMessage("Which city?")),
+		agentstesting.Turn().
+			Expect(agentstesting.InputContains("sunny")).
+			Reply(agentstesting.GetTextMessage("It is sunny in Rome.")),
+	)
+}
+
+func weatherAgent(model agents.Model) *agents.Agent {
+	return agents.New("test").
+		WithInstructions("You report the weather.").
+		WithModelInstance(model).
+		WithTools(agentstesting.GetFunctionTool("weather", "sunny"))
+}
+
+func TestScriptedFakeModel(t *testing.T) {
+	model := weatherScript()
+	result, err := agents.Run(t.Context(), weatherAgent(model), "Weather in Rome?")
+	require.NoError(t, err)
+	assert.Equal(t, "It is sunny in Rome.", result.FinalOutput)
+
+	calls := model.GetCalls()
+	require.Len(t, calls, 2)
+	assert.Len(t, calls[0].Input, 1)
+	assert.Len(t, calls[1].Input, 3)
+	assert.False(t, calls[0].Streamed)
+	assert.Equal(t, calls[1], model.GetLastTurnArgs())
+
+	// The default reply is used when no branch matches.
+	model = weatherScript()
+	result, err = agents.Run(t.Context(), weatherAgent(model), "Weather?")
+	require.NoError(t, err)
+	assert.Equal(t, "Which city?", result.FinalOutput)
+}
+
+func TestScriptedFakeModelMismatch(t *testing.T) {
+	agent := weatherAgent(weatherScript()).WithTools()
+	_, err := agents.Run(t.Context(), agent, "Weather in Rome?")
+	assert.ErrorContains(t, err, `expected tool "weather", got tools []`)
+
+	model := agentstesting.NewScriptedFakeModel(
+		agentstesting.Turn().
+			When(agentstesting.HasHandoff("transfer_to_billing"), agentstesting.GetTextMessage("billing")).
+			WhenFail(agentstesting.HasOutputSchema("invoice"), errors.New("unreachable")).
+			Reply(),
+	)
+	_, err = agents.Run(t.Context(), agents.New("test").WithModelInstance(model), "hi")
+	assert.ErrorContains(t, err, "no branch matches the model request")
+	assert.ErrorContains(t, err, `branch 0: expected handoff "transfer_to_billing", got handoffs []`)
+	assert.ErrorContains(t, err, `branch 1: expected output schema "invoice", got "<plain text>"`)
+}
+
+func TestInputEquals(t *testing.T) {
+	input := agents.ItemHelpers().InputToNewInputList(agents.InputString("hello"))
+	model := agentstesting.NewScriptedFakeModel(
+		agentstesting.Turn().
+			Expect(agentstesting.InputEquals(input...)).
+			Reply(agentstesting.GetTextMessage("hi")),
+	)
+	result, err := agents.Run(t.Context(), agents.New("test").WithModelInstance(model), "hello")
+	require.NoError(t, err)
+	assert.Equal(t, "hi", result.FinalOutput)
+
+	model = agentstesting.NewScriptedFakeModel(
+		agentstesting.Turn().
+			Expect(agentstesting.InputEquals(input...)).
+			Reply(agentstesting.GetTextMessage("hi")),
+	)
+	_, err = agents.Run(t.Context(), agents.New("test").WithModelInstance(model), "goodbye")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--- expected input\n+++ actual input\n")
+	assert.Contains(t, err.Error(), `-    "content": "hello",`)
+	assert.Contains(t, err.Error(), `+    "content": "goodbye",`)
+}
+
+func TestFakeModelStreamsDeltas(t *testing.T) {
+	model := agentstesting.NewFakeModel(nil)
+	model.AddMultipleTurnOutputs([]agentstesting.FakeModelTurnOutput{
+		{Value: []agents.TResponseOutputItem{
+			agentstesting.GetTextMessage("Let me check."),
+			agentstesting.GetFunctionToolCall("weather", `{"city": "Rome"}`),
+		}},
+		{Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("It is sunny.")}},
+	})
+
+	result, err := agents.RunStreamed(t.Context(), weatherAgent(model), "Weather in Rome?")
+	require.NoError(t, err)
+
+	var (
+		types          []string
+		textDeltas     []string
+		argumentDeltas []string
+		sequence       []int64
+	)
+	err = result.StreamEvents(func(event agents.StreamEvent) error {
+		e, ok := event.(agents.RawResponsesStreamEvent)
+		if !ok {
+			return nil
+		}
+		sequence = append(sequence, e.Data.SequenceNumber)
+		if len(types) < 13 {
+			types = append(types, e.Data.Type)
+		}
+		switch e.Data.Type {
+		case "response.output_text.delta":
+			textDeltas = append(textDeltas, e.Data.Delta.OfString)
+		case "response.function_call_arguments.delta":
+			argumentDeltas = append(argumentDeltas, e.Data.Delta.OfString)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "It is sunny.", result.FinalOutput())
+	assert.True(t, model.LastTurnArgs.Streamed)
+
+	assert.Equal(t, []string{
+		"response.created",
+		"response.output_item.added",
+		"response.content_part.added",
+		"response.output_text.delta",
+		"response.output_text.delta",
+		"response.output_text.delta",
+		"response.output_text.done",
+		"response.content_part.done",
+		"response.output_item.done",
+		"response.output_item.added",
+		"response.function_call_arguments.delta",
+		"response.function_call_arguments.delta",
+		"response.function_call_arguments.done",
+	}, types)
+	assert.Equal(t, []string{"Let ", "me ", "check.", "It ", "is ", "sunny."}, textDeltas)
+	assert.Equal(t, []string{`{"city": `, `"Rome"}`}, argumentDeltas)
+	assert.Equal(t, int64(0), sequence[0])
+	assert.Equal(t, int64(1), sequence[1])
+}
+
+func TestItemBuilders(t *testing.T) {
+	reasoning := agentstesting.GetReasoningItem("thinking")
+	assert.Equal(t, "reasoning", reasoning.Type)
+	assert.Equal(t, "thinking", reasoning.Summary[0].Text)
+
+	shell := agentstesting.GetLocalShellCall("ls", "-l")
+	assert.Equal(t, "local_shell_call", shell.Type)
+	assert.Equal(t, []string{"ls", "-l"}, shell.Action.Command)
+
+	model := agentstesting.NewFakeModel(&agentstesting.FakeModelTurnOutput{
+		Value: []agents.TResponseOutputItem{reasoning, agentstesting.GetTextMessage("done")},
+	})
+	result, err := agents.Run(t.Context(), agents.New("test").WithModelInstance(model), "hi")
+	require.NoError(t, err)
+	require.Len(t, result.NewItems, 2)
+	assert.IsType(t, agents.ReasoningItem{}, result.NewItems[0])
+}
+
+func TestFakeModelConcurrentCalls(t *testing.T) {
+	model := agentstesting.NewFakeModel(nil)
+	for range 8 {
+		model.SetNextOutput(agentstesting.FakeModelTurnOutput{
+			Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("hi")},
+		})
+	}
+	agent := agents.New("test").WithModelInstance(model)
+
+	result, err := agents.RunParallel(t.Context(), agents.RepeatAgent(agent, 8), "hello", agents.MergeOutputs(nil))
+	require.NoError(t, err)
+	assert.Len(t, result.Outcomes, 8)
+	assert.Len(t, model.GetCalls(), 8)
+	assert.Empty(t, model.TurnOutputs)
+}
@@ -0,0 +1,413 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agentstesting
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+)
+
+// StepKind is the kind of a trajectory Step.
+type StepKind string
+
+const (
+	StepMessage           StepKind = "message"
+	StepToolCall          StepKind = "tool_call"
+	StepToolOutput        StepKind = "tool_output"
+	StepHandoffCall       StepKind = "handoff_call"
+	StepHandoff           StepKind = "handoff"
+	StepReasoning         StepKind = "reasoning"
+	StepGuardrailFeedback StepKind = "guardrail_feedback"
+	StepOutputRepair      StepKind = "output_repair"
+)
+
+// A Step is a single item generated during a run, simplified for matching.
+type Step struct {
+	// The position of the step in the trajectory, starting from 0.
+	Index int
+
+	Kind StepKind
+
+	// The name of the agent which generated the step.
+	Agent string
+
+	// The name of the tool for tool calls and outputs, the name of the
+	// handoff tool for handoff calls, the name of the target agent for
+	// handoffs, the name of the guardrail for guardrail feedbacks.
+	Name string
+
+	// The JSON arguments of function and handoff calls.
+	Arguments string
+
+	// The text of messages, the output of tools, the error of output repairs.
+	Text string
+
+	// The original run item.
+	Item agents.RunItem
+}
+
+// A Trajectory is the sequence of steps of a run, with the information
+// needed to check it against TrajectoryMatcher.
+type Trajectory struct {
+	Steps []Step
+
+	// The number of model responses, i.e. the number of turns of the run.
+	Turns int
+
+	// The name of the last agent of the run.
+	LastAgent string
+
+	FinalOutput any
+
+	// A readable description of the run, reported on mismatches.
+	Trace string
+}
+
+// NewTrajectory returns the trajectory of a run.
+func NewTrajectory(result agents.RunResult) Trajectory {
+	trace := agents.PrettyPrintResult(result)
+	return newTrajectory(result.NewItems, len(result.RawResponses), result.LastAgent, result.FinalOutput, trace)
+}
+
+// NewStreamedTrajectory returns the trajectory of a streamed run. It should be
+// called once the events have been consumed.
+func NewStreamedTrajectory(result *agents.RunResultStreaming) Trajectory {
+	trace := agents.PrettyPrintRunResultStreaming(*result)
+	return newTrajectory(result.NewItems(), len(result.RawResponses()), result.LastAgent(), result.FinalOutput(), trace)
+}
+
+func newTrajectory(items []agents.RunItem, turns int, lastAgent *agents.Agent, finalOutput any, trace string) Trajectory {
+	t := Trajectory{
+		Steps:       make([]Step, len(items)),
+		Turns:       turns,
+		FinalOutput: finalOutput,
+		Trace:       trace,
+	}
+	if lastAgent != nil {
+		t.LastAgent = lastAgent.Name
+	}
+	if len(items) > 0 {
+		t.Trace += "\n\nItems:\n" + agents.PrettyPrintRunItems(items)
+	}
+
+	toolNames := make(map[string]string) // call ID -> tool name
+	for i, item := range items {
+		step := Step{Index: i, Item: item}
+		switch item := item.(type) {
+		case agents.MessageOutputItem:
+			step.Kind = StepMessage
+			step.Agent = item.Agent.Name
+			step.Text = agents.ItemHelpers().TextMessageOutput(item)
+		case agents.HandoffCallItem:
+			step.Kind = StepHandoffCall
+			step.Agent = item.Agent.Name
+			step.Name = item.RawItem.Name
+			step.Arguments = item.RawItem.Arguments
+		case agents.HandoffOutputItem:
+			step.Kind = StepHandoff
+			step.Agent = item.SourceAgent.Name
+			step.Name = item.TargetAgent.Name
+		case agents.ToolCallItem:
+			step.Kind = StepToolCall
+			step.Agent = item.Agent.Name
+			var callID string
+			step.Name, step.Arguments, callID = toolCallInfo(item.RawItem)
+			toolNames[callID] = step.Name
+		case agents.ToolCallOutputItem:
+			step.Kind = StepToolOutput
+			step.Agent = item.Agent.Name
+			step.Name = toolNames[toolOutputCallID(item.RawItem)]
+			step.Text = outputText(item.Output)
+		case agents.ReasoningItem:
+			step.Kind = StepReasoning
+			step.Agent = item.Agent.Name
+		case agents.GuardrailFeedbackItem:
+			step.Kind = StepGuardrailFeedback
+			step.Agent = item.Agent.Name
+			step.Name = item.GuardrailName
+			step.Text = item.RawItem.Content.OfString.Value
+		case agents.OutputRepairItem:
+			step.Kind = StepOutputRepair
+			step.Agent = item.Agent.Name
+			step.Text = item.Error
+		}
+		t.Steps[i] = step
+	}
+	return t
+}
+
+func toolCallInfo(call agents.ToolCallItemType) (name, arguments, callID string) {
+	switch call := call.(type) {
+	case agents.ResponseFunctionToolCall:
+		return call.Name, call.Arguments, call.CallID
+	case agents.ResponseComputerToolCall:
+		return "computer", "", call.CallID
+	case agents.ResponseOutputItemLocalShellCall:
+		return "local_shell", "", call.CallID
+	case agents.ResponseFileSearchToolCall:
+		return "file_search", "", call.ID
+	case agents.ResponseFunctionWebSearch:
+		return "web_search", "", call.ID
+	case agents.ResponseCodeInterpreterToolCall:
+		return "code_interpreter", "", call.ID
+	case agents.ResponseOutputItemImageGenerationCall:
+		return "image_generation", "", call.ID
+	default:
+		return fmt.Sprintf("%T", call), "", ""
+	}
+}
+
+func toolOutputCallID(output agents.ToolCallOutputRawItem) string {
+	switch output := output.(type) {
+	case agents.ResponseInputItemFunctionCallOutputParam:
+		return output.CallID
+	case agents.ResponseInputItemComputerCallOutputParam:
+		return output.CallID
+	case agents.ResponseInputItemLocalShellCallOutputParam:
+		return output.ID
+	default:
+		return ""
+	}
+}
+
+// outputText returns a tool output as text: strings are returned as they are,
+// other values are encoded as JSON.
+func outputText(output any) string {
+	if s, ok := output.(string); ok {
+		return s
+	}
+	b, err := json.Marshal(output)
+	if err != nil {
+		return fmt.Sprint(output)
+	}
+	return string(b)
+}
+
+// String returns the trace of the trajectory.
+func (t Trajectory) String() string {
+	return t.Trace
+}
+
+// Check checks the trajectory against the given matchers. The returned error
+// describes all the mismatches, followed by the trace of the run.
+func (t Trajectory) Check(matchers ...TrajectoryMatcher) error {
+	var errs []error
+	for _, match := range matchers {
+		if err := match(t); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("trajectory mismatch:\n%w\n\n%s", errors.Join(errs...), t.Trace)
+}
+
+// AssertTrajectory checks the trajectory of a run against the given matchers,
+// reporting mismatches as test errors. It returns whether the trajectory
+// matches.
+//
+// Example:
+//
+//	agentstesting.AssertTrajectory(t, *result,
+//		agentstesting.InOrder(agentstesting.HandoffTo("billing"), agentstesting.CallTo("refund")),
+//		agentstesting.NeverCalledTool("delete_account"),
+//		agentstesting.FinishedWithin(3),
+//	)
+func AssertTrajectory(t testing.TB, result agents.RunResult, matchers ...TrajectoryMatcher) bool {
+	t.Helper()
+	return assertTrajectory(t, NewTrajectory(result), matchers)
+}
+
+// AssertStreamedTrajectory is like AssertTrajectory, for streamed runs.
+func AssertStreamedTrajectory(t testing.TB, result *agents.RunResultStreaming, matchers ...TrajectoryMatcher) bool {
+	t.Helper()
+	return assertTrajectory(t, NewStreamedTrajectory(result), matchers)
+}
+
+func assertTrajectory(t testing.TB, trajectory Trajectory, matchers []TrajectoryMatcher) bool {
+	t.Helper()
+	if err := trajectory.Check(matchers...); err != nil {
+		t.Error(err)
+		return false
+	}
+	return true
+}
+
+// A TrajectoryMatcher checks a trajectory, returning an error describing the
+// mismatch if the trajectory does not satisfy it.
+type TrajectoryMatcher func(Trajectory) error
+
+// A StepMatcher selects steps of a trajectory.
+type StepMatcher struct {
+	// A description of the selected steps, such as `call to "search"`.
+	Description string
+	Match       func(Step) bool
+}
+
+func (m StepMatcher) String() string {
+	return m.Description
+}
+
+// CallTo matches calls to the tool with the given name.
+func CallTo(name string) StepMatcher {
+	return StepMatcher{
+		Description: fmt.Sprintf("call to %q", name),
+		Match: func(s Step) bool {
+			return s.Kind == StepToolCall && s.Name == name
+		},
+	}
+}
+
+// CallWith matches calls to the function tool with the given name, whose
+// arguments, decoded from JSON, satisfy the predicate.
+func CallWith(name string, predicate func(arguments map[string]any) bool) StepMatcher {
+	return StepMatcher{
+		Description: fmt.Sprintf("call to %q with matching arguments", name),
+		Match: func(s Step) bool {
+			if s.Kind != StepToolCall || s.Name != name {
+				return false
+			}
+			var arguments map[string]any
+			if err := json.Unmarshal([]byte(s.Arguments), &arguments); err != nil {
+				return false
+			}
+			return predicate(arguments)
+		},
+	}
+}
+
+// HandoffTo matches handoffs to the agent with the given name.
+func HandoffTo(agent string) StepMatcher {
+	return StepMatcher{
+		Description: fmt.Sprintf("handoff to %q", agent),
+		Match: func(s Step) bool {
+			return s.Kind == StepHandoff && s.Name == agent
+		},
+	}
+}
+
+// MessageContaining matches messages containing the given text.
+func MessageContaining(text string) StepMatcher {
+	return StepMatcher{
+		Description: fmt.Sprintf("message containing %q", text),
+		Match: func(s Step) bool {
+			return s.Kind == StepMessage && strings.Contains(s.Text, text)
+		},
+	}
+}
+
+// By restricts a step matcher to the steps generated by the given agent.
+func (m StepMatcher) By(agent string) StepMatcher {
+	return StepMatcher{
+		Description: fmt.Sprintf("%s by %q", m.Description, agent),
+		Match: func(s Step) bool {
+			return s.Agent == agent && m.Match(s)
+		},
+	}
+}
+
+// Contains matches trajectories with at least one step selected by m.
+func Contains(m StepMatcher) TrajectoryMatcher {
+	return func(t Trajectory) error {
+		for _, s := range t.Steps {
+			if m.Match(s) {
+				return nil
+			}
+		}
+		return fmt.Errorf("expected a %s, found none", m)
+	}
+}
+
+// NotContains matches trajectories without any step selected by m.
+func NotContains(m StepMatcher) TrajectoryMatcher {
+	return func(t Trajectory) error {
+		for _, s := range t.Steps {
+			if m.Match(s) {
+				return fmt.Errorf("expected no %s, found one at step %d", m, s.Index+1)
+			}
+		}
+		return nil
+	}
+}
+
+// InOrder matches trajectories with steps selected by the given matchers, in
+// the given order. Other steps may occur in between.
+func InOrder(matchers ...StepMatcher) TrajectoryMatcher {
+	return func(t Trajectory) error {
+		next := 0
+		for i, m := range matchers {
+			found := false
+			for ; next < len(t.Steps); next++ {
+				if m.Match(t.Steps[next]) {
+					found = true
+					next++
+					break
+				}
+			}
+			if !found {
+				if i == 0 {
+					return fmt.Errorf("expected a %s, found none", m)
+				}
+				return fmt.Errorf("expected a %s after the %s, found none", m, matchers[i-1])
+			}
+		}
+		return nil
+	}
+}
+
+// CalledTool matches trajectories with at least one call to the tool with the
+// given name.
+func CalledTool(name string) TrajectoryMatcher {
+	return Contains(CallTo(name))
+}
+
+// NeverCalledTool matches trajectories without calls to the tool with the
+// given name.
+func NeverCalledTool(name string) TrajectoryMatcher {
+	return NotContains(CallTo(name))
+}
+
+// HandedOffTo matches trajectories with a handoff to the agent with the given
+// name.
+func HandedOffTo(agent string) TrajectoryMatcher {
+	return Contains(HandoffTo(agent))
+}
+
+// FinishedWithin matches trajectories of runs which took at most the given
+// number of turns.
+func FinishedWithin(turns int) TrajectoryMatcher {
+	return func(t Trajectory) error {
+		if t.Turns > turns {
+			return fmt.Errorf("expected at most %d turn(s), got %d", turns, t.Turns)
+		}
+		return nil
+	}
+}
+
+// EndedWith matches trajectories of runs whose last agent has the given name.
+func EndedWith(agent string) TrajectoryMatcher {
+	return func(t Trajectory) error {
+		if t.LastAgent != agent {
+			return fmt.Errorf("expected the run to end with agent %q, got %q", agent, t.LastAgent)
+		}
+		return nil
+	}
+}
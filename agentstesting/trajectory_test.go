@@ -0,0 +1,114 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agentstesting_test
+
+import (
+	"testing"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/agentstesting"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func refundRun(t *testing.T) *agents.RunResult {
+	t.Helper()
+	model := agentstesting.NewFakeModel(nil)
+	billing := agents.New("billing").WithModelInstance(model).
+		WithTools(agentstesting.GetFunctionTool("refund", "done"))
+	triage := agents.New("triage").WithModelInstance(model).WithAgentHandoffs(billing)
+
+	model.AddMultipleTurnOutputs([]agentstesting.FakeModelTurnOutput{
+		{Value: []agents.TResponseOutputItem{agentstesting.GetHandoffToolCall(billing, "", "")}},
+		{Value: []agents.TResponseOutputItem{agentstesting.GetFunctionToolCall("refund", `{"order": 42}`)}},
+		{Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("Your order was refunded.")}},
+	})
+	result, err := agents.Run(t.Context(), triage, "Refund order 42")
+	require.NoError(t, err)
+	return result
+}
+
+func TestTrajectoryMatchers(t *testing.T) {
+	result := refundRun(t)
+
+	agentstesting.AssertTrajectory(t, *result,
+		agentstesting.HandedOffTo("billing"),
+		agentstesting.InOrder(
+			agentstesting.HandoffTo("billing"),
+			agentstesting.CallWith("refund", func(args map[string]any) bool { return args["order"] == 42.0 }).By("billing"),
+			agentstesting.MessageContaining("refunded"),
+		),
+		agentstesting.NeverCalledTool("delete_account"),
+		agentstesting.FinishedWithin(3),
+		agentstesting.EndedWith("billing"),
+	)
+
+	trajectory := agentstesting.NewTrajectory(*result)
+	require.Len(t, trajectory.Steps, 5)
+	assert.Equal(t, agentstesting.StepToolOutput, trajectory.Steps[3].Kind)
+	assert.Equal(t, "refund", trajectory.Steps[3].Name)
+	assert.Equal(t, "done", trajectory.Steps[3].Text)
+}
+
+func TestTrajectoryMismatch(t *testing.T) {
+	trajectory := agentstesting.NewTrajectory(*refundRun(t))
+
+	err := trajectory.Check(
+		agentstesting.InOrder(agentstesting.CallTo("refund"), agentstesting.HandoffTo("billing")),
+		agentstesting.NeverCalledTool("refund"),
+		agentstesting.CalledTool("refund"),
+		agentstesting.FinishedWithin(2),
+		agentstesting.Contains(agentstesting.CallTo("refund").By("triage")),
+	)
+	require.Error(t, err)
+	assert.Equal(t, `trajectory mismatch:
+expected a handoff to "billing" after the call to "refund", found none
+expected no call to "refund", found one at step 3
+expected at most 2 turn(s), got 3
+expected a call to "refund" by "triage", found none
+
+RunResult:
+- Last agent: Agent(name="billing", ...)
+- Final output (string):
+    Your order was refunded.
+- 5 new item(s)
+- 3 raw response(s)
+- 0 input guardrail result(s)
+- 0 output guardrail result(s)
+(See `+"`RunResult`"+` for more details)
+
+Items:
+1. [triage] handoff call: transfer_to_billing()
+2. [triage] handoff: triage -> billing
+3. [billing] tool call: refund({"order": 42})
+4. [billing] tool output: "done"
+5. [billing] message: "Your order was refunded."`, err.Error())
+
+	assert.NoError(t, trajectory.Check(agentstesting.FinishedWithin(3)))
+}
+
+func TestStreamedTrajectory(t *testing.T) {
+	model := agentstesting.NewFakeModel(&agentstesting.FakeModelTurnOutput{
+		Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("Hi there")},
+	})
+	result, err := agents.Runner{}.RunStreamed(t.Context(), agents.New("test").WithModelInstance(model), "Hello")
+	require.NoError(t, err)
+	require.NoError(t, result.StreamEvents(func(agents.StreamEvent) error { return nil }))
+
+	agentstesting.AssertStreamedTrajectory(t, result,
+		agentstesting.Contains(agentstesting.MessageContaining("Hi").By("test")),
+		agentstesting.FinishedWithin(1),
+	)
+}
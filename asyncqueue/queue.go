@@ -42,6 +42,30 @@ func (q *Queue[T]) Get() T {
 	return q.get()
 }
 
+// GetUnless is like Get, but stops waiting as soon as stop returns true,
+// returning false. The condition is checked again whenever a value is put, or
+// Wake is called.
+func (q *Queue[T]) GetUnless(stop func() bool) (T, bool) {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	for len(q.values) == 0 {
+		if stop() {
+			var zero T
+			return zero, false
+		}
+		q.cond.Wait()
+	}
+	return q.get(), true
+}
+
+// Wake wakes up all the goroutines waiting in GetUnless, so that they check
+// their stop condition again.
+func (q *Queue[T]) Wake() {
+	q.cond.L.Lock()
+	q.cond.Broadcast()
+	q.cond.L.Unlock()
+}
+
 func (q *Queue[T]) GetNoWait() (T, bool) {
 	q.cond.L.Lock()
 	defer q.cond.L.Unlock()
@@ -15,7 +15,9 @@
 package asyncqueue
 
 import (
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -56,3 +58,34 @@ func TestQueue(t *testing.T) {
 	v, ok = q.GetNoWait()
 	assert.False(t, ok)
 }
+
+func TestQueueGetUnless(t *testing.T) {
+	q := New[int]()
+	var stop atomic.Bool
+
+	q.Put(1)
+	v, ok := q.GetUnless(stop.Load)
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+
+	done := make(chan bool)
+	go func() {
+		_, ok := q.GetUnless(stop.Load)
+		done <- ok
+	}()
+	time.Sleep(10 * time.Millisecond)
+	stop.Store(true)
+	q.Wake()
+
+	select {
+	case ok = <-done:
+		assert.False(t, ok)
+	case <-time.After(5 * time.Second):
+		t.Fatal("GetUnless did not return after Wake")
+	}
+
+	q.Put(2)
+	v, ok = q.GetUnless(stop.Load)
+	assert.True(t, ok)
+	assert.Equal(t, 2, v)
+}
@@ -0,0 +1,380 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package playwright provides a computer.Computer driving a local browser
+// with Playwright.
+//
+// The Playwright driver and the browsers must be installed beforehand, for
+// example with the Playwright CLI, or by setting Options.Install.
+//
+// Example:
+//
+//	comp, err := playwright.New(playwright.Options{StartURL: "https://www.bing.com"})
+//	if err != nil {
+//		return err
+//	}
+//	defer func() { _ = comp.Close() }()
+//
+//	agent := agents.New("Browser user").
+//		WithTools(agents.ComputerTool{Computer: comp}).
+//		WithModel("computer-use-preview")
+package playwright
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nlpodyssey/openai-agents-go/computer"
+	"github.com/openai/openai-go/packages/param"
+	pw "github.com/playwright-community/playwright-go"
+)
+
+// DefaultDimensions are the default dimensions of the viewport.
+var DefaultDimensions = computer.Dimensions{Width: 1024, Height: 768}
+
+// DefaultWaitDuration is the default duration of Computer.Wait.
+const DefaultWaitDuration = time.Second
+
+// Options configures a Computer.
+type Options struct {
+	// The dimensions of the viewport. Default: DefaultDimensions.
+	Dimensions computer.Dimensions
+
+	// Whether to run the browser without a window. Default: true.
+	Headless param.Opt[bool]
+
+	// The browser to launch: "chromium", "firefox" or "webkit".
+	// Default: "chromium".
+	Browser string
+
+	// The page to open. Default: a blank page.
+	StartURL string
+
+	// How long Computer.Wait waits. Default: DefaultWaitDuration.
+	WaitDuration time.Duration
+
+	// Download the Playwright driver and the browser, if missing.
+	Install bool
+
+	// Optional options of the Playwright driver, such as its location.
+	RunOptions *pw.RunOptions
+}
+
+// Computer is a computer.Computer driving a browser page with Playwright.
+// Its methods must not be called concurrently.
+type Computer struct {
+	dimensions   computer.Dimensions
+	waitDuration time.Duration
+	playwright   *pw.Playwright
+	browser      pw.Browser
+	page         pw.Page
+}
+
+var _ computer.Computer = (*Computer)(nil)
+
+// New starts Playwright, launches a browser and opens a page. The returned
+// Computer must be closed with Close.
+func New(opts Options) (_ *Computer, err error) {
+	dimensions := opts.Dimensions
+	if dimensions.Width <= 0 || dimensions.Height <= 0 {
+		dimensions = DefaultDimensions
+	}
+	waitDuration := opts.WaitDuration
+	if waitDuration <= 0 {
+		waitDuration = DefaultWaitDuration
+	}
+	browserName := opts.Browser
+	if browserName == "" {
+		browserName = "chromium"
+	}
+
+	runOptions := opts.RunOptions
+	if opts.Install {
+		installOptions := &pw.RunOptions{}
+		if runOptions != nil {
+			*installOptions = *runOptions
+		}
+		if installOptions.Browsers == nil {
+			installOptions.Browsers = []string{browserName}
+		}
+		if err = pw.Install(installOptions); err != nil {
+			return nil, fmt.Errorf("error installing playwright: %w", err)
+		}
+	}
+
+	var runOptionsList []*pw.RunOptions
+	if runOptions != nil {
+		runOptionsList = append(runOptionsList, runOptions)
+	}
+	c := &Computer{dimensions: dimensions, waitDuration: waitDuration}
+	if c.playwright, err = pw.Run(runOptionsList...); err != nil {
+		return nil, fmt.Errorf("error starting playwright: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, c.Close())
+		}
+	}()
+
+	var browserType pw.BrowserType
+	switch browserName {
+	case "chromium":
+		browserType = c.playwright.Chromium
+	case "firefox":
+		browserType = c.playwright.Firefox
+	case "webkit":
+		browserType = c.playwright.WebKit
+	default:
+		return nil, fmt.Errorf("unknown browser %q", browserName)
+	}
+
+	c.browser, err = browserType.Launch(pw.BrowserTypeLaunchOptions{
+		Headless: pw.Bool(opts.Headless.Or(true)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error launching browser: %w", err)
+	}
+
+	c.page, err = c.browser.NewPage(pw.BrowserNewPageOptions{
+		Viewport: &pw.Size{Width: int(dimensions.Width), Height: int(dimensions.Height)},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating page: %w", err)
+	}
+
+	if opts.StartURL != "" {
+		if _, err = c.page.Goto(opts.StartURL); err != nil {
+			return nil, fmt.Errorf("error navigating to %s: %w", opts.StartURL, err)
+		}
+	}
+	return c, nil
+}
+
+// Page returns the Playwright page driven by the computer.
+func (c *Computer) Page() pw.Page {
+	return c.page
+}
+
+// Close closes the browser and stops Playwright.
+func (c *Computer) Close() error {
+	var errs []error
+	if c.browser != nil {
+		if err := c.browser.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("error closing browser: %w", err))
+		}
+		c.browser = nil
+		c.page = nil
+	}
+	if c.playwright != nil {
+		if err := c.playwright.Stop(); err != nil {
+			errs = append(errs, fmt.Errorf("error stopping playwright: %w", err))
+		}
+		c.playwright = nil
+	}
+	return errors.Join(errs...)
+}
+
+func (c *Computer) Environment(context.Context) (computer.Environment, error) {
+	return computer.EnvironmentBrowser, nil
+}
+
+func (c *Computer) Dimensions(context.Context) (computer.Dimensions, error) {
+	return c.dimensions, nil
+}
+
+// Screenshot returns a base64-encoded PNG screenshot of the viewport.
+func (c *Computer) Screenshot(context.Context) (string, error) {
+	pngBytes, err := c.page.Screenshot(pw.PageScreenshotOptions{
+		FullPage: pw.Bool(false),
+		Type:     pw.ScreenshotTypePng,
+	})
+	if err != nil {
+		return "", fmt.Errorf("screenshot error: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(pngBytes), nil
+}
+
+// Click clicks at the given position. The back and forward buttons navigate
+// the page history, as in most browsers.
+func (c *Computer) Click(_ context.Context, x, y int64, button computer.Button) error {
+	var pwButton *pw.MouseButton
+	switch button {
+	case computer.ButtonBack:
+		if _, err := c.page.GoBack(); err != nil {
+			return fmt.Errorf("go back error: %w", err)
+		}
+		return nil
+	case computer.ButtonForward:
+		if _, err := c.page.GoForward(); err != nil {
+			return fmt.Errorf("go forward error: %w", err)
+		}
+		return nil
+	case computer.ButtonRight:
+		pwButton = pw.MouseButtonRight
+	case computer.ButtonWheel:
+		pwButton = pw.MouseButtonMiddle
+	default:
+		pwButton = pw.MouseButtonLeft
+	}
+
+	err := c.page.Mouse().Click(float64(x), float64(y), pw.MouseClickOptions{Button: pwButton})
+	if err != nil {
+		return fmt.Errorf("click error: %w", err)
+	}
+	return nil
+}
+
+func (c *Computer) DoubleClick(_ context.Context, x, y int64) error {
+	if err := c.page.Mouse().Dblclick(float64(x), float64(y)); err != nil {
+		return fmt.Errorf("double click error: %w", err)
+	}
+	return nil
+}
+
+func (c *Computer) Scroll(_ context.Context, x, y int64, scrollX, scrollY int64) error {
+	if err := c.page.Mouse().Move(float64(x), float64(y)); err != nil {
+		return fmt.Errorf("mouse move to scroll error: %w", err)
+	}
+	if _, err := c.page.Evaluate(fmt.Sprintf("window.scrollBy(%d, %d)", scrollX, scrollY)); err != nil {
+		return fmt.Errorf("page scroll error: %w", err)
+	}
+	return nil
+}
+
+func (c *Computer) Type(_ context.Context, text string) error {
+	if err := c.page.Keyboard().Type(text); err != nil {
+		return fmt.Errorf("keyboard typing error: %w", err)
+	}
+	return nil
+}
+
+// Wait waits for Options.WaitDuration, or until ctx is done.
+func (c *Computer) Wait(ctx context.Context) error {
+	timer := time.NewTimer(c.waitDuration)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (c *Computer) Move(_ context.Context, x, y int64) error {
+	if err := c.page.Mouse().Move(float64(x), float64(y)); err != nil {
+		return fmt.Errorf("mouse move error: %w", err)
+	}
+	return nil
+}
+
+// Keypress presses the keys together, as in a keyboard shortcut, and releases
+// them in reverse order. Key names used by computer-use models, such as
+// "CTRL" or "ESC", are mapped to the names used by Playwright (see KeyName).
+func (c *Computer) Keypress(_ context.Context, keys []string) error {
+	mappedKeys := make([]string, len(keys))
+	for i, key := range keys {
+		mappedKeys[i] = KeyName(key)
+	}
+
+	for _, key := range mappedKeys {
+		if err := c.page.Keyboard().Down(key); err != nil {
+			return fmt.Errorf("key down error: %w", err)
+		}
+	}
+	for i := len(mappedKeys) - 1; i >= 0; i-- {
+		if err := c.page.Keyboard().Up(mappedKeys[i]); err != nil {
+			return fmt.Errorf("key up error: %w", err)
+		}
+	}
+	return nil
+}
+
+func (c *Computer) Drag(_ context.Context, path []computer.Position) error {
+	if len(path) == 0 {
+		return nil
+	}
+	mouse := c.page.Mouse()
+	if err := mouse.Move(float64(path[0].X), float64(path[0].Y)); err != nil {
+		return fmt.Errorf("mouse move to drag error: %w", err)
+	}
+	if err := mouse.Down(); err != nil {
+		return fmt.Errorf("mouse down to drag error: %w", err)
+	}
+	for _, p := range path[1:] {
+		if err := mouse.Move(float64(p.X), float64(p.Y)); err != nil {
+			return fmt.Errorf("mouse move to drag error: %w", err)
+		}
+	}
+	if err := mouse.Up(); err != nil {
+		return fmt.Errorf("mouse up to drag error: %w", err)
+	}
+	return nil
+}
+
+var cuaKeyToPlaywrightKey = map[string]string{
+	"/":          "Divide",
+	"\\":         "Backslash",
+	"alt":        "Alt",
+	"arrowdown":  "ArrowDown",
+	"arrowleft":  "ArrowLeft",
+	"arrowright": "ArrowRight",
+	"arrowup":    "ArrowUp",
+	"backspace":  "Backspace",
+	"capslock":   "CapsLock",
+	"cmd":        "Meta",
+	"command":    "Meta",
+	"control":    "Control",
+	"ctrl":       "Control",
+	"del":        "Delete",
+	"delete":     "Delete",
+	"down":       "ArrowDown",
+	"end":        "End",
+	"enter":      "Enter",
+	"esc":        "Escape",
+	"escape":     "Escape",
+	"home":       "Home",
+	"insert":     "Insert",
+	"left":       "ArrowLeft",
+	"meta":       "Meta",
+	"option":     "Alt",
+	"pagedown":   "PageDown",
+	"pageup":     "PageUp",
+	"return":     "Enter",
+	"right":      "ArrowRight",
+	"shift":      "Shift",
+	"space":      " ",
+	"super":      "Meta",
+	"tab":        "Tab",
+	"up":         "ArrowUp",
+	"win":        "Meta",
+}
+
+// KeyName returns the Playwright name of a key named by a computer-use model.
+// Names are matched case-insensitively; function keys such as "f5" become
+// "F5", and unknown names, such as single characters, are returned as they
+// are.
+func KeyName(key string) string {
+	lower := strings.ToLower(key)
+	if v, ok := cuaKeyToPlaywrightKey[lower]; ok {
+		return v
+	}
+	if len(lower) >= 2 && len(lower) <= 3 && lower[0] == 'f' && strings.Trim(lower[1:], "0123456789") == "" {
+		return strings.ToUpper(lower)
+	}
+	return key
+}
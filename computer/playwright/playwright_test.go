@@ -0,0 +1,141 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package playwright_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/nlpodyssey/openai-agents-go/computer"
+	"github.com/nlpodyssey/openai-agents-go/computer/playwright"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPage = `<!DOCTYPE html>
+<html>
+<head>
+<style>
+  body { margin: 0; height: 3000px; }
+  input { position: absolute; left: 10px; top: 10px; width: 200px; height: 30px; }
+</style>
+</head>
+<body>
+<input id="input">
+<script>
+  window.events = [];
+  document.addEventListener("keydown", e => window.events.push("keydown:" + e.key));
+  document.addEventListener("click", e => window.events.push("click:" + e.clientX + "," + e.clientY));
+  document.addEventListener("dblclick", e => window.events.push("dblclick:" + e.clientX + "," + e.clientY));
+</script>
+</body>
+</html>`
+
+func TestKeyName(t *testing.T) {
+	for key, expected := range map[string]string{
+		"CTRL":      "Control",
+		"esc":       "Escape",
+		"Return":    "Enter",
+		"ArrowLeft": "ArrowLeft",
+		"space":     " ",
+		"f5":        "F5",
+		"F12":       "F12",
+		"a":         "a",
+		"A":         "A",
+		"foo":       "foo",
+	} {
+		assert.Equal(t, expected, playwright.KeyName(key), key)
+	}
+}
+
+func TestComputer(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(testPage))
+	}))
+	t.Cleanup(server.Close)
+
+	comp, err := playwright.New(playwright.Options{
+		Dimensions:   computer.Dimensions{Width: 640, Height: 480},
+		StartURL:     server.URL,
+		WaitDuration: time.Millisecond,
+	})
+	if err != nil {
+		t.Skipf("Playwright is not available: %v", err)
+	}
+	t.Cleanup(func() { _ = comp.Close() })
+	ctx := t.Context()
+
+	env, err := comp.Environment(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, computer.EnvironmentBrowser, env)
+
+	dimensions, err := comp.Dimensions(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, computer.Dimensions{Width: 640, Height: 480}, dimensions)
+
+	screenshot, err := comp.Screenshot(ctx)
+	require.NoError(t, err)
+	pngBytes, err := base64.StdEncoding.DecodeString(screenshot)
+	require.NoError(t, err)
+	img, err := png.Decode(bytes.NewReader(pngBytes))
+	require.NoError(t, err)
+	assert.Equal(t, 640, img.Bounds().Dx())
+	assert.Equal(t, 480, img.Bounds().Dy())
+
+	require.NoError(t, comp.Click(ctx, 20, 20, computer.ButtonLeft))
+	require.NoError(t, comp.Type(ctx, "hi"))
+	value, err := comp.Page().InputValue("#input")
+	require.NoError(t, err)
+	assert.Equal(t, "hi", value)
+
+	require.NoError(t, comp.Keypress(ctx, []string{"CTRL", "A"}))
+	require.NoError(t, comp.Keypress(ctx, []string{"backspace"}))
+	value, err = comp.Page().InputValue("#input")
+	require.NoError(t, err)
+	assert.Equal(t, "", value)
+
+	require.NoError(t, comp.DoubleClick(ctx, 300, 300))
+	require.NoError(t, comp.Move(ctx, 310, 310))
+	require.NoError(t, comp.Drag(ctx, []computer.Position{{X: 300, Y: 300}, {X: 320, Y: 320}}))
+	require.NoError(t, comp.Wait(ctx))
+
+	events, err := comp.Page().Evaluate("window.events")
+	require.NoError(t, err)
+	assert.Equal(t, []any{
+		"click:20,20",
+		"keydown:h",
+		"keydown:i",
+		"keydown:Control",
+		"keydown:A",
+		"keydown:Backspace",
+		"click:300,300",
+		"click:300,300",
+		"dblclick:300,300",
+		"click:320,320",
+	}, events)
+
+	require.NoError(t, comp.Scroll(ctx, 100, 100, 0, 200))
+	scrollY, err := comp.Page().Evaluate("window.scrollY")
+	require.NoError(t, err)
+	assert.EqualValues(t, 200, scrollY)
+
+	require.NoError(t, comp.Close())
+}
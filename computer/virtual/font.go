@@ -0,0 +1,120 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package virtual
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"unicode"
+)
+
+// The text is drawn with a 3x5 pixel font, scaled by glyphScale.
+const (
+	glyphWidth  = 3
+	glyphHeight = 5
+	glyphScale  = 2
+
+	// GlyphAdvance is the horizontal space, in pixels, taken by a character.
+	GlyphAdvance = (glyphWidth + 1) * glyphScale
+
+	// LineHeight is the height, in pixels, of a line of text.
+	LineHeight = (glyphHeight + 2) * glyphScale
+)
+
+// glyphs maps characters to rows of 3 pixels, the most significant bit being
+// the leftmost one. Lowercase letters are drawn as uppercase ones, and other
+// characters as unknownGlyph.
+var glyphs = map[rune][glyphHeight]uint8{
+	'A':  {0b010, 0b101, 0b111, 0b101, 0b101},
+	'B':  {0b110, 0b101, 0b110, 0b101, 0b110},
+	'C':  {0b011, 0b100, 0b100, 0b100, 0b011},
+	'D':  {0b110, 0b101, 0b101, 0b101, 0b110},
+	'E':  {0b111, 0b100, 0b110, 0b100, 0b111},
+	'F':  {0b111, 0b100, 0b110, 0b100, 0b100},
+	'G':  {0b011, 0b100, 0b101, 0b101, 0b011},
+	'H':  {0b101, 0b101, 0b111, 0b101, 0b101},
+	'I':  {0b111, 0b010, 0b010, 0b010, 0b111},
+	'J':  {0b001, 0b001, 0b001, 0b101, 0b010},
+	'K':  {0b101, 0b101, 0b110, 0b101, 0b101},
+	'L':  {0b100, 0b100, 0b100, 0b100, 0b111},
+	'M':  {0b101, 0b111, 0b111, 0b101, 0b101},
+	'N':  {0b110, 0b101, 0b101, 0b101, 0b101},
+	'O':  {0b010, 0b101, 0b101, 0b101, 0b010},
+	'P':  {0b110, 0b101, 0b110, 0b100, 0b100},
+	'Q':  {0b010, 0b101, 0b101, 0b110, 0b011},
+	'R':  {0b110, 0b101, 0b110, 0b101, 0b101},
+	'S':  {0b011, 0b100, 0b010, 0b001, 0b110},
+	'T':  {0b111, 0b010, 0b010, 0b010, 0b010},
+	'U':  {0b101, 0b101, 0b101, 0b101, 0b111},
+	'V':  {0b101, 0b101, 0b101, 0b101, 0b010},
+	'W':  {0b101, 0b101, 0b111, 0b111, 0b101},
+	'X':  {0b101, 0b101, 0b010, 0b101, 0b101},
+	'Y':  {0b101, 0b101, 0b010, 0b010, 0b010},
+	'Z':  {0b111, 0b001, 0b010, 0b100, 0b111},
+	'0':  {0b111, 0b101, 0b101, 0b101, 0b111},
+	'1':  {0b010, 0b110, 0b010, 0b010, 0b111},
+	'2':  {0b110, 0b001, 0b010, 0b100, 0b111},
+	'3':  {0b110, 0b001, 0b010, 0b001, 0b110},
+	'4':  {0b101, 0b101, 0b111, 0b001, 0b001},
+	'5':  {0b111, 0b100, 0b110, 0b001, 0b110},
+	'6':  {0b011, 0b100, 0b111, 0b101, 0b111},
+	'7':  {0b111, 0b001, 0b010, 0b010, 0b010},
+	'8':  {0b111, 0b101, 0b111, 0b101, 0b111},
+	'9':  {0b111, 0b101, 0b111, 0b001, 0b110},
+	' ':  {},
+	'.':  {0b000, 0b000, 0b000, 0b000, 0b010},
+	',':  {0b000, 0b000, 0b000, 0b010, 0b100},
+	':':  {0b000, 0b010, 0b000, 0b010, 0b000},
+	';':  {0b000, 0b010, 0b000, 0b010, 0b100},
+	'!':  {0b010, 0b010, 0b010, 0b000, 0b010},
+	'?':  {0b110, 0b001, 0b010, 0b000, 0b010},
+	'-':  {0b000, 0b000, 0b111, 0b000, 0b000},
+	'+':  {0b000, 0b010, 0b111, 0b010, 0b000},
+	'=':  {0b000, 0b111, 0b000, 0b111, 0b000},
+	'_':  {0b000, 0b000, 0b000, 0b000, 0b111},
+	'/':  {0b001, 0b001, 0b010, 0b100, 0b100},
+	'(':  {0b001, 0b010, 0b010, 0b010, 0b001},
+	')':  {0b100, 0b010, 0b010, 0b010, 0b100},
+	'\'': {0b010, 0b010, 0b000, 0b000, 0b000},
+	'"':  {0b101, 0b101, 0b000, 0b000, 0b000},
+	'#':  {0b101, 0b111, 0b101, 0b111, 0b101},
+	'@':  {0b010, 0b101, 0b111, 0b100, 0b011},
+}
+
+var unknownGlyph = [glyphHeight]uint8{0b111, 0b111, 0b111, 0b111, 0b111}
+
+// drawText draws a line of text with its top-left corner at p, clipped to
+// clip.
+func drawText(dst *image.RGBA, clip image.Rectangle, p image.Point, text string, c color.Color) {
+	src := image.NewUniform(c)
+	for _, r := range text {
+		g, ok := glyphs[unicode.ToUpper(r)]
+		if !ok {
+			g = unknownGlyph
+		}
+		for row, bits := range g {
+			for col := range glyphWidth {
+				if bits&(1<<(glyphWidth-1-col)) == 0 {
+					continue
+				}
+				pixel := image.Rect(0, 0, glyphScale, glyphScale).
+					Add(p).Add(image.Pt(col*glyphScale, row*glyphScale))
+				draw.Draw(dst, pixel.Intersect(clip), src, image.Point{}, draw.Src)
+			}
+		}
+		p.X += GlyphAdvance
+	}
+}
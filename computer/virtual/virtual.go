@@ -0,0 +1,646 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package virtual provides a simulated computer.Computer, which needs neither
+// a browser nor a display, for deterministic tests of computer-use flows.
+//
+// A Desktop renders a scripted UI, made of labels, buttons and text fields,
+// into an in-memory framebuffer, and returns real PNG screenshots of it. The
+// actions of the model are applied to the elements under the pointer: clicks
+// focus buttons and text fields and run their handlers, typed text goes to
+// the focused text field, drags move draggable elements, and scrolls move
+// the page. Every action is recorded in a transcript.
+//
+// Example:
+//
+//	desktop := virtual.New(virtual.Options{})
+//	desktop.Add(
+//		virtual.Element{ID: "query", Kind: virtual.ElementTextField, Bounds: image.Rect(10, 10, 210, 40)},
+//		virtual.Element{ID: "search", Kind: virtual.ElementButton, Bounds: image.Rect(220, 10, 300, 40),
+//			Text: "Search", OnClick: func(d *virtual.Desktop) {
+//				d.Add(virtual.Element{ID: "result", Bounds: image.Rect(10, 60, 300, 80), Text: "Found: " + d.Text("query")})
+//			}},
+//	)
+//	agent := agents.New("Assistant").
+//		WithTools(agents.ComputerTool{Computer: desktop}).
+//		WithModelInstance(fakeModel)
+package virtual
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/nlpodyssey/openai-agents-go/computer"
+)
+
+// DefaultDimensions are the default dimensions of the screen.
+var DefaultDimensions = computer.Dimensions{Width: 1024, Height: 768}
+
+var (
+	defaultBackground = color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+	textColor         = color.RGBA{A: 0xff}
+	buttonColor       = color.RGBA{R: 0xdd, G: 0xdd, B: 0xdd, A: 0xff}
+	borderColor       = color.RGBA{R: 0x88, G: 0x88, B: 0x88, A: 0xff}
+	focusColor        = color.RGBA{R: 0x1a, G: 0x73, B: 0xe8, A: 0xff}
+)
+
+// ElementKind is the kind of an Element, which determines how it is drawn
+// and how it reacts to the actions.
+type ElementKind string
+
+const (
+	// ElementLabel is a static text.
+	ElementLabel ElementKind = "label"
+
+	// ElementButton is a focusable element, clicked with the pointer or by
+	// pressing Enter or Space while focused.
+	ElementButton ElementKind = "button"
+
+	// ElementTextField is a focusable element receiving the text typed while
+	// focused, and submitted by pressing Enter.
+	ElementTextField ElementKind = "text_field"
+)
+
+// Element is an element of the UI of a Desktop.
+type Element struct {
+	// Identifies the element in the transcript and in the methods of Desktop.
+	ID string
+
+	// Default: ElementLabel.
+	Kind ElementKind
+
+	// The position of the element on the page, which is scrolled in the
+	// screen. Elements added later are drawn on top of the previous ones.
+	Bounds image.Rectangle
+
+	// The text shown on the element, or the value of a text field.
+	Text string
+
+	// Optional background color, replacing the default one of the kind.
+	Color color.Color
+
+	// Whether the element can be moved with Drag.
+	Draggable bool
+
+	// Optional handler of left clicks on the element.
+	OnClick func(*Desktop)
+
+	// Optional handler of double clicks on the element.
+	OnDoubleClick func(*Desktop)
+
+	// Optional handler of the Enter key pressed on a focused text field.
+	OnSubmit func(*Desktop)
+}
+
+func (e *Element) focusable() bool {
+	return e.Kind == ElementButton || e.Kind == ElementTextField
+}
+
+// Action is an action performed on a Desktop, as recorded in its transcript.
+type Action struct {
+	// The type of the action, named after the computer-use action types:
+	// "click", "double_click", "scroll", "type", "wait", "move", "keypress"
+	// or "drag".
+	Type string
+
+	// The position of the pointer, for "click", "double_click", "scroll" and
+	// "move".
+	X, Y int64
+
+	// The button, for "click".
+	Button computer.Button
+
+	// The scroll distance, for "scroll".
+	ScrollX, ScrollY int64
+
+	// The text, for "type".
+	Text string
+
+	// The keys, for "keypress".
+	Keys []string
+
+	// The path, for "drag".
+	Path []computer.Position
+
+	// The ID of the element the action was applied to: the element under the
+	// pointer (or at the start of a drag), or the focused element for "type"
+	// and "keypress". Empty if none.
+	Target string
+}
+
+// String returns a compact representation of the action, such as
+// `click(10, 20, left) -> "submit"`.
+func (a Action) String() string {
+	var args []string
+	switch a.Type {
+	case "click":
+		args = []string{fmt.Sprint(a.X), fmt.Sprint(a.Y), string(a.Button)}
+	case "double_click", "move":
+		args = []string{fmt.Sprint(a.X), fmt.Sprint(a.Y)}
+	case "scroll":
+		args = []string{fmt.Sprint(a.X), fmt.Sprint(a.Y), fmt.Sprint(a.ScrollX), fmt.Sprint(a.ScrollY)}
+	case "type":
+		args = []string{strconv.Quote(a.Text)}
+	case "keypress":
+		args = []string{strings.Join(a.Keys, "+")}
+	case "drag":
+		for _, p := range a.Path {
+			args = append(args, fmt.Sprintf("(%d, %d)", p.X, p.Y))
+		}
+	}
+	s := a.Type + "(" + strings.Join(args, ", ") + ")"
+	if a.Target != "" {
+		s += " -> " + strconv.Quote(a.Target)
+	}
+	return s
+}
+
+// Options configures a Desktop.
+type Options struct {
+	// The dimensions of the screen. Default: DefaultDimensions.
+	Dimensions computer.Dimensions
+
+	// The environment reported to the model. Default: computer.EnvironmentLinux.
+	Environment computer.Environment
+
+	// The background color of the page. Default: white.
+	Background color.Color
+}
+
+// Desktop is a computer.Computer simulating a screen showing a scripted UI.
+// It is safe for concurrent use.
+//
+// The handlers of the elements are called after the action is applied, and
+// may use the methods of the Desktop to update the UI. Screenshots are not
+// recorded in the transcript.
+type Desktop struct {
+	dimensions  computer.Dimensions
+	environment computer.Environment
+	background  color.Color
+
+	mu         sync.Mutex
+	elements   []*Element
+	focused    *Element
+	scroll     image.Point
+	transcript []Action
+}
+
+var _ computer.Computer = (*Desktop)(nil)
+
+// New returns a Desktop showing an empty page.
+func New(opts Options) *Desktop {
+	d := &Desktop{
+		dimensions:  opts.Dimensions,
+		environment: opts.Environment,
+		background:  opts.Background,
+	}
+	if d.dimensions.Width <= 0 || d.dimensions.Height <= 0 {
+		d.dimensions = DefaultDimensions
+	}
+	if d.environment == "" {
+		d.environment = computer.EnvironmentLinux
+	}
+	if d.background == nil {
+		d.background = defaultBackground
+	}
+	return d
+}
+
+// Add adds elements to the page, on top of the existing ones. Elements with
+// the ID of an existing element replace it.
+func (d *Desktop) Add(elements ...Element) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, e := range elements {
+		if e.Kind == "" {
+			e.Kind = ElementLabel
+		}
+		if i := d.index(e.ID); i >= 0 && e.ID != "" {
+			if d.focused == d.elements[i] {
+				d.focused = nil
+			}
+			d.elements = slices.Delete(d.elements, i, i+1)
+		}
+		d.elements = append(d.elements, &e)
+	}
+}
+
+// Remove removes the element with the given ID, if any.
+func (d *Desktop) Remove(id string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if i := d.index(id); i >= 0 {
+		if d.focused == d.elements[i] {
+			d.focused = nil
+		}
+		d.elements = slices.Delete(d.elements, i, i+1)
+	}
+}
+
+// Element returns a copy of the element with the given ID.
+func (d *Desktop) Element(id string) (Element, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if i := d.index(id); i >= 0 {
+		return *d.elements[i], true
+	}
+	return Element{}, false
+}
+
+// Text returns the text of the element with the given ID, or an empty string.
+func (d *Desktop) Text(id string) string {
+	e, _ := d.Element(id)
+	return e.Text
+}
+
+// SetText sets the text of the element with the given ID, reporting whether
+// it exists.
+func (d *Desktop) SetText(id, text string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if i := d.index(id); i >= 0 {
+		d.elements[i].Text = text
+		return true
+	}
+	return false
+}
+
+// Focused returns the ID of the focused element, or an empty string.
+func (d *Desktop) Focused() string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return id(d.focused)
+}
+
+// ScrollPosition returns the position of the page shown at the top-left
+// corner of the screen.
+func (d *Desktop) ScrollPosition() image.Point {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.scroll
+}
+
+// Transcript returns the actions performed so far.
+func (d *Desktop) Transcript() []Action {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return slices.Clone(d.transcript)
+}
+
+// Image renders the screen.
+func (d *Desktop) Image() *image.RGBA {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.render()
+}
+
+func (d *Desktop) Environment(context.Context) (computer.Environment, error) {
+	return d.environment, nil
+}
+
+func (d *Desktop) Dimensions(context.Context) (computer.Dimensions, error) {
+	return d.dimensions, nil
+}
+
+// Screenshot returns a base64-encoded PNG image of the screen.
+func (d *Desktop) Screenshot(context.Context) (string, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, d.Image()); err != nil {
+		return "", fmt.Errorf("screenshot error: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// Click focuses the element under the pointer, if focusable, and runs its
+// OnClick handler. Only the left button has effects.
+func (d *Desktop) Click(_ context.Context, x, y int64, button computer.Button) error {
+	var handlers []func(*Desktop)
+	d.mu.Lock()
+	target := d.hit(x, y)
+	d.record(Action{Type: "click", X: x, Y: y, Button: button, Target: id(target)})
+	if button == computer.ButtonLeft {
+		d.focus(target)
+		if target != nil {
+			handlers = appendHandler(handlers, target.OnClick)
+		}
+	}
+	d.mu.Unlock()
+	d.run(handlers)
+	return nil
+}
+
+// DoubleClick focuses the element under the pointer, if focusable, and runs
+// its OnDoubleClick handler.
+func (d *Desktop) DoubleClick(_ context.Context, x, y int64) error {
+	var handlers []func(*Desktop)
+	d.mu.Lock()
+	target := d.hit(x, y)
+	d.record(Action{Type: "double_click", X: x, Y: y, Target: id(target)})
+	d.focus(target)
+	if target != nil {
+		handlers = appendHandler(handlers, target.OnDoubleClick)
+	}
+	d.mu.Unlock()
+	d.run(handlers)
+	return nil
+}
+
+// Scroll scrolls the page, within its bounds. The page extends to the
+// bottom-right corner of the farthest element.
+func (d *Desktop) Scroll(_ context.Context, x, y int64, scrollX, scrollY int64) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.record(Action{Type: "scroll", X: x, Y: y, ScrollX: scrollX, ScrollY: scrollY, Target: id(d.hit(x, y))})
+
+	page := image.Rect(0, 0, int(d.dimensions.Width), int(d.dimensions.Height))
+	for _, e := range d.elements {
+		page = page.Union(e.Bounds)
+	}
+	maxScroll := page.Max.Sub(image.Pt(int(d.dimensions.Width), int(d.dimensions.Height)))
+	d.scroll.X = min(max(d.scroll.X+int(scrollX), 0), maxScroll.X)
+	d.scroll.Y = min(max(d.scroll.Y+int(scrollY), 0), maxScroll.Y)
+	return nil
+}
+
+// Type appends the text to the focused text field, if any. A newline
+// submits the text field.
+func (d *Desktop) Type(_ context.Context, text string) error {
+	var handlers []func(*Desktop)
+	d.mu.Lock()
+	d.record(Action{Type: "type", Text: text, Target: id(d.focused)})
+	if e := d.focused; e != nil && e.Kind == ElementTextField {
+		for _, r := range text {
+			if r == '\n' {
+				handlers = appendHandler(handlers, e.OnSubmit)
+			} else {
+				e.Text += string(r)
+			}
+		}
+	}
+	d.mu.Unlock()
+	d.run(handlers)
+	return nil
+}
+
+func (d *Desktop) Wait(context.Context) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.record(Action{Type: "wait"})
+	return nil
+}
+
+func (d *Desktop) Move(_ context.Context, x, y int64) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.record(Action{Type: "move", X: x, Y: y, Target: id(d.hit(x, y))})
+	return nil
+}
+
+// Keypress applies a key, possibly combined with Shift, to the focused
+// element. Key names are matched case-insensitively:
+//
+//   - Enter submits the focused text field, or clicks the focused button.
+//   - Space types a space, or clicks the focused button.
+//   - Backspace deletes the last character of the focused text field.
+//   - Tab focuses the next focusable element (the previous one with Shift).
+//   - Escape removes the focus.
+//   - Single characters are typed into the focused text field.
+//
+// Other keys and combinations only appear in the transcript.
+func (d *Desktop) Keypress(_ context.Context, keys []string) error {
+	var handlers []func(*Desktop)
+	d.mu.Lock()
+	d.record(Action{Type: "keypress", Keys: slices.Clone(keys), Target: id(d.focused)})
+
+	shift := false
+	var pressed []string
+	for _, key := range keys {
+		if strings.EqualFold(key, "shift") {
+			shift = true
+		} else {
+			pressed = append(pressed, key)
+		}
+	}
+	if len(pressed) != 1 {
+		d.mu.Unlock()
+		return nil
+	}
+
+	key := pressed[0]
+	e := d.focused
+	isTextField := e != nil && e.Kind == ElementTextField
+	isButton := e != nil && e.Kind == ElementButton
+	switch strings.ToLower(key) {
+	case "enter", "return":
+		if isTextField {
+			handlers = appendHandler(handlers, e.OnSubmit)
+		} else if isButton {
+			handlers = appendHandler(handlers, e.OnClick)
+		}
+	case "space":
+		if isTextField {
+			e.Text += " "
+		} else if isButton {
+			handlers = appendHandler(handlers, e.OnClick)
+		}
+	case "backspace":
+		if isTextField && e.Text != "" {
+			_, size := utf8.DecodeLastRuneInString(e.Text)
+			e.Text = e.Text[:len(e.Text)-size]
+		}
+	case "tab":
+		d.focusNext(shift)
+	case "esc", "escape":
+		d.focused = nil
+	default:
+		if isTextField && utf8.RuneCountInString(key) == 1 {
+			if shift {
+				key = strings.ToUpper(key)
+			}
+			e.Text += key
+		}
+	}
+	d.mu.Unlock()
+	d.run(handlers)
+	return nil
+}
+
+// Drag moves the element at the start of the path, if draggable, by the
+// distance between the start and the end of the path.
+func (d *Desktop) Drag(_ context.Context, path []computer.Position) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if len(path) == 0 {
+		d.record(Action{Type: "drag"})
+		return nil
+	}
+	start, end := path[0], path[len(path)-1]
+	target := d.hit(start.X, start.Y)
+	d.record(Action{Type: "drag", Path: slices.Clone(path), Target: id(target)})
+	if target != nil && target.Draggable {
+		target.Bounds = target.Bounds.Add(image.Pt(int(end.X-start.X), int(end.Y-start.Y)))
+	}
+	return nil
+}
+
+func (d *Desktop) record(action Action) {
+	d.transcript = append(d.transcript, action)
+}
+
+func (d *Desktop) run(handlers []func(*Desktop)) {
+	for _, h := range handlers {
+		h(d)
+	}
+}
+
+func appendHandler(handlers []func(*Desktop), h func(*Desktop)) []func(*Desktop) {
+	if h == nil {
+		return handlers
+	}
+	return append(handlers, h)
+}
+
+func (d *Desktop) index(id string) int {
+	return slices.IndexFunc(d.elements, func(e *Element) bool { return e.ID == id })
+}
+
+func id(e *Element) string {
+	if e == nil {
+		return ""
+	}
+	return e.ID
+}
+
+// hit returns the topmost element at the given screen position, if any.
+func (d *Desktop) hit(x, y int64) *Element {
+	if x < 0 || y < 0 || x >= d.dimensions.Width || y >= d.dimensions.Height {
+		return nil
+	}
+	p := image.Pt(int(x), int(y)).Add(d.scroll)
+	for _, e := range slices.Backward(d.elements) {
+		if p.In(e.Bounds) {
+			return e
+		}
+	}
+	return nil
+}
+
+// focus focuses the element, if focusable, or removes the focus.
+func (d *Desktop) focus(e *Element) {
+	if e != nil && e.focusable() {
+		d.focused = e
+	} else {
+		d.focused = nil
+	}
+}
+
+func (d *Desktop) focusNext(backward bool) {
+	var focusable []*Element
+	for _, e := range d.elements {
+		if e.focusable() {
+			focusable = append(focusable, e)
+		}
+	}
+	if len(focusable) == 0 {
+		return
+	}
+	i := slices.Index(focusable, d.focused)
+	switch {
+	case i < 0 && backward:
+		i = len(focusable) - 1
+	case i < 0:
+		i = 0
+	case backward:
+		i = (i + len(focusable) - 1) % len(focusable)
+	default:
+		i = (i + 1) % len(focusable)
+	}
+	d.focused = focusable[i]
+}
+
+func (d *Desktop) render() *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, int(d.dimensions.Width), int(d.dimensions.Height)))
+	draw.Draw(img, img.Bounds(), image.NewUniform(d.background), image.Point{}, draw.Src)
+
+	for _, e := range d.elements {
+		r := e.Bounds.Sub(d.scroll)
+		clip := r.Intersect(img.Bounds())
+		if clip.Empty() {
+			continue
+		}
+
+		fill := e.Color
+		if fill == nil {
+			switch e.Kind {
+			case ElementButton:
+				fill = buttonColor
+			case ElementTextField:
+				fill = defaultBackground
+			}
+		}
+		if fill != nil {
+			draw.Draw(img, clip, image.NewUniform(fill), image.Point{}, draw.Src)
+		}
+
+		textWidth := utf8.RuneCountInString(e.Text)*GlyphAdvance - glyphScale
+		textY := r.Min.Y + (r.Dy()-glyphHeight*glyphScale)/2
+		switch e.Kind {
+		case ElementButton:
+			drawBorder(img, r, clip, borderColor)
+			if e == d.focused {
+				drawBorder(img, r.Inset(1), clip, focusColor)
+			}
+			drawText(img, clip, image.Pt(r.Min.X+(r.Dx()-textWidth)/2, textY), e.Text, textColor)
+		case ElementTextField:
+			textX := r.Min.X + 2*glyphScale
+			if e == d.focused {
+				drawBorder(img, r, clip, focusColor)
+				drawBorder(img, r.Inset(1), clip, focusColor)
+				caret := image.Rect(0, 0, glyphScale/2, glyphHeight*glyphScale).
+					Add(image.Pt(textX+textWidth+glyphScale, textY))
+				draw.Draw(img, caret.Intersect(clip), image.NewUniform(textColor), image.Point{}, draw.Src)
+			} else {
+				drawBorder(img, r, clip, borderColor)
+			}
+			drawText(img, clip, image.Pt(textX, textY), e.Text, textColor)
+		default:
+			drawText(img, clip, r.Min, e.Text, textColor)
+		}
+	}
+	return img
+}
+
+// drawBorder draws a 1-pixel border inside r, clipped to clip.
+func drawBorder(dst *image.RGBA, r, clip image.Rectangle, c color.Color) {
+	src := image.NewUniform(c)
+	for _, side := range []image.Rectangle{
+		image.Rect(r.Min.X, r.Min.Y, r.Max.X, r.Min.Y+1),
+		image.Rect(r.Min.X, r.Max.Y-1, r.Max.X, r.Max.Y),
+		image.Rect(r.Min.X, r.Min.Y, r.Min.X+1, r.Max.Y),
+		image.Rect(r.Max.X-1, r.Min.Y, r.Max.X, r.Max.Y),
+	} {
+		draw.Draw(dst, side.Intersect(clip), src, image.Point{}, draw.Src)
+	}
+}
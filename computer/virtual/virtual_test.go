@@ -0,0 +1,203 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package virtual_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/agentstesting"
+	"github.com/nlpodyssey/openai-agents-go/computer"
+	"github.com/nlpodyssey/openai-agents-go/computer/virtual"
+	"github.com/openai/openai-go/responses"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var red = color.RGBA{R: 0xff, A: 0xff}
+
+// newSearchDesktop returns a desktop with a search form, showing the query
+// in a "result" label when submitted.
+func newSearchDesktop() *virtual.Desktop {
+	d := virtual.New(virtual.Options{Dimensions: computer.Dimensions{Width: 320, Height: 240}})
+	search := func(d *virtual.Desktop) {
+		d.Add(virtual.Element{ID: "result", Bounds: image.Rect(10, 60, 310, 80), Text: "Results for " + d.Text("query")})
+	}
+	d.Add(
+		virtual.Element{ID: "query", Kind: virtual.ElementTextField, Bounds: image.Rect(10, 10, 210, 40), OnSubmit: search},
+		virtual.Element{ID: "search", Kind: virtual.ElementButton, Bounds: image.Rect(220, 10, 310, 40), Text: "Search", OnClick: search},
+	)
+	return d
+}
+
+func decodeScreenshot(t *testing.T, screenshot string) image.Image {
+	t.Helper()
+	pngBytes, err := base64.StdEncoding.DecodeString(screenshot)
+	require.NoError(t, err)
+	img, err := png.Decode(bytes.NewReader(pngBytes))
+	require.NoError(t, err)
+	return img
+}
+
+func assertColor(t *testing.T, expected color.Color, img image.Image, x, y int) {
+	t.Helper()
+	assert.Equal(t, color.RGBAModel.Convert(expected), color.RGBAModel.Convert(img.At(x, y)), "pixel (%d, %d)", x, y)
+}
+
+func TestDesktopScreenshot(t *testing.T) {
+	d := virtual.New(virtual.Options{})
+	d.Add(virtual.Element{ID: "box", Bounds: image.Rect(10, 20, 30, 40), Color: red})
+
+	env, err := d.Environment(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, computer.EnvironmentLinux, env)
+	dimensions, err := d.Dimensions(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, virtual.DefaultDimensions, dimensions)
+
+	screenshot, err := d.Screenshot(t.Context())
+	require.NoError(t, err)
+	img := decodeScreenshot(t, screenshot)
+	assert.Equal(t, image.Rect(0, 0, 1024, 768), img.Bounds())
+	assertColor(t, red, img, 10, 20)
+	assertColor(t, red, img, 29, 39)
+	assertColor(t, color.White, img, 30, 40)
+	assertColor(t, color.White, img, 9, 20)
+
+	require.NoError(t, d.Drag(t.Context(), []computer.Position{{X: 15, Y: 25}, {X: 100, Y: 100}}))
+	assertColor(t, red, d.Image(), 10, 20)
+
+	d.Add(virtual.Element{ID: "box", Bounds: image.Rect(10, 20, 30, 40), Color: red, Draggable: true})
+	require.NoError(t, d.Drag(t.Context(), []computer.Position{{X: 15, Y: 25}, {X: 50, Y: 50}, {X: 115, Y: 125}}))
+	box, ok := d.Element("box")
+	require.True(t, ok)
+	assert.Equal(t, image.Rect(110, 120, 130, 140), box.Bounds)
+	img = d.Image()
+	assertColor(t, color.White, img, 10, 20)
+	assertColor(t, red, img, 110, 120)
+}
+
+func TestDesktopText(t *testing.T) {
+	d := virtual.New(virtual.Options{Dimensions: computer.Dimensions{Width: 100, Height: 20}})
+	assert.Equal(t, d.Image(), virtual.New(virtual.Options{Dimensions: computer.Dimensions{Width: 100, Height: 20}}).Image())
+
+	d.Add(virtual.Element{ID: "label", Bounds: image.Rect(0, 0, 100, 20), Text: "Hi"})
+	img := d.Image()
+	// The "H" is drawn from (0, 0), with a 2x2 pixel for each dot.
+	assertColor(t, color.Black, img, 0, 0)
+	assertColor(t, color.Black, img, 1, 9)
+	assertColor(t, color.White, img, 2, 0)
+	assertColor(t, color.Black, img, 2, 4)
+	// The "I" starts after GlyphAdvance pixels.
+	assertColor(t, color.Black, img, virtual.GlyphAdvance, 0)
+	assertColor(t, color.White, img, virtual.GlyphAdvance, 2)
+}
+
+func TestDesktopInteraction(t *testing.T) {
+	d := newSearchDesktop()
+	ctx := t.Context()
+
+	require.NoError(t, d.Type(ctx, "ignored"))
+	require.NoError(t, d.Click(ctx, 50, 20, computer.ButtonLeft))
+	assert.Equal(t, "query", d.Focused())
+	require.NoError(t, d.Type(ctx, "cats"))
+	require.NoError(t, d.Keypress(ctx, []string{"BACKSPACE"}))
+	require.NoError(t, d.Keypress(ctx, []string{"shift", "x"}))
+	require.NoError(t, d.Keypress(ctx, []string{"ctrl", "a"}))
+	assert.Equal(t, "catX", d.Text("query"))
+	_, ok := d.Element("result")
+	assert.False(t, ok)
+
+	require.NoError(t, d.Keypress(ctx, []string{"Enter"}))
+	assert.Equal(t, "Results for catX", d.Text("result"))
+
+	require.NoError(t, d.Keypress(ctx, []string{"tab"}))
+	assert.Equal(t, "search", d.Focused())
+	require.NoError(t, d.Keypress(ctx, []string{"esc"}))
+	assert.Equal(t, "", d.Focused())
+	require.NoError(t, d.Click(ctx, 20, 70, computer.ButtonRight))
+	require.NoError(t, d.Move(ctx, 300, 200))
+	require.NoError(t, d.Wait(ctx))
+
+	var transcript []string
+	for _, action := range d.Transcript() {
+		transcript = append(transcript, action.String())
+	}
+	assert.Equal(t, []string{
+		`type("ignored")`,
+		`click(50, 20, left) -> "query"`,
+		`type("cats") -> "query"`,
+		`keypress(BACKSPACE) -> "query"`,
+		`keypress(shift+x) -> "query"`,
+		`keypress(ctrl+a) -> "query"`,
+		`keypress(Enter) -> "query"`,
+		`keypress(tab) -> "query"`,
+		`keypress(esc) -> "search"`,
+		`click(20, 70, right) -> "result"`,
+		`move(300, 200)`,
+		`wait()`,
+	}, transcript)
+}
+
+func TestDesktopScroll(t *testing.T) {
+	d := virtual.New(virtual.Options{Dimensions: computer.Dimensions{Width: 100, Height: 100}})
+	d.Add(virtual.Element{ID: "far", Bounds: image.Rect(0, 250, 50, 300), Color: red})
+
+	require.NoError(t, d.Scroll(t.Context(), 10, 10, 0, 180))
+	assert.Equal(t, image.Pt(0, 180), d.ScrollPosition())
+	require.NoError(t, d.Scroll(t.Context(), 10, 80, 30, 100))
+	assert.Equal(t, image.Pt(0, 200), d.ScrollPosition())
+	assertColor(t, red, d.Image(), 0, 50)
+
+	require.NoError(t, d.Click(t.Context(), 10, 60, computer.ButtonLeft))
+	assert.Equal(t, `click(10, 60, left) -> "far"`, d.Transcript()[2].String())
+
+	require.NoError(t, d.Scroll(t.Context(), 10, 10, 0, -500))
+	assert.Equal(t, image.Pt(0, 0), d.ScrollPosition())
+}
+
+func TestDesktopWithComputerTool(t *testing.T) {
+	d := newSearchDesktop()
+
+	type Action = responses.ResponseOutputItemUnionAction
+	model := agentstesting.NewFakeModel(nil)
+	model.AddMultipleTurnOutputs([]agentstesting.FakeModelTurnOutput{
+		{Value: []agents.TResponseOutputItem{agentstesting.GetComputerToolCall(Action{Type: "click", X: 50, Y: 20, Button: "left"})}},
+		{Value: []agents.TResponseOutputItem{agentstesting.GetComputerToolCall(Action{Type: "type", Text: "go"})}},
+		{Value: []agents.TResponseOutputItem{agentstesting.GetComputerToolCall(Action{Type: "click", X: 250, Y: 25, Button: "left"})}},
+		{Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("done")}},
+	})
+	agent := agents.New("test").WithModelInstance(model).
+		WithTools(agents.ComputerTool{Computer: d})
+
+	result, err := agents.Run(t.Context(), agent, "Search for go")
+	require.NoError(t, err)
+	assert.Equal(t, "done", result.FinalOutput)
+	assert.Equal(t, "Results for go", d.Text("result"))
+	assert.Len(t, d.Transcript(), 3)
+
+	last := result.NewItems[len(result.NewItems)-2].(agents.ToolCallOutputItem).Output.(string)
+	screenshot, ok := strings.CutPrefix(last, "data:image/png;base64,")
+	require.True(t, ok)
+	img := decodeScreenshot(t, screenshot)
+	assert.Equal(t, image.Rect(0, 0, 320, 240), img.Bounds())
+	assertColor(t, color.Black, img, 10, 60) // the "R" of the result
+}
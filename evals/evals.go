@@ -0,0 +1,288 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package evals measures the quality of agents over datasets of cases.
+//
+// An Eval runs an agent over each Case of a dataset, usually loaded from a
+// JSONL file with LoadDataset, and applies a set of graders to the outputs:
+// exact match, JSON field match, regular expressions, tool call expectations
+// or a model acting as a judge. The resulting Report aggregates pass rates,
+// token usage, cost (of the agent runs and, separately, of the graders) and
+// latency, and can be saved as JSON, to be used as the
+// baseline of later runs, or rendered as Markdown with the differences from a
+// baseline.
+package evals
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/usage"
+)
+
+// DefaultConcurrency is the default number of cases an Eval runs at the same time.
+const DefaultConcurrency = 4
+
+// A Case is a single example of a dataset.
+type Case struct {
+	// A unique identifier of the case. Default: "case-N", N being the line
+	// number in the dataset file.
+	ID string `json:"id"`
+
+	// The input given to the agent.
+	Input string `json:"input"`
+
+	// The expected output, as a JSON string for plain text outputs, or as
+	// any other JSON value for structured outputs.
+	Expected json.RawMessage `json:"expected,omitempty"`
+
+	// The tool calls the agent is expected to make (see ToolCalls).
+	ExpectedTools []ToolCallExpectation `json:"expected_tools,omitempty"`
+
+	// Optional free-form data, available to custom graders.
+	Metadata map[string]any `json:"metadata,omitempty"`
+}
+
+// ExpectedText returns the expected output as text: the string itself if it
+// is a JSON string, or else the raw JSON.
+func (c Case) ExpectedText() string {
+	var s string
+	if err := json.Unmarshal(c.Expected, &s); err == nil {
+		return s
+	}
+	return string(c.Expected)
+}
+
+// LoadDataset reads a dataset from a JSONL file, with one Case per line.
+func LoadDataset(path string) ([]Case, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open dataset: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+	return ReadDataset(f)
+}
+
+// ReadDataset reads a dataset in JSONL format, with one Case per line.
+// Blank lines are ignored.
+func ReadDataset(r io.Reader) ([]Case, error) {
+	var cases []Case
+	ids := make(map[string]int)
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 16*1024*1024)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var c Case
+		if err := json.Unmarshal(line, &c); err != nil {
+			return nil, fmt.Errorf("dataset line %d: %w", lineNumber, err)
+		}
+		if c.ID == "" {
+			c.ID = fmt.Sprintf("case-%d", lineNumber)
+		}
+		if previous, ok := ids[c.ID]; ok {
+			return nil, fmt.Errorf("dataset line %d: duplicate case ID %q (first on line %d)", lineNumber, c.ID, previous)
+		}
+		ids[c.ID] = lineNumber
+		cases = append(cases, c)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read dataset: %w", err)
+	}
+	return cases, nil
+}
+
+// Pricing is the price of a model, in dollars per million tokens.
+type Pricing struct {
+	InputPerMillion float64
+
+	// The price of cached input tokens. Default: InputPerMillion.
+	CachedInputPerMillion float64
+
+	OutputPerMillion float64
+}
+
+// Cost returns the cost of the given usage.
+func (p Pricing) Cost(u usage.Usage) float64 {
+	cachedPrice := p.CachedInputPerMillion
+	if cachedPrice == 0 {
+		cachedPrice = p.InputPerMillion
+	}
+	cached := min(u.InputTokensDetails.CachedTokens, int64(u.InputTokens))
+	uncached := int64(u.InputTokens) - cached
+	return (float64(uncached)*p.InputPerMillion +
+		float64(cached)*cachedPrice +
+		float64(u.OutputTokens)*p.OutputPerMillion) / 1e6
+}
+
+// Eval runs an agent over the cases of a dataset, grading its outputs.
+type Eval struct {
+	// The name of the eval, reported in the Report.
+	Name string
+
+	// The agent to evaluate. Required.
+	Agent *agents.Agent
+
+	// The runner used to run the agent, with its configuration.
+	Runner agents.Runner
+
+	// The graders applied to each output. A case passes when all graders
+	// pass.
+	Graders []Grader
+
+	// The maximum number of cases running at the same time.
+	// Default: DefaultConcurrency.
+	Concurrency int
+
+	// Optional timeout of each case run.
+	Timeout time.Duration
+
+	// Optional pricing of the model, used to compute the cost of the runs.
+	Pricing Pricing
+
+	// Optional pricing of the models called by the graders (see LLMJudge),
+	// used to compute the grader cost of the cases. Default: Pricing.
+	GraderPricing Pricing
+}
+
+// Run runs the eval over the given cases. Failed agent runs are reported in
+// the results; an error is returned only if the eval cannot run at all, or
+// if ctx is canceled.
+func (e Eval) Run(ctx context.Context, cases []Case) (*Report, error) {
+	if e.Agent == nil {
+		return nil, fmt.Errorf("eval agent is not set")
+	}
+	concurrency := e.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+
+	results := make([]CaseResult, len(cases))
+	semaphore := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, c := range cases {
+		select {
+		case semaphore <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+			return nil, ctx.Err()
+		}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+			results[i] = e.runCase(ctx, c)
+		}()
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return NewReport(e.Name, results), nil
+}
+
+func (e Eval) runCase(ctx context.Context, c Case) CaseResult {
+	if e.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, e.Timeout)
+		defer cancel()
+	}
+
+	result := CaseResult{ID: c.ID, Input: c.Input}
+	start := time.Now()
+	runResult, err := e.Runner.Run(ctx, e.Agent, c.Input)
+	result.Latency = time.Since(start)
+
+	// The responses of a failed run are recorded in the error, if any.
+	var rawResponses []agents.ModelResponse
+	var agentsErr *agents.AgentsError
+	switch {
+	case runResult != nil:
+		rawResponses = runResult.RawResponses
+	case errors.As(err, &agentsErr) && agentsErr.RunData != nil:
+		rawResponses = agentsErr.RunData.RawResponses
+	}
+	u := usage.NewUsage()
+	for _, response := range rawResponses {
+		if response.Usage != nil {
+			u.Add(response.Usage)
+		}
+	}
+	result.InputTokens = u.InputTokens
+	result.OutputTokens = u.OutputTokens
+	result.Cost = e.Pricing.Cost(*u)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	sample := Sample{
+		Case:       c,
+		Output:     runResult.FinalOutput,
+		OutputText: OutputText(runResult.FinalOutput),
+		Result:     runResult,
+	}
+	result.Output = sample.OutputText
+	result.Pass = true
+	graderUsage := usage.NewUsage()
+	for _, grader := range e.Graders {
+		grade, err := grader.Grade(ctx, sample)
+		if err != nil {
+			grade = Grade{Reason: "grader error: " + err.Error(), Usage: grade.Usage}
+		}
+		if grade.Usage != nil {
+			graderUsage.Add(grade.Usage)
+		}
+		grade.Grader = grader.Name()
+		result.Grades = append(result.Grades, grade)
+		result.Pass = result.Pass && grade.Pass
+	}
+
+	graderPricing := e.GraderPricing
+	if graderPricing == (Pricing{}) {
+		graderPricing = e.Pricing
+	}
+	result.GraderInputTokens = graderUsage.InputTokens
+	result.GraderOutputTokens = graderUsage.OutputTokens
+	result.GraderCost = graderPricing.Cost(*graderUsage)
+	return result
+}
+
+// OutputText returns a final output as text: strings are returned as they
+// are, other values are encoded as JSON.
+func OutputText(output any) string {
+	if s, ok := output.(string); ok {
+		return s
+	}
+	b, err := json.Marshal(output)
+	if err != nil {
+		return fmt.Sprint(output)
+	}
+	return string(b)
+}
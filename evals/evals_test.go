@@ -0,0 +1,289 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evals_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"iter"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/agentstesting"
+	"github.com/nlpodyssey/openai-agents-go/evals"
+	"github.com/nlpodyssey/openai-agents-go/usage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// capitalsModel answers with the capital of the country in the input. It is
+// safe for concurrent use.
+type capitalsModel map[string]string
+
+func (m capitalsModel) GetResponse(_ context.Context, params agents.ModelResponseParams) (*agents.ModelResponse, error) {
+	input := params.Input.(agents.InputItems)
+	country := input[0].OfMessage.Content.OfString.Value
+	if country == "Atlantis" {
+		return nil, errors.New("no such country")
+	}
+	return &agents.ModelResponse{
+		Output: []agents.TResponseOutputItem{agentstesting.GetTextMessage(m[country])},
+		Usage:  &usage.Usage{Requests: 1, InputTokens: 1000, OutputTokens: 100, TotalTokens: 1100},
+	}, nil
+}
+
+func (capitalsModel) StreamResponse(context.Context, agents.ModelResponseParams) (iter.Seq2[*agents.TResponseStreamEvent, error], error) {
+	return nil, errors.New("not implemented")
+}
+
+const capitalsDataset = `{"id": "fr", "input": "France", "expected": "Paris"}
+
+{"input": "Italy", "expected": "Rome"}
+{"id": "de", "input": "Germany", "expected": "Berlin"}
+{"id": "at", "input": "Atlantis", "expected": "Poseidonia"}
+`
+
+func runCapitals(t *testing.T, model capitalsModel) *evals.Report {
+	t.Helper()
+	dataset, err := evals.ReadDataset(strings.NewReader(capitalsDataset))
+	require.NoError(t, err)
+
+	report, err := evals.Eval{
+		Name:        "capitals",
+		Agent:       agents.New("capitals").WithModelInstance(model),
+		Graders:     []evals.Grader{evals.ExactMatch{IgnoreCase: true}, evals.Regex{Pattern: regexp.MustCompile(`^\w+$`)}},
+		Concurrency: 2,
+		Pricing:     evals.Pricing{InputPerMillion: 2.5, OutputPerMillion: 10},
+	}.Run(t.Context(), dataset)
+	require.NoError(t, err)
+	return report
+}
+
+func TestReadDataset(t *testing.T) {
+	dataset, err := evals.ReadDataset(strings.NewReader(capitalsDataset))
+	require.NoError(t, err)
+	require.Len(t, dataset, 4)
+	assert.Equal(t, "case-3", dataset[1].ID)
+	assert.Equal(t, "Rome", dataset[1].ExpectedText())
+
+	_, err = evals.ReadDataset(strings.NewReader("{\"id\": \"a\"}\n{\"id\": \"a\"}\n"))
+	assert.ErrorContains(t, err, `dataset line 2: duplicate case ID "a" (first on line 1)`)
+
+	_, err = evals.ReadDataset(strings.NewReader("{\"id\": \"a\"}\nnot json\n"))
+	assert.ErrorContains(t, err, "dataset line 2")
+}
+
+func TestEvalRun(t *testing.T) {
+	report := runCapitals(t, capitalsModel{"France": "Paris", "Italy": "rome", "Germany": "Munich"})
+
+	require.Len(t, report.Results, 4)
+	assert.Equal(t, []bool{true, true, false, false}, []bool{
+		report.Results[0].Pass, report.Results[1].Pass, report.Results[2].Pass, report.Results[3].Pass,
+	})
+	assert.Equal(t, "no such country", report.Results[3].Error)
+	assert.Equal(t, `expected "Berlin", got "Munich"`, report.Results[2].Grades[0].Reason)
+
+	s := report.Summary
+	assert.Equal(t, 4, s.Cases)
+	assert.Equal(t, 2, s.Passed)
+	assert.Equal(t, 1, s.Errors)
+	assert.Equal(t, 0.5, s.PassRate)
+	assert.InDelta(t, 2.0/3, s.GraderPassRates["exact_match"], 1e-9)
+	assert.Equal(t, 1.0, s.GraderPassRates["regex"])
+	assert.Equal(t, uint64(3000), s.InputTokens)
+	assert.InDelta(t, 3*(1000*2.5+100*10)/1e6, s.Cost, 1e-12)
+}
+
+func TestReportBaseline(t *testing.T) {
+	baseline := runCapitals(t, capitalsModel{"France": "Paris", "Italy": "Rome", "Germany": "Munich"})
+	path := filepath.Join(t.TempDir(), "reports", "baseline.json")
+	require.NoError(t, baseline.WriteJSON(path))
+	baseline, err := evals.LoadReport(path)
+	require.NoError(t, err)
+
+	current := runCapitals(t, capitalsModel{"France": "Marseille", "Italy": "Rome", "Germany": "Berlin"})
+	comparison := evals.Compare(baseline, current)
+	assert.Equal(t, []string{"fr"}, comparison.Regressions)
+	assert.Equal(t, []string{"de"}, comparison.Fixes)
+	assert.Empty(t, comparison.Added)
+	require.Len(t, comparison.Changed, 2)
+	assert.Contains(t, comparison.Changed[0].Diff, "-Paris\n+Marseille\n")
+
+	markdown := current.Markdown(baseline)
+	assert.Contains(t, markdown, "# Eval report: capitals\n")
+	assert.Contains(t, markdown, "| Pass rate | 50.0% (2/4) | 50.0% (2/4) | +0.0 pp |")
+	assert.Contains(t, markdown, "## Regressions\n\n- fr\n")
+	assert.Contains(t, markdown, "## Fixes\n\n- de\n")
+	assert.Contains(t, markdown, "```diff\n--- baseline\n+++ current\n")
+	assert.Contains(t, markdown, "| fr | fail | exact_match: expected \"Paris\", got \"Marseille\" |")
+	assert.Contains(t, markdown, "| at | error | no such country |")
+
+	mdPath := filepath.Join(t.TempDir(), "report.md")
+	require.NoError(t, current.WriteMarkdown(mdPath, nil))
+}
+
+func TestJSONFieldMatch(t *testing.T) {
+	sample := evals.Sample{
+		Case: evals.Case{
+			ID:       "1",
+			Expected: json.RawMessage(`{"city": "Rome", "address": {"zip": "00100"}, "temp": 20}`),
+		},
+		OutputText: "Here it is:\n```json\n{\"city\": \"Rome\", \"address\": {\"zip\": \"00199\"}, \"temp\": 20.0}\n```",
+	}
+
+	grade, err := evals.JSONFieldMatch{}.Grade(t.Context(), sample)
+	require.NoError(t, err)
+	assert.False(t, grade.Pass)
+	assert.InDelta(t, 2.0/3, grade.Score, 1e-9)
+	assert.Equal(t, `address: expected {"zip":"00100"}, got {"zip":"00199"}`, grade.Reason)
+
+	grade, err = evals.JSONFieldMatch{Fields: []string{"city", "temp", "address.country"}}.Grade(t.Context(), sample)
+	require.NoError(t, err)
+	assert.Equal(t, "address.country: missing", grade.Reason)
+}
+
+func TestToolCallsGrader(t *testing.T) {
+	model := agentstesting.NewFakeModel(nil)
+	model.AddMultipleTurnOutputs([]agentstesting.FakeModelTurnOutput{
+		{Value: []agents.TResponseOutputItem{
+			agentstesting.GetFunctionToolCall("search", `{"query": "flights", "limit": 5}`),
+			agentstesting.GetFunctionToolCall("book", `{"flight": "AZ123"}`),
+		}},
+		{Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("Booked.")}},
+	})
+	agent := agents.New("test").WithModelInstance(model).WithTools(
+		agentstesting.GetFunctionTool("search", "AZ123"),
+		agentstesting.GetFunctionTool("book", "ok"),
+	)
+	result, err := agents.Run(t.Context(), agent, "Book a flight")
+	require.NoError(t, err)
+
+	sample := evals.Sample{
+		Case: evals.Case{ExpectedTools: []evals.ToolCallExpectation{
+			{Name: "book"},
+			{Name: "search", Arguments: map[string]any{"query": "flights"}},
+		}},
+		Result: result,
+	}
+	grade, err := evals.ToolCalls{}.Grade(t.Context(), sample)
+	require.NoError(t, err)
+	assert.True(t, grade.Pass)
+
+	grade, err = evals.ToolCalls{Ordered: true}.Grade(t.Context(), sample)
+	require.NoError(t, err)
+	assert.False(t, grade.Pass)
+	assert.Equal(t, 0.5, grade.Score)
+	assert.Equal(t, `missing calls: search{"query":"flights"}`, grade.Reason)
+
+	sample.Case.ExpectedTools = sample.Case.ExpectedTools[1:]
+	grade, err = evals.ToolCalls{Exact: true}.Grade(t.Context(), sample)
+	require.NoError(t, err)
+	assert.Equal(t, `unexpected calls: book({"flight": "AZ123"})`, grade.Reason)
+}
+
+type verdict struct {
+	Correct   bool    `json:"correct"`
+	Score     float64 `json:"score"`
+	Reasoning string  `json:"reasoning"`
+}
+
+func TestLLMJudge(t *testing.T) {
+	judgeModel := agentstesting.NewFakeModel(&agentstesting.FakeModelTurnOutput{
+		Value: []agents.TResponseOutputItem{
+			agentstesting.GetTextMessage(`{"correct": true, "score": 0.8, "reasoning": "close enough"}`),
+		},
+	})
+	grader := evals.LLMJudge(evals.JudgeParams[verdict]{
+		Model:        judgeModel,
+		Instructions: "Judge the answer.",
+		Pass:         func(v verdict) bool { return v.Correct },
+		Score:        func(v verdict) float64 { return v.Score },
+		Reason:       func(v verdict) string { return v.Reasoning },
+	})
+	assert.Equal(t, "judge", grader.Name())
+
+	grade, err := grader.Grade(t.Context(), evals.Sample{
+		Case:       evals.Case{Input: "Capital of Italy?", Expected: json.RawMessage(`"Rome"`)},
+		OutputText: "It's Rome.",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, evals.Grade{Pass: true, Score: 0.8, Reason: "close enough", Usage: grade.Usage}, grade)
+	assert.NotNil(t, grade.Usage, "the usage of the judge is reported")
+
+	args := judgeModel.LastTurnArgs
+	assert.Equal(t, "Judge the answer.", args.SystemInstructions.Value)
+	assert.Equal(t, agents.InputString("# Input\nCapital of Italy?\n\n# Expected output\nRome\n\n# Output\nIt's Rome.\n"), args.Input)
+	require.NotNil(t, args.OutputSchema)
+	assert.Equal(t, "verdict", args.OutputSchema.Name())
+	assert.True(t, args.OutputSchema.IsStrictJSONSchema())
+}
+
+func TestEvalRunUsage(t *testing.T) {
+	// The agent calls a tool forever, until the maximum number of turns.
+	agentModel := agentstesting.NewFakeModel(nil)
+	agentModel.SetHardcodedUsage(usage.Usage{Requests: 1, InputTokens: 1000, OutputTokens: 100, TotalTokens: 1100})
+	agentModel.AddMultipleTurnOutputs([]agentstesting.FakeModelTurnOutput{
+		{Value: []agents.TResponseOutputItem{agentstesting.GetFunctionToolCall("search", `{}`)}},
+		{Value: []agents.TResponseOutputItem{agentstesting.GetFunctionToolCall("search", `{}`)}},
+	})
+	agent := agents.New("test").
+		WithModelInstance(agentModel).
+		WithTools(agentstesting.GetFunctionTool("search", "nothing"))
+
+	judgeModel := agentstesting.NewFakeModel(&agentstesting.FakeModelTurnOutput{
+		Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage(`{"correct": true}`)},
+	})
+	judgeModel.SetHardcodedUsage(usage.Usage{Requests: 1, InputTokens: 500, OutputTokens: 10, TotalTokens: 510})
+	judge := evals.LLMJudge(evals.JudgeParams[verdict]{
+		Model:        judgeModel,
+		Instructions: "Judge the answer.",
+		Pass:         func(v verdict) bool { return v.Correct },
+	})
+
+	e := evals.Eval{
+		Agent:         agent,
+		Runner:        agents.Runner{Config: agents.RunConfig{MaxTurns: 2}},
+		Graders:       []evals.Grader{judge},
+		Pricing:       evals.Pricing{InputPerMillion: 2.5, OutputPerMillion: 10},
+		GraderPricing: evals.Pricing{InputPerMillion: 1, OutputPerMillion: 4},
+	}
+	report, err := e.Run(t.Context(), []evals.Case{{ID: "loop", Input: "Search"}})
+	require.NoError(t, err)
+
+	result := report.Results[0]
+	assert.Contains(t, result.Error, "max turns")
+	assert.Equal(t, uint64(2000), result.InputTokens, "the usage of the failed run is reported")
+	assert.InDelta(t, 2*(1000*2.5+100*10)/1e6, result.Cost, 1e-12)
+	assert.Zero(t, result.GraderCost)
+
+	agentModel.AddMultipleTurnOutputs([]agentstesting.FakeModelTurnOutput{
+		{Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("Found nothing.")}},
+	})
+	report, err = e.Run(t.Context(), []evals.Case{{ID: "ok", Input: "Search"}})
+	require.NoError(t, err)
+
+	result = report.Results[0]
+	require.True(t, result.Pass)
+	assert.Equal(t, uint64(1000), result.InputTokens)
+	assert.Equal(t, uint64(500), result.GraderInputTokens)
+	assert.Equal(t, uint64(10), result.GraderOutputTokens)
+	assert.InDelta(t, (500*1+10*4)/1e6, result.GraderCost, 1e-12)
+	assert.InDelta(t, result.GraderCost, report.Summary.GraderCost, 1e-12)
+	assert.Contains(t, report.Markdown(nil), "| Grader cost | $0.0005 |")
+}
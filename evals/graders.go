@@ -0,0 +1,306 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evals
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/usage"
+)
+
+// A Sample is the output of the agent for a case, to be graded.
+type Sample struct {
+	Case Case
+
+	// The final output of the run.
+	Output any
+
+	// The final output as text (see OutputText).
+	OutputText string
+
+	// The full result of the run.
+	Result *agents.RunResult
+}
+
+// A Grade is the evaluation of a sample by a grader.
+type Grade struct {
+	// The name of the grader.
+	Grader string `json:"grader"`
+	Pass   bool   `json:"pass"`
+	// A score between 0 and 1. Graders without a notion of partial
+	// success score 1 when passing, 0 otherwise.
+	Score float64 `json:"score"`
+	// An explanation of the grade, mostly useful on failures.
+	Reason string `json:"reason,omitempty"`
+	// The usage of the model calls made by the grader, if any. It is
+	// reported in the grader tokens and cost of the case.
+	Usage *usage.Usage `json:"-"`
+}
+
+// A Grader evaluates samples.
+type Grader interface {
+	// Name returns the name identifying the grader in reports.
+	Name() string
+	// Grade evaluates a sample. On error, only the Usage of the returned
+	// Grade is considered.
+	Grade(ctx context.Context, sample Sample) (Grade, error)
+}
+
+func passFail(pass bool, reason string) Grade {
+	if pass {
+		return Grade{Pass: true, Score: 1}
+	}
+	return Grade{Reason: reason}
+}
+
+// ExactMatch passes when the output text is equal to the expected text of
+// the case.
+type ExactMatch struct {
+	IgnoreCase bool
+	// Ignore leading and trailing white space.
+	TrimSpace bool
+}
+
+func (ExactMatch) Name() string { return "exact_match" }
+
+func (g ExactMatch) Grade(_ context.Context, sample Sample) (Grade, error) {
+	got, want := sample.OutputText, sample.Case.ExpectedText()
+	if g.TrimSpace {
+		got, want = strings.TrimSpace(got), strings.TrimSpace(want)
+	}
+	pass := got == want || (g.IgnoreCase && strings.EqualFold(got, want))
+	return passFail(pass, fmt.Sprintf("expected %q, got %q", want, got)), nil
+}
+
+// JSONFieldMatch passes when the fields of the output, decoded as JSON, are
+// equal to the ones of the expected output of the case. The score is the
+// fraction of matching fields.
+//
+// JSON is extracted leniently from text outputs (see agents.ExtractJSON).
+type JSONFieldMatch struct {
+	// The fields to compare, as dot-separated paths such as "address.city".
+	// Default: the top-level fields of the expected output.
+	Fields []string
+}
+
+func (JSONFieldMatch) Name() string { return "json_fields" }
+
+func (g JSONFieldMatch) Grade(_ context.Context, sample Sample) (Grade, error) {
+	var expected any
+	if err := json.Unmarshal(sample.Case.Expected, &expected); err != nil {
+		return Grade{}, fmt.Errorf("case %s: invalid expected JSON: %w", sample.Case.ID, err)
+	}
+
+	text, ok := agents.ExtractJSON(sample.OutputText)
+	if !ok {
+		return Grade{Reason: "output is not JSON"}, nil
+	}
+	var output any
+	if err := json.Unmarshal([]byte(text), &output); err != nil {
+		return Grade{Reason: "output is not JSON: " + err.Error()}, nil
+	}
+
+	fields := g.Fields
+	if len(fields) == 0 {
+		m, ok := expected.(map[string]any)
+		if !ok {
+			return Grade{}, fmt.Errorf("case %s: expected output is not a JSON object", sample.Case.ID)
+		}
+		for k := range m {
+			fields = append(fields, k)
+		}
+		slices.Sort(fields)
+	}
+
+	var mismatches []string
+	for _, field := range fields {
+		want, _ := jsonField(expected, field)
+		got, found := jsonField(output, field)
+		switch {
+		case !found:
+			mismatches = append(mismatches, fmt.Sprintf("%s: missing", field))
+		case !reflect.DeepEqual(want, got):
+			mismatches = append(mismatches, fmt.Sprintf("%s: expected %s, got %s", field, jsonString(want), jsonString(got)))
+		}
+	}
+	if len(fields) == 0 {
+		return Grade{Pass: true, Score: 1}, nil
+	}
+	return Grade{
+		Pass:   len(mismatches) == 0,
+		Score:  float64(len(fields)-len(mismatches)) / float64(len(fields)),
+		Reason: strings.Join(mismatches, "; "),
+	}, nil
+}
+
+func jsonField(v any, path string) (any, bool) {
+	for _, key := range strings.Split(path, ".") {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if v, ok = m[key]; !ok {
+			return nil, false
+		}
+	}
+	return v, true
+}
+
+func jsonString(v any) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
+
+// Regex passes when the output text matches a regular expression.
+type Regex struct {
+	Pattern *regexp.Regexp
+}
+
+func (Regex) Name() string { return "regex" }
+
+func (g Regex) Grade(_ context.Context, sample Sample) (Grade, error) {
+	if g.Pattern == nil {
+		return Grade{}, fmt.Errorf("regex grader pattern is not set")
+	}
+	pass := g.Pattern.MatchString(sample.OutputText)
+	return passFail(pass, fmt.Sprintf("output does not match %s", g.Pattern)), nil
+}
+
+// ToolCallExpectation describes a tool call the agent is expected to make.
+type ToolCallExpectation struct {
+	// The name of the tool.
+	Name string `json:"name"`
+
+	// Optional arguments the call must have. Other arguments are ignored.
+	Arguments map[string]any `json:"arguments,omitempty"`
+}
+
+// ToolCalls passes when the agent made the tool calls expected by the case.
+// The score is the fraction of expected calls which were made.
+type ToolCalls struct {
+	// Require the calls in the expected order.
+	Ordered bool
+
+	// Fail if the agent made calls which were not expected.
+	Exact bool
+}
+
+func (ToolCalls) Name() string { return "tool_calls" }
+
+func (g ToolCalls) Grade(_ context.Context, sample Sample) (Grade, error) {
+	calls := functionToolCalls(sample.Result)
+	expected := sample.Case.ExpectedTools
+
+	used := make([]bool, len(calls))
+	next := 0
+	var missing []string
+	for _, expectation := range expected {
+		start := 0
+		if g.Ordered {
+			start = next
+		}
+		index := -1
+		for i := start; i < len(calls); i++ {
+			if !used[i] && expectation.matches(calls[i]) {
+				index = i
+				break
+			}
+		}
+		if index == -1 {
+			missing = append(missing, expectation.String())
+			continue
+		}
+		used[index] = true
+		next = index + 1
+	}
+
+	var reasons []string
+	if len(missing) > 0 {
+		reasons = append(reasons, "missing calls: "+strings.Join(missing, ", "))
+	}
+	if g.Exact {
+		var unexpected []string
+		for i, call := range calls {
+			if !used[i] {
+				unexpected = append(unexpected, call.Name+"("+call.Arguments+")")
+			}
+		}
+		if len(unexpected) > 0 {
+			reasons = append(reasons, "unexpected calls: "+strings.Join(unexpected, ", "))
+		}
+	}
+
+	score := 1.0
+	if len(expected) > 0 {
+		score = float64(len(expected)-len(missing)) / float64(len(expected))
+	}
+	return Grade{
+		Pass:   len(reasons) == 0,
+		Score:  score,
+		Reason: strings.Join(reasons, "; "),
+	}, nil
+}
+
+func (e ToolCallExpectation) matches(call agents.ResponseFunctionToolCall) bool {
+	if call.Name != e.Name {
+		return false
+	}
+	if len(e.Arguments) == 0 {
+		return true
+	}
+	var arguments map[string]any
+	if err := json.Unmarshal([]byte(call.Arguments), &arguments); err != nil {
+		return false
+	}
+	for k, want := range e.Arguments {
+		got, ok := arguments[k]
+		if !ok || jsonString(got) != jsonString(want) {
+			return false
+		}
+	}
+	return true
+}
+
+func (e ToolCallExpectation) String() string {
+	if len(e.Arguments) == 0 {
+		return e.Name
+	}
+	return e.Name + jsonString(e.Arguments)
+}
+
+func functionToolCalls(result *agents.RunResult) []agents.ResponseFunctionToolCall {
+	if result == nil {
+		return nil
+	}
+	var calls []agents.ResponseFunctionToolCall
+	for _, item := range result.NewItems {
+		if toolCall, ok := item.(agents.ToolCallItem); ok {
+			if call, ok := toolCall.RawItem.(agents.ResponseFunctionToolCall); ok {
+				calls = append(calls, call)
+			}
+		}
+	}
+	return calls
+}
@@ -0,0 +1,181 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evals
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/invopop/jsonschema"
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/modelsettings"
+	"github.com/openai/openai-go/packages/param"
+)
+
+// JudgeParams configures a grader asking a model to judge the outputs.
+// T is the type of the verdict, a struct whose JSON schema is used as the
+// structured output of the model.
+type JudgeParams[T any] struct {
+	// The name of the grader. Default: "judge".
+	Name string
+
+	// The judging model. Required.
+	Model agents.Model
+
+	// Optional model settings.
+	ModelSettings modelsettings.ModelSettings
+
+	// The system instructions of the model, describing the grading rubric.
+	// The input, the expected output (if any) and the output of the case
+	// are sent as the user input. Required.
+	Instructions string
+
+	// A function deciding, from the verdict, whether the sample passes.
+	// Required.
+	Pass func(T) bool
+
+	// An optional function returning a score between 0 and 1 from the
+	// verdict. Default: 1 when passing, 0 otherwise.
+	Score func(T) float64
+
+	// An optional function explaining the verdict, reported as the reason
+	// of the grade. Default: the verdict encoded as JSON.
+	Reason func(T) string
+}
+
+// LLMJudge returns a Grader asking a model to judge each sample.
+//
+// Example:
+//
+//	type Verdict struct {
+//	    Correct   bool   `json:"correct"`
+//	    Reasoning string `json:"reasoning"`
+//	}
+//
+//	grader := evals.LLMJudge(evals.JudgeParams[Verdict]{
+//	    Model:        model,
+//	    Instructions: "Check whether the answer is factually consistent with the expected answer.",
+//	    Pass:         func(v Verdict) bool { return v.Correct },
+//	})
+func LLMJudge[T any](params JudgeParams[T]) Grader {
+	if params.Name == "" {
+		params.Name = "judge"
+	}
+	return judge[T]{params: params, schema: newJudgeSchema[T]()}
+}
+
+type judge[T any] struct {
+	params JudgeParams[T]
+	schema judgeSchema[T]
+}
+
+func (j judge[T]) Name() string { return j.params.Name }
+
+func (j judge[T]) Grade(ctx context.Context, sample Sample) (Grade, error) {
+	if j.params.Model == nil {
+		return Grade{}, errors.New("judge model is not set")
+	}
+	if j.params.Pass == nil {
+		return Grade{}, errors.New("judge pass function is not set")
+	}
+
+	var input strings.Builder
+	fmt.Fprintf(&input, "# Input\n%s\n\n", sample.Case.Input)
+	if len(sample.Case.Expected) > 0 {
+		fmt.Fprintf(&input, "# Expected output\n%s\n\n", sample.Case.ExpectedText())
+	}
+	fmt.Fprintf(&input, "# Output\n%s\n", sample.OutputText)
+
+	response, err := j.params.Model.GetResponse(ctx, agents.ModelResponseParams{
+		SystemInstructions: param.NewOpt(j.params.Instructions),
+		Input:              agents.InputString(input.String()),
+		ModelSettings:      j.params.ModelSettings,
+		OutputSchema:       j.schema,
+	})
+	if err != nil {
+		return Grade{}, fmt.Errorf("judge model call failed: %w", err)
+	}
+
+	var output string
+	for _, item := range response.Output {
+		if s, ok := agents.ItemHelpers().ExtractLastText(item); ok {
+			output = s
+		}
+	}
+	if output == "" {
+		return Grade{Usage: response.Usage}, agents.NewModelBehaviorError("judge model returned no text")
+	}
+	v, err := j.schema.ValidateJSON(output)
+	if err != nil {
+		return Grade{Usage: response.Usage}, err
+	}
+	verdict := v.(T)
+
+	grade := Grade{Pass: j.params.Pass(verdict), Usage: response.Usage}
+	switch {
+	case j.params.Score != nil:
+		grade.Score = j.params.Score(verdict)
+	case grade.Pass:
+		grade.Score = 1
+	}
+	if j.params.Reason != nil {
+		grade.Reason = j.params.Reason(verdict)
+	} else {
+		grade.Reason = output
+	}
+	return grade, nil
+}
+
+// judgeSchema is the strict output schema of a judge, reflected from the
+// type of the verdict.
+type judgeSchema[T any] struct {
+	name   string
+	schema map[string]any
+}
+
+func newJudgeSchema[T any]() judgeSchema[T] {
+	reflector := &jsonschema.Reflector{
+		ExpandedStruct:             true,
+		RequiredFromJSONSchemaTags: false,
+		AllowAdditionalProperties:  false,
+	}
+	var zero T
+	schemaBytes, _ := json.Marshal(reflector.Reflect(&zero))
+	var schemaMap map[string]any
+	_ = json.Unmarshal(schemaBytes, &schemaMap)
+
+	name := "verdict"
+	if t := reflect.TypeOf(zero); t != nil && t.Name() != "" {
+		name = t.Name()
+	}
+	return judgeSchema[T]{name: name, schema: schemaMap}
+}
+
+func (s judgeSchema[T]) IsPlainText() bool          { return false }
+func (s judgeSchema[T]) Name() string               { return s.name }
+func (s judgeSchema[T]) JSONSchema() map[string]any { return s.schema }
+func (s judgeSchema[T]) IsStrictJSONSchema() bool   { return true }
+
+func (s judgeSchema[T]) ValidateJSON(jsonStr string) (any, error) {
+	var v T
+	if err := json.Unmarshal([]byte(jsonStr), &v); err != nil {
+		return nil, agents.ModelBehaviorErrorf("invalid judge output: %w", err)
+	}
+	return v, nil
+}
@@ -0,0 +1,383 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evals
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// CaseResult is the result of a single case.
+type CaseResult struct {
+	ID     string `json:"id"`
+	Input  string `json:"input"`
+	Output string `json:"output,omitempty"`
+
+	// Whether the run succeeded and all graders passed.
+	Pass   bool    `json:"pass"`
+	Grades []Grade `json:"grades,omitempty"`
+
+	// The error of the run, if it failed.
+	Error string `json:"error,omitempty"`
+
+	// The usage and cost of the agent run, including failed runs.
+	InputTokens  uint64        `json:"input_tokens"`
+	OutputTokens uint64        `json:"output_tokens"`
+	Cost         float64       `json:"cost"`
+	Latency      time.Duration `json:"latency"`
+
+	// The usage and cost of the model calls made by the graders.
+	GraderInputTokens  uint64  `json:"grader_input_tokens,omitempty"`
+	GraderOutputTokens uint64  `json:"grader_output_tokens,omitempty"`
+	GraderCost         float64 `json:"grader_cost,omitempty"`
+}
+
+// Summary aggregates the results of an eval.
+type Summary struct {
+	Cases  int `json:"cases"`
+	Passed int `json:"passed"`
+	// The number of failed runs.
+	Errors   int     `json:"errors"`
+	PassRate float64 `json:"pass_rate"`
+
+	// The pass rate of each grader, over the successful runs.
+	GraderPassRates map[string]float64 `json:"grader_pass_rates,omitempty"`
+	// The mean score of each grader, over the successful runs.
+	GraderScores map[string]float64 `json:"grader_scores,omitempty"`
+
+	InputTokens  uint64        `json:"input_tokens"`
+	OutputTokens uint64        `json:"output_tokens"`
+	Cost         float64       `json:"cost"`
+	MeanLatency  time.Duration `json:"mean_latency"`
+	P50Latency   time.Duration `json:"p50_latency"`
+	P95Latency   time.Duration `json:"p95_latency"`
+
+	GraderInputTokens  uint64  `json:"grader_input_tokens,omitempty"`
+	GraderOutputTokens uint64  `json:"grader_output_tokens,omitempty"`
+	GraderCost         float64 `json:"grader_cost,omitempty"`
+}
+
+func (s Summary) hasGraderUsage() bool {
+	return s.GraderInputTokens > 0 || s.GraderOutputTokens > 0 || s.GraderCost > 0
+}
+
+// Report is the outcome of an eval run.
+type Report struct {
+	Name      string       `json:"name,omitempty"`
+	CreatedAt time.Time    `json:"created_at"`
+	Summary   Summary      `json:"summary"`
+	Results   []CaseResult `json:"results"`
+}
+
+// NewReport creates a report from the results of the cases, in dataset order.
+func NewReport(name string, results []CaseResult) *Report {
+	return &Report{
+		Name:      name,
+		CreatedAt: time.Now().UTC(),
+		Summary:   summarize(results),
+		Results:   results,
+	}
+}
+
+func summarize(results []CaseResult) Summary {
+	s := Summary{Cases: len(results)}
+	if len(results) == 0 {
+		return s
+	}
+
+	gradeCounts := make(map[string]int)
+	gradePasses := make(map[string]int)
+	gradeScores := make(map[string]float64)
+	latencies := make([]time.Duration, 0, len(results))
+	var totalLatency time.Duration
+	for _, r := range results {
+		if r.Pass {
+			s.Passed++
+		}
+		if r.Error != "" {
+			s.Errors++
+		}
+		for _, g := range r.Grades {
+			gradeCounts[g.Grader]++
+			gradeScores[g.Grader] += g.Score
+			if g.Pass {
+				gradePasses[g.Grader]++
+			}
+		}
+		s.InputTokens += r.InputTokens
+		s.OutputTokens += r.OutputTokens
+		s.Cost += r.Cost
+		s.GraderInputTokens += r.GraderInputTokens
+		s.GraderOutputTokens += r.GraderOutputTokens
+		s.GraderCost += r.GraderCost
+		totalLatency += r.Latency
+		latencies = append(latencies, r.Latency)
+	}
+
+	s.PassRate = float64(s.Passed) / float64(s.Cases)
+	if len(gradeCounts) > 0 {
+		s.GraderPassRates = make(map[string]float64, len(gradeCounts))
+		s.GraderScores = make(map[string]float64, len(gradeCounts))
+		for name, n := range gradeCounts {
+			s.GraderPassRates[name] = float64(gradePasses[name]) / float64(n)
+			s.GraderScores[name] = gradeScores[name] / float64(n)
+		}
+	}
+
+	slices.Sort(latencies)
+	s.MeanLatency = totalLatency / time.Duration(len(results))
+	s.P50Latency = percentile(latencies, 0.50)
+	s.P95Latency = percentile(latencies, 0.95)
+	return s
+}
+
+// percentile returns the nearest-rank percentile of sorted values.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p * float64(len(sorted))))
+	return sorted[max(rank-1, 0)]
+}
+
+// LoadReport reads a report saved with WriteJSON.
+func LoadReport(path string) (*Report, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read report: %w", err)
+	}
+	var r Report
+	if err = json.Unmarshal(b, &r); err != nil {
+		return nil, fmt.Errorf("failed to decode report %s: %w", path, err)
+	}
+	return &r, nil
+}
+
+// WriteJSON saves the report as JSON, creating its directory if needed.
+func (r *Report) WriteJSON(path string) error {
+	b, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode report: %w", err)
+	}
+	return writeFile(path, append(b, '\n'))
+}
+
+// WriteMarkdown saves the report as Markdown, comparing it with the given
+// baseline, if not nil.
+func (r *Report) WriteMarkdown(path string, baseline *Report) error {
+	return writeFile(path, []byte(r.Markdown(baseline)))
+}
+
+func writeFile(path string, content []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create report directory: %w", err)
+	}
+	if err := os.WriteFile(path, content, 0o644); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	return nil
+}
+
+// Comparison describes the differences between a report and a baseline.
+type Comparison struct {
+	PassRateDelta    float64
+	CostDelta        float64
+	MeanLatencyDelta time.Duration
+
+	// The cases passing in the baseline and failing now.
+	Regressions []string
+	// The cases failing in the baseline and passing now.
+	Fixes []string
+	// The cases missing from the baseline.
+	Added []string
+	// The cases missing from the report.
+	Removed []string
+	// The cases whose output changed.
+	Changed []OutputChange
+}
+
+// OutputChange is a changed output of a case.
+type OutputChange struct {
+	ID string
+	// A unified diff from the baseline output to the current one.
+	Diff string
+}
+
+// Compare compares a report with a baseline, matching cases by ID.
+func Compare(baseline, current *Report) Comparison {
+	c := Comparison{
+		PassRateDelta:    current.Summary.PassRate - baseline.Summary.PassRate,
+		CostDelta:        current.Summary.Cost - baseline.Summary.Cost,
+		MeanLatencyDelta: current.Summary.MeanLatency - baseline.Summary.MeanLatency,
+	}
+
+	baselineResults := make(map[string]CaseResult, len(baseline.Results))
+	for _, r := range baseline.Results {
+		baselineResults[r.ID] = r
+	}
+	currentIDs := make(map[string]bool, len(current.Results))
+	for _, r := range current.Results {
+		currentIDs[r.ID] = true
+		old, ok := baselineResults[r.ID]
+		switch {
+		case !ok:
+			c.Added = append(c.Added, r.ID)
+			continue
+		case old.Pass && !r.Pass:
+			c.Regressions = append(c.Regressions, r.ID)
+		case !old.Pass && r.Pass:
+			c.Fixes = append(c.Fixes, r.ID)
+		}
+		if old.Output != r.Output {
+			diff, _ := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+				A:        difflib.SplitLines(old.Output + "\n"),
+				B:        difflib.SplitLines(r.Output + "\n"),
+				FromFile: "baseline",
+				ToFile:   "current",
+				Context:  2,
+			})
+			c.Changed = append(c.Changed, OutputChange{ID: r.ID, Diff: diff})
+		}
+	}
+	for _, r := range baseline.Results {
+		if !currentIDs[r.ID] {
+			c.Removed = append(c.Removed, r.ID)
+		}
+	}
+	return c
+}
+
+// Markdown renders the report as Markdown, comparing it with the given
+// baseline, if not nil.
+func (r *Report) Markdown(baseline *Report) string {
+	var b strings.Builder
+	s := r.Summary
+
+	title := "Eval report"
+	if r.Name != "" {
+		title += ": " + r.Name
+	}
+	fmt.Fprintf(&b, "# %s\n\n", title)
+
+	if baseline == nil {
+		b.WriteString("| Metric | Value |\n|---|---|\n")
+		fmt.Fprintf(&b, "| Pass rate | %s (%d/%d) |\n", percent(s.PassRate), s.Passed, s.Cases)
+		fmt.Fprintf(&b, "| Errors | %d |\n", s.Errors)
+		fmt.Fprintf(&b, "| Tokens | %d in, %d out |\n", s.InputTokens, s.OutputTokens)
+		fmt.Fprintf(&b, "| Cost | $%.4f |\n", s.Cost)
+		if s.hasGraderUsage() {
+			fmt.Fprintf(&b, "| Grader tokens | %d in, %d out |\n", s.GraderInputTokens, s.GraderOutputTokens)
+			fmt.Fprintf(&b, "| Grader cost | $%.4f |\n", s.GraderCost)
+		}
+		fmt.Fprintf(&b, "| Latency | mean %s, p50 %s, p95 %s |\n", roundDuration(s.MeanLatency), roundDuration(s.P50Latency), roundDuration(s.P95Latency))
+	} else {
+		bs := baseline.Summary
+		c := Compare(baseline, r)
+		b.WriteString("| Metric | Value | Baseline | Change |\n|---|---|---|---|\n")
+		fmt.Fprintf(&b, "| Pass rate | %s (%d/%d) | %s (%d/%d) | %+.1f pp |\n",
+			percent(s.PassRate), s.Passed, s.Cases, percent(bs.PassRate), bs.Passed, bs.Cases, c.PassRateDelta*100)
+		fmt.Fprintf(&b, "| Errors | %d | %d | %+d |\n", s.Errors, bs.Errors, s.Errors-bs.Errors)
+		fmt.Fprintf(&b, "| Tokens | %d in, %d out | %d in, %d out | %+d in, %+d out |\n",
+			s.InputTokens, s.OutputTokens, bs.InputTokens, bs.OutputTokens,
+			int64(s.InputTokens)-int64(bs.InputTokens), int64(s.OutputTokens)-int64(bs.OutputTokens))
+		fmt.Fprintf(&b, "| Cost | $%.4f | $%.4f | %+.4f |\n", s.Cost, bs.Cost, c.CostDelta)
+		if s.hasGraderUsage() || bs.hasGraderUsage() {
+			fmt.Fprintf(&b, "| Grader cost | $%.4f | $%.4f | %+.4f |\n", s.GraderCost, bs.GraderCost, s.GraderCost-bs.GraderCost)
+		}
+		fmt.Fprintf(&b, "| Mean latency | %s | %s | %s |\n",
+			roundDuration(s.MeanLatency), roundDuration(bs.MeanLatency), signedDuration(c.MeanLatencyDelta))
+		fmt.Fprintf(&b, "| P95 latency | %s | %s | %s |\n",
+			roundDuration(s.P95Latency), roundDuration(bs.P95Latency), signedDuration(s.P95Latency-bs.P95Latency))
+
+		writeIDList(&b, "Regressions", c.Regressions)
+		writeIDList(&b, "Fixes", c.Fixes)
+		writeIDList(&b, "Added cases", c.Added)
+		writeIDList(&b, "Removed cases", c.Removed)
+		if len(c.Changed) > 0 {
+			b.WriteString("\n## Changed outputs\n")
+			for _, change := range c.Changed {
+				fmt.Fprintf(&b, "\n### %s\n\n```diff\n%s```\n", change.ID, change.Diff)
+			}
+		}
+	}
+
+	if len(s.GraderPassRates) > 0 {
+		b.WriteString("\n## Graders\n\n| Grader | Pass rate | Mean score |\n|---|---|---|\n")
+		names := make([]string, 0, len(s.GraderPassRates))
+		for name := range s.GraderPassRates {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+		for _, name := range names {
+			fmt.Fprintf(&b, "| %s | %s | %.2f |\n", name, percent(s.GraderPassRates[name]), s.GraderScores[name])
+		}
+	}
+
+	b.WriteString("\n## Cases\n\n| Case | Result | Details | Latency | Cost |\n|---|---|---|---|---|\n")
+	for _, result := range r.Results {
+		status, details := "pass", ""
+		switch {
+		case result.Error != "":
+			status, details = "error", result.Error
+		case !result.Pass:
+			status = "fail"
+			var reasons []string
+			for _, g := range result.Grades {
+				if !g.Pass {
+					reasons = append(reasons, g.Grader+": "+g.Reason)
+				}
+			}
+			details = strings.Join(reasons, "; ")
+		}
+		fmt.Fprintf(&b, "| %s | %s | %s | %s | $%.4f |\n",
+			markdownCell(result.ID), status, markdownCell(details), roundDuration(result.Latency), result.Cost)
+	}
+	return b.String()
+}
+
+func writeIDList(b *strings.Builder, title string, ids []string) {
+	if len(ids) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "\n## %s\n\n", title)
+	for _, id := range ids {
+		fmt.Fprintf(b, "- %s\n", id)
+	}
+}
+
+func percent(v float64) string {
+	return fmt.Sprintf("%.1f%%", v*100)
+}
+
+func roundDuration(d time.Duration) time.Duration {
+	return d.Round(time.Millisecond)
+}
+
+func signedDuration(d time.Duration) string {
+	if d >= 0 {
+		return "+" + roundDuration(d).String()
+	}
+	return roundDuration(d).String()
+}
+
+func markdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.ReplaceAll(s, "\n", " ")
+}
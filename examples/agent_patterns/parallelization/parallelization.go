@@ -17,11 +17,9 @@ package main
 import (
 	"bufio"
 	"context"
-	"errors"
 	"fmt"
 	"os"
 	"strings"
-	"sync"
 
 	"github.com/nlpodyssey/openai-agents-go/agents"
 	"github.com/openai/openai-go/packages/param"
@@ -29,7 +27,7 @@ import (
 
 /*
 This example shows the parallelization pattern. We run the agent three times
-in parallel, and pick the best result.
+in parallel, and let a judge agent pick the best result.
 */
 
 var (
@@ -51,38 +49,26 @@ func main() {
 	}
 	msg := string(line)
 
-	const N = 3
-	var runResults [N]*agents.RunResult
-	var runErrors [N]error
-
-	var wg sync.WaitGroup
-	wg.Add(N)
-
-	for i := range N {
-		go func() {
-			defer wg.Done()
-			runResults[i], runErrors[i] = agents.Run(context.Background(), SpanishAgent, msg)
-		}()
-	}
-
-	wg.Wait()
-	if err = errors.Join(runErrors[:]...); err != nil {
+	result, err := agents.RunParallel(
+		context.Background(),
+		agents.RepeatAgent(SpanishAgent, 3),
+		msg,
+		agents.JudgeSelection(TranslationPicker),
+	)
+	if err != nil {
 		panic(err)
 	}
 
-	var outputs [N]string
-	for i, runResult := range runResults {
-		outputs[i] = agents.ItemHelpers().TextMessageOutputs(runResult.NewItems)
-	}
-
-	translations := strings.Join(outputs[:], "\n\n")
-	fmt.Printf("\n\nTranslations:\n\n%s\n", translations)
-
-	bestTranslation, err := agents.Run(context.Background(), TranslationPicker, fmt.Sprintf("Input: %s\n\nTranslations:\n%s", msg, translations))
-	if err != nil {
-		panic(err)
+	var outputs []string
+	for _, outcome := range result.Outcomes {
+		if !outcome.Succeeded() {
+			continue
+		}
+		outputs = append(outputs, agents.ItemHelpers().TextMessageOutputs(outcome.Result.NewItems))
 	}
+	fmt.Printf("\n\nTranslations:\n\n%s\n", strings.Join(outputs, "\n\n"))
 
 	fmt.Println("\n\n-----")
-	fmt.Printf("Best translation: %s\n", bestTranslation.FinalOutput)
+	fmt.Printf("Best translation: %s\n", result.FinalOutput)
+	fmt.Printf("Total tokens: %d\n", result.Usage.TotalTokens)
 }
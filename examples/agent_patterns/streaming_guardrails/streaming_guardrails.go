@@ -23,14 +23,13 @@ import (
 	"strings"
 
 	"github.com/nlpodyssey/openai-agents-go/agents"
-	"github.com/nlpodyssey/openai-agents-go/asynctask"
 	"github.com/openai/openai-go/packages/param"
 )
 
 /*
 This example shows how to use guardrails as the model is streaming. Output guardrails run after the
-final output has been generated; this example runs guardrails every N tokens, allowing for early
-termination if bad output is detected.
+final output has been generated; a StreamingOutputGuardrail runs every N characters instead, allowing
+for early termination if bad output is detected.
 
 The expected output is that you'll see a bunch of tokens stream in, then the guardrail will trigger
 and stop the streaming.
@@ -90,87 +89,47 @@ var GuardrailAgent = agents.New("Checker").
 	WithOutputSchema(GuardrailOutputSchema{}).
 	WithModelOpt(param.NewOpt(Model))
 
-type CheckGuardrailResult struct {
-	Output GuardrailOutput
-	Error  error
-}
-
-func CheckGuardrail(text string) CheckGuardrailResult {
-	result, err := agents.Run(context.Background(), GuardrailAgent, text)
-	if err != nil {
-		return CheckGuardrailResult{Error: err}
-	}
-	return CheckGuardrailResult{Output: result.FinalOutput.(GuardrailOutput)}
+// ReadabilityGuardrail checks the text streamed so far every 300 characters.
+var ReadabilityGuardrail = agents.StreamingOutputGuardrail{
+	Name:            "readability",
+	CheckEveryChars: 300,
+	GuardrailFunction: func(ctx context.Context, _ *agents.Agent, partialText string) (agents.GuardrailFunctionOutput, error) {
+		result, err := agents.Run(ctx, GuardrailAgent, partialText)
+		if err != nil {
+			return agents.GuardrailFunctionOutput{}, err
+		}
+		output := result.FinalOutput.(GuardrailOutput)
+		return agents.GuardrailFunctionOutput{
+			OutputInfo:        output,
+			TripwireTriggered: !output.IsReadableByTenYearOld,
+		}, nil
+	},
 }
 
 func main() {
 	question := "What is a black hole, and how does it behave?"
-	result, err := agents.RunStreamed(
-		context.Background(), Agent, question,
-	)
+	agent := Agent.WithStreamingOutputGuardrails([]agents.StreamingOutputGuardrail{ReadabilityGuardrail})
+	result, err := agents.RunStreamed(context.Background(), agent, question)
 	if err != nil {
 		panic(err)
 	}
 
-	currentText := ""
-
-	// We will check the guardrail every N characters
-	nextGuardrailCheckLen := 300
-	var guardrailTask *asynctask.Task[CheckGuardrailResult]
-
-	streamBreakErr := errors.New("stream break")
-
 	err = result.StreamEvents(func(event agents.StreamEvent) error {
 		if e, ok := event.(agents.RawResponsesStreamEvent); ok && e.Data.Type == "response.output_text.delta" {
 			fmt.Print(e.Data.Delta.OfString)
 			_ = os.Stdout.Sync()
-			currentText += e.Data.Delta.OfString
-
-			// Check if it's time to run the guardrail check
-			// Note that we don't run the guardrail check if there's already a task running. An
-			// alternate implementation is to have N guardrails running, or cancel the previous
-			// one.
-			if len(currentText) >= nextGuardrailCheckLen && guardrailTask == nil {
-				fmt.Println("Running guardrail check")
-				currentText := currentText
-				guardrailTask = asynctask.CreateTask(context.Background(), func(context.Context) CheckGuardrailResult {
-					return CheckGuardrail(currentText)
-				})
-				nextGuardrailCheckLen += 300
-			}
-		}
-
-		// Every iteration of the loop, check if the guardrail has been triggered
-		if guardrailTask != nil && guardrailTask.IsDone() {
-			taskResult := guardrailTask.Await()
-			if taskResult.Canceled {
-				return errors.New("guardrail result canceled")
-			}
-			if err := taskResult.Result.Error; err != nil {
-				return err
-			}
-			guardrailResult := taskResult.Result.Output
-			if !guardrailResult.IsReadableByTenYearOld {
-				fmt.Print("\n\n================\n\n\n")
-				fmt.Printf("Guardrail triggered. Reasoning:\n%s\n", guardrailResult.Reasoning)
-				return streamBreakErr
-			}
 		}
-
 		return nil
 	})
-	if err != nil && !errors.Is(err, streamBreakErr) {
-		panic(err)
-	}
 
-	// Do one final check on the final output
-	checkResult := CheckGuardrail(currentText)
-	if err = checkResult.Error; err != nil {
-		panic(err)
-	}
-	guardrailResult := checkResult.Output
-	if !guardrailResult.IsReadableByTenYearOld {
+	var tripwireErr agents.OutputGuardrailTripwireTriggeredError
+	if errors.As(err, &tripwireErr) {
 		fmt.Print("\n\n================\n\n\n")
-		fmt.Printf("Guardrail triggered. Reasoning:\n%s\n", guardrailResult.Reasoning)
+		reasoning := tripwireErr.GuardrailResult.Output.OutputInfo.(GuardrailOutput).Reasoning
+		fmt.Printf("Guardrail triggered. Reasoning:\n%s\n", reasoning)
+		return
+	}
+	if err != nil {
+		panic(err)
 	}
 }
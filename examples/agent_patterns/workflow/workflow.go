@@ -0,0 +1,133 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/workflow"
+	"github.com/openai/openai-go/packages/param"
+)
+
+/*
+This example expresses the parallelization and LLM-as-a-judge patterns as a
+declarative workflow. Three translations run concurrently, a picker chooses
+the best one, and a reviewer loop polishes it until the judge is satisfied.
+*/
+
+var (
+	Model        = agents.NewAgentModelName("gpt-4.1-nano")
+	SpanishAgent = agents.New("spanish_agent").
+			WithInstructions("You translate the user's message to Spanish").
+			WithModelOpt(param.NewOpt(Model))
+	TranslationPicker = agents.New("translation_picker").
+				WithInstructions("You pick the best Spanish translation from the given options. Only output the translation.").
+				WithModelOpt(param.NewOpt(Model))
+	Polisher = agents.New("polisher").
+			WithInstructions("You improve the given Spanish translation, following the feedback if any. Only output the translation.").
+			WithModelOpt(param.NewOpt(Model))
+	Judge = agents.New("judge").
+		WithInstructions("You judge a Spanish translation. Answer PASS if it is natural and accurate, otherwise give brief feedback.").
+		WithModelOpt(param.NewOpt(Model))
+)
+
+type State struct {
+	Message      string
+	Translations []string
+	Best         string
+	Feedback     string
+	Passed       bool
+}
+
+func translate() workflow.AgentNode[State] {
+	return workflow.Agent(
+		SpanishAgent,
+		func(s State) agents.Input { return agents.InputString(s.Message) },
+		func(s *State, r workflow.AgentResult) error {
+			s.Translations = append(s.Translations, r.Text())
+			return nil
+		},
+	)
+}
+
+func main() {
+	fmt.Print("Hi! Enter a message, and we'll translate it to Spanish.\n\n")
+	_ = os.Stdout.Sync()
+	line, _, err := bufio.NewReader(os.Stdin).ReadLine()
+	if err != nil {
+		panic(err)
+	}
+
+	pick := workflow.Agent(
+		TranslationPicker,
+		func(s State) agents.Input {
+			return agents.InputString(fmt.Sprintf("Input: %s\n\nTranslations:\n%s", s.Message, strings.Join(s.Translations, "\n\n")))
+		},
+		func(s *State, r workflow.AgentResult) error {
+			s.Best = r.Text()
+			return nil
+		},
+	)
+
+	review := workflow.New[State]().
+		Add("polish", workflow.Agent(
+			Polisher,
+			func(s State) agents.Input {
+				return agents.InputString(fmt.Sprintf("Translation: %s\n\nFeedback: %s", s.Best, s.Feedback))
+			},
+			func(s *State, r workflow.AgentResult) error {
+				s.Best = r.Text()
+				return nil
+			},
+		)).
+		Add("judge", workflow.Agent(
+			Judge,
+			func(s State) agents.Input { return agents.InputString(s.Best) },
+			func(s *State, r workflow.AgentResult) error {
+				s.Feedback = r.Text()
+				s.Passed = strings.HasPrefix(strings.TrimSpace(s.Feedback), "PASS")
+				return nil
+			},
+		), "polish")
+
+	g := workflow.New[State]().
+		Add("translate_1", translate()).
+		Add("translate_2", translate()).
+		Add("translate_3", translate()).
+		Add("pick", pick, "translate_1", "translate_2", "translate_3").
+		Add("review", workflow.Loop(review, func(s State) bool { return s.Passed }).WithMaxIterations(3), "pick")
+
+	result, err := workflow.RunStreamed(context.Background(), g, State{Message: string(line)}, func(event workflow.Event) error {
+		switch e := event.(type) {
+		case workflow.NodeStartedEvent:
+			fmt.Printf("-- %s started\n", e.Node)
+		case workflow.NodeCompletedEvent:
+			fmt.Printf("-- %s completed\n", e.Node)
+		}
+		return nil
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Printf("\n\nTranslations:\n\n%s\n", strings.Join(result.Translations, "\n\n"))
+	fmt.Println("\n\n-----")
+	fmt.Printf("Best translation: %s\n", result.Best)
+}
@@ -193,6 +193,10 @@ func main() {
 				fmt.Printf("%s: Skipping item: HandoffCallItem\n", newItem.Agent.Name)
 			case agents.ReasoningItem:
 				fmt.Printf("%s: Skipping item: ReasoningItem\n", newItem.Agent.Name)
+			case agents.GuardrailFeedbackItem:
+				fmt.Printf("%s: Skipping item: GuardrailFeedbackItem\n", newItem.Agent.Name)
+			case agents.OutputRepairItem:
+				fmt.Printf("%s: Skipping item: OutputRepairItem\n", newItem.Agent.Name)
 			default:
 				panic(fmt.Errorf("unexpected item type %T\n", newItem))
 			}
@@ -0,0 +1,87 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/agentstesting"
+	"github.com/openai/openai-go"
+	"github.com/openai/openai-go/packages/param"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const cassettePath = "testdata/customer_service.json"
+
+// TestSeatChange replays a recorded conversation, so that it runs without
+// network access. Set AGENTS_RECORD_CASSETTES=1 to record it again against
+// the real model, using OPENAI_API_KEY.
+func TestSeatChange(t *testing.T) {
+	var model agents.Model
+	var recorder *agentstesting.RecordingModel
+	var replay *agentstesting.ReplayModel
+	if os.Getenv("AGENTS_RECORD_CASSETTES") != "" {
+		recorder = agentstesting.NewRecordingModel(
+			agents.NewOpenAIResponsesModel(openai.ChatModelGPT4o, agents.NewOpenaiClient(param.Opt[string]{})),
+		)
+		model = recorder
+	} else {
+		var err error
+		replay, err = agentstesting.LoadReplayModel(cassettePath, agentstesting.ReplayStrict)
+		require.NoError(t, err)
+		model = replay
+	}
+
+	runner := agents.Runner{Config: agents.RunConfig{Model: param.NewOpt(agents.NewAgentModel(model))}}
+	airlineCtx := new(AirlineAgentContext)
+	ctx := context.WithValue(t.Context(), airlineAgentContextKey{}, airlineCtx)
+
+	currentAgent := TriageAgent
+	var inputItems []agents.TResponseInputItem
+	var handoffs []string
+	for _, message := range []string{
+		"Hi, I would like to change my seat.",
+		"My confirmation number is ABC123 and I would like seat 12A.",
+	} {
+		inputItems = append(inputItems, agentstesting.GetTextInputItem(message))
+		result, err := runner.RunResponseInputs(ctx, currentAgent, inputItems)
+		require.NoError(t, err)
+
+		for _, item := range result.NewItems {
+			if handoff, ok := item.(agents.HandoffOutputItem); ok {
+				handoffs = append(handoffs, handoff.SourceAgent.Name+" -> "+handoff.TargetAgent.Name)
+			}
+		}
+		inputItems = result.ToInputList()
+		currentAgent = result.LastAgent
+	}
+
+	assert.Equal(t, []string{"Triage Agent -> Seat Booking Agent"}, handoffs)
+	assert.Same(t, SeatBookingAgent, currentAgent)
+	assert.Equal(t, "ABC123", airlineCtx.ConfirmationNumber)
+	assert.Equal(t, "12A", airlineCtx.SeatNumber)
+	assert.True(t, strings.HasPrefix(airlineCtx.FlightNumber, "FLT-"))
+
+	if recorder != nil {
+		require.NoError(t, recorder.Cassette.Save(cassettePath))
+	} else {
+		assert.Zero(t, replay.Remaining())
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/agents/extensions/localshell"
+)
+
+func main() {
+	// Read-only commands, confined to the current directory.
+	executor, err := localshell.NewExecutor(localshell.Config{
+		AllowedCommands: []string{"ls", "cat", "head", "wc", "grep", "find"},
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	agent := agents.New("Shell assistant").
+		WithInstructions("You answer questions about the files in the working directory, using the shell.").
+		WithTools(agents.LocalShellTool{Executor: executor.Execute}).
+		WithModel("codex-mini-latest")
+
+	question := "How many Go files are there in this directory, and which one is the largest?"
+	if len(os.Args) > 1 {
+		question = os.Args[1]
+	}
+
+	result, err := agents.Run(context.Background(), agent, question)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, item := range result.NewItems {
+		if toolCallItem, ok := item.(agents.ToolCallItem); ok {
+			if shellCall, ok := toolCallItem.RawItem.(agents.ResponseOutputItemLocalShellCall); ok {
+				fmt.Printf("$ %q\n", shellCall.Action.Command)
+			}
+		}
+	}
+	fmt.Println(result.FinalOutput)
+}
@@ -0,0 +1,85 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package modelsettings
+
+import (
+	"strings"
+	"sync"
+)
+
+// ModelLimits describes the token limits of a model.
+type ModelLimits struct {
+	// The maximum number of tokens of input and output combined.
+	ContextWindow int64
+	// The maximum number of output tokens.
+	MaxOutputTokens int64
+}
+
+var (
+	modelLimitsMu sync.RWMutex
+
+	// modelLimits maps model name prefixes to their limits.
+	// The longest matching prefix wins.
+	modelLimits = map[string]ModelLimits{
+		"gpt-5":         {ContextWindow: 400_000, MaxOutputTokens: 128_000},
+		"gpt-4.1":       {ContextWindow: 1_047_576, MaxOutputTokens: 32_768},
+		"gpt-4o":        {ContextWindow: 128_000, MaxOutputTokens: 16_384},
+		"chatgpt-4o":    {ContextWindow: 128_000, MaxOutputTokens: 16_384},
+		"gpt-4-turbo":   {ContextWindow: 128_000, MaxOutputTokens: 4_096},
+		"gpt-4-32k":     {ContextWindow: 32_768, MaxOutputTokens: 32_768},
+		"gpt-4":         {ContextWindow: 8_192, MaxOutputTokens: 8_192},
+		"gpt-3.5-turbo": {ContextWindow: 16_385, MaxOutputTokens: 4_096},
+		"o1":            {ContextWindow: 200_000, MaxOutputTokens: 100_000},
+		"o1-mini":       {ContextWindow: 128_000, MaxOutputTokens: 65_536},
+		"o3":            {ContextWindow: 200_000, MaxOutputTokens: 100_000},
+		"o4-mini":       {ContextWindow: 200_000, MaxOutputTokens: 100_000},
+		"codex-mini":    {ContextWindow: 200_000, MaxOutputTokens: 100_000},
+		"computer-use":  {ContextWindow: 8_192, MaxOutputTokens: 1_024},
+	}
+)
+
+// GetModelLimits returns the token limits of the named model, looked up by
+// longest matching prefix (so that dated snapshots such as
+// "gpt-4o-2024-08-06" match their base model).
+func GetModelLimits(model string) (ModelLimits, bool) {
+	modelLimitsMu.RLock()
+	defer modelLimitsMu.RUnlock()
+
+	var (
+		best       ModelLimits
+		bestLength = -1
+	)
+	for prefix, limits := range modelLimits {
+		if strings.HasPrefix(model, prefix) && len(prefix) > bestLength {
+			best = limits
+			bestLength = len(prefix)
+		}
+	}
+	return best, bestLength >= 0
+}
+
+// ContextWindow returns the context window size, in tokens, of the named model.
+func ContextWindow(model string) (int64, bool) {
+	limits, ok := GetModelLimits(model)
+	return limits.ContextWindow, ok
+}
+
+// RegisterModelLimits registers (or overrides) the token limits for all
+// models whose name starts with the given prefix.
+func RegisterModelLimits(prefix string, limits ModelLimits) {
+	modelLimitsMu.Lock()
+	defer modelLimitsMu.Unlock()
+	modelLimits[prefix] = limits
+}
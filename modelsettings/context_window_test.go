@@ -0,0 +1,46 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package modelsettings
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContextWindow(t *testing.T) {
+	for model, expected := range map[string]int64{
+		"gpt-4o":                 128_000,
+		"gpt-4o-mini-2024-07-18": 128_000,
+		"gpt-4.1-nano":           1_047_576,
+		"gpt-4":                  8_192,
+		"gpt-4-turbo-preview":    128_000,
+		"o1-mini":                128_000,
+		"o1-preview":             200_000,
+		"o3-mini":                200_000,
+	} {
+		v, ok := ContextWindow(model)
+		assert.True(t, ok, model)
+		assert.Equal(t, expected, v, model)
+	}
+
+	_, ok := ContextWindow("unknown-model")
+	assert.False(t, ok)
+
+	RegisterModelLimits("my-custom-model", ModelLimits{ContextWindow: 42, MaxOutputTokens: 10})
+	v, ok := ContextWindow("my-custom-model-v2")
+	assert.True(t, ok)
+	assert.Equal(t, int64(42), v)
+}
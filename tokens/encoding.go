@@ -0,0 +1,238 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokens
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// EncodingName is the name of a known BPE encoding.
+type EncodingName string
+
+const (
+	CL100KBase EncodingName = "cl100k_base"
+	O200KBase  EncodingName = "o200k_base"
+)
+
+// EncodingForModel returns the name of the encoding used by the named model.
+// Unknown models are assumed to use O200KBase, like all recent OpenAI models.
+func EncodingForModel(model string) EncodingName {
+	for _, prefix := range []string{"gpt-4-", "gpt-3.5", "text-embedding-3", "text-embedding-ada"} {
+		if strings.HasPrefix(model, prefix) && !strings.HasPrefix(model, "gpt-4o") {
+			return CL100KBase
+		}
+	}
+	if model == "gpt-4" {
+		return CL100KBase
+	}
+	return O200KBase
+}
+
+// Pretokenizer returns the pretokenizer of the encoding.
+func (n EncodingName) Pretokenizer() (Pretokenizer, error) {
+	switch n {
+	case CL100KBase:
+		return PretokenizeCL100K, nil
+	case O200KBase:
+		return PretokenizeO200K, nil
+	default:
+		return nil, fmt.Errorf("unknown encoding %q", n)
+	}
+}
+
+// Encoding is a byte-pair encoding tokenizer, compatible with the OpenAI
+// "tiktoken" encodings.
+//
+// It is safe for concurrent use.
+type Encoding struct {
+	name         string
+	ranks        map[string]int
+	decoder      map[int]string
+	pretokenizer Pretokenizer
+}
+
+var _ Counter = (*Encoding)(nil)
+
+// NewEncoding creates a new Encoding from the mergeable ranks of its byte
+// sequences, and the function splitting the text before merging.
+func NewEncoding(name string, ranks map[string]int, pretokenizer Pretokenizer) *Encoding {
+	decoder := make(map[int]string, len(ranks))
+	for k, v := range ranks {
+		decoder[v] = k
+	}
+	return &Encoding{
+		name:         name,
+		ranks:        ranks,
+		decoder:      decoder,
+		pretokenizer: pretokenizer,
+	}
+}
+
+// LoadEncoding loads a known encoding from a vocabulary in tiktoken format.
+func LoadEncoding(name EncodingName, r io.Reader) (*Encoding, error) {
+	pretokenizer, err := name.Pretokenizer()
+	if err != nil {
+		return nil, err
+	}
+	ranks, err := ParseTiktokenRanks(r)
+	if err != nil {
+		return nil, err
+	}
+	return NewEncoding(string(name), ranks, pretokenizer), nil
+}
+
+// LoadEncodingFile loads a known encoding from a vocabulary file in tiktoken
+// format (for example, "o200k_base.tiktoken").
+func LoadEncodingFile(name EncodingName, path string) (*Encoding, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+	return LoadEncoding(name, f)
+}
+
+// LoadEncodingFromDir loads a known encoding from the file named
+// "<name>.tiktoken" in the given directory.
+func LoadEncodingFromDir(name EncodingName, dir string) (*Encoding, error) {
+	return LoadEncodingFile(name, filepath.Join(dir, string(name)+".tiktoken"))
+}
+
+// ParseTiktokenRanks parses a vocabulary in tiktoken format: each line holds
+// a base64-encoded byte sequence and its rank, separated by a space.
+func ParseTiktokenRanks(r io.Reader) (map[string]int, error) {
+	ranks := make(map[string]int)
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		token, rank, ok := bytes.Cut(line, []byte{' '})
+		if !ok {
+			return nil, fmt.Errorf("invalid tiktoken line %d", lineNumber)
+		}
+		decoded, err := base64.StdEncoding.DecodeString(string(token))
+		if err != nil {
+			return nil, fmt.Errorf("invalid tiktoken line %d: %w", lineNumber, err)
+		}
+		rankValue, err := strconv.Atoi(string(rank))
+		if err != nil {
+			return nil, fmt.Errorf("invalid tiktoken line %d: %w", lineNumber, err)
+		}
+		ranks[string(decoded)] = rankValue
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return ranks, nil
+}
+
+// Name returns the name of the encoding.
+func (e *Encoding) Name() string { return e.name }
+
+// Encode converts a text to a list of token IDs.
+// Special tokens are not recognized: they are encoded as plain text.
+func (e *Encoding) Encode(text string) []int {
+	var ids []int
+	for _, piece := range e.pretokenizer(text) {
+		if rank, ok := e.ranks[piece]; ok {
+			ids = append(ids, rank)
+			continue
+		}
+		ids = append(ids, e.bytePairEncode([]byte(piece))...)
+	}
+	return ids
+}
+
+// Decode converts a list of token IDs back to text. Unknown IDs are skipped.
+func (e *Encoding) Decode(ids []int) string {
+	var sb strings.Builder
+	for _, id := range ids {
+		sb.WriteString(e.decoder[id])
+	}
+	return sb.String()
+}
+
+// CountTokens returns the number of tokens of the encoded text.
+func (e *Encoding) CountTokens(text string) int {
+	return len(e.Encode(text))
+}
+
+// bytePairEncode merges the bytes of a piece, repeatedly joining the
+// adjacent pair with the lowest rank.
+func (e *Encoding) bytePairEncode(piece []byte) []int {
+	// Boundaries of the current parts: part i is piece[bounds[i]:bounds[i+1]].
+	bounds := make([]int, len(piece)+1)
+	for i := range bounds {
+		bounds[i] = i
+	}
+
+	pairRank := func(i int) int {
+		if i+2 >= len(bounds) {
+			return math.MaxInt
+		}
+		if rank, ok := e.ranks[string(piece[bounds[i]:bounds[i+2]])]; ok {
+			return rank
+		}
+		return math.MaxInt
+	}
+
+	ranks := make([]int, len(bounds)-1)
+	for i := range ranks {
+		ranks[i] = pairRank(i)
+	}
+
+	for len(bounds) > 2 {
+		minIndex, minRank := -1, math.MaxInt
+		for i, rank := range ranks[:len(ranks)-1] {
+			if rank < minRank {
+				minIndex, minRank = i, rank
+			}
+		}
+		if minIndex < 0 {
+			break
+		}
+
+		bounds = append(bounds[:minIndex+1], bounds[minIndex+2:]...)
+		ranks = append(ranks[:minIndex+1], ranks[minIndex+2:]...)
+		ranks[minIndex] = pairRank(minIndex)
+		if minIndex > 0 {
+			ranks[minIndex-1] = pairRank(minIndex - 1)
+		}
+	}
+
+	ids := make([]int, 0, len(bounds)-1)
+	for i := 0; i+1 < len(bounds); i++ {
+		part := string(piece[bounds[i]:bounds[i+1]])
+		if rank, ok := e.ranks[part]; ok {
+			ids = append(ids, rank)
+		}
+		// Parts without a rank can only occur with incomplete vocabularies,
+		// which lack some single bytes: they are dropped.
+	}
+	return ids
+}
@@ -0,0 +1,261 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokens
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"image"
+	_ "image/gif"  // register GIF decoding for image size estimation
+	_ "image/jpeg" // register JPEG decoding for image size estimation
+	_ "image/png"  // register PNG decoding for image size estimation
+	"math"
+	"strings"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/modelsettings"
+	"github.com/openai/openai-go/responses"
+)
+
+// Fixed overheads of the chat format, following the guidelines of OpenAI.
+const (
+	tokensPerMessage    = 3
+	tokensPerReply      = 3
+	tokensPerToolCall   = 3
+	tokensPerFunction   = 8
+	tokensPerHostedTool = 20
+	tokensPerFile       = 100
+)
+
+// Estimate is the estimated size, in tokens, of a model request.
+type Estimate struct {
+	// The size of the system instructions.
+	Instructions int
+	// The size of the input items.
+	Input int
+	// The size of the tool and handoff definitions.
+	Tools int
+	// The size of the output schema.
+	OutputSchema int
+	// The total size, including the fixed overhead of the reply.
+	Total int
+}
+
+// Estimator estimates the size of model requests.
+type Estimator struct {
+	// The counter of text tokens. Default: Heuristic.
+	Counter Counter
+}
+
+// NewEstimator creates a new Estimator using the given Counter.
+func NewEstimator(counter Counter) Estimator {
+	return Estimator{Counter: counter}
+}
+
+// CountText returns the number of tokens of a text.
+func (e Estimator) CountText(text string) int {
+	if text == "" {
+		return 0
+	}
+	if e.Counter == nil {
+		return Heuristic{}.CountTokens(text)
+	}
+	return e.Counter.CountTokens(text)
+}
+
+func (e Estimator) countJSON(v any) int {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return 0
+	}
+	return e.CountText(string(b))
+}
+
+// EstimateParams estimates the size of a complete model request.
+func (e Estimator) EstimateParams(params agents.ModelResponseParams) Estimate {
+	var est Estimate
+
+	if params.SystemInstructions.Valid() {
+		est.Instructions = tokensPerMessage + e.CountText(params.SystemInstructions.Value)
+	}
+
+	switch input := params.Input.(type) {
+	case agents.InputString:
+		est.Input = tokensPerMessage + e.CountText(string(input))
+	case agents.InputItems:
+		for _, item := range input {
+			est.Input += e.CountInputItem(item)
+		}
+	}
+
+	for _, tool := range params.Tools {
+		est.Tools += e.CountTool(tool)
+	}
+	for _, h := range params.Handoffs {
+		est.Tools += tokensPerFunction +
+			e.CountText(h.ToolName) +
+			e.CountText(h.ToolDescription) +
+			e.countJSON(h.InputJSONSchema)
+	}
+
+	if params.OutputSchema != nil && !params.OutputSchema.IsPlainText() {
+		est.OutputSchema = tokensPerFunction +
+			e.CountText(params.OutputSchema.Name()) +
+			e.countJSON(params.OutputSchema.JSONSchema())
+	}
+
+	est.Total = est.Instructions + est.Input + est.Tools + est.OutputSchema + tokensPerReply
+	return est
+}
+
+// RemainingContext returns how many tokens are left in the context window
+// of the named model after the request (possibly a negative number), if the
+// context window of the model is known (see modelsettings.ContextWindow).
+func (e Estimator) RemainingContext(model string, params agents.ModelResponseParams) (int64, bool) {
+	window, ok := modelsettings.ContextWindow(model)
+	if !ok {
+		return 0, false
+	}
+	return window - int64(e.EstimateParams(params).Total), true
+}
+
+// CountTool estimates the size of a tool definition.
+func (e Estimator) CountTool(tool agents.Tool) int {
+	switch t := tool.(type) {
+	case agents.FunctionTool:
+		return tokensPerFunction +
+			e.CountText(t.Name) +
+			e.CountText(t.Description) +
+			e.countJSON(t.ParamsJSONSchema)
+	default:
+		return tokensPerHostedTool
+	}
+}
+
+// CountInputItem estimates the size of an input item.
+//
+// The method value can be used as a context_managers.TokenCounter.
+func (e Estimator) CountInputItem(item agents.TResponseInputItem) int {
+	switch {
+	case item.OfMessage != nil:
+		n := tokensPerMessage + 1
+		content := item.OfMessage.Content
+		if content.OfString.Valid() {
+			return n + e.CountText(content.OfString.Value)
+		}
+		return n + e.countInputContent(content.OfInputItemContentList)
+	case item.OfInputMessage != nil:
+		return tokensPerMessage + 1 + e.countInputContent(item.OfInputMessage.Content)
+	case item.OfOutputMessage != nil:
+		n := tokensPerMessage + 1
+		for _, c := range item.OfOutputMessage.Content {
+			switch {
+			case c.OfOutputText != nil:
+				n += e.CountText(c.OfOutputText.Text)
+			case c.OfRefusal != nil:
+				n += e.CountText(c.OfRefusal.Refusal)
+			}
+		}
+		return n
+	case item.OfFunctionCall != nil:
+		return tokensPerToolCall + e.CountText(item.OfFunctionCall.Name) + e.CountText(item.OfFunctionCall.Arguments)
+	case item.OfFunctionCallOutput != nil:
+		return tokensPerToolCall + e.CountText(item.OfFunctionCallOutput.Output)
+	case item.OfLocalShellCallOutput != nil:
+		return tokensPerToolCall + e.CountText(item.OfLocalShellCallOutput.Output)
+	case item.OfComputerCallOutput != nil:
+		screenshot := item.OfComputerCallOutput.Output
+		return tokensPerToolCall + e.countImage(screenshot.ImageURL.Value, responses.ResponseInputImageDetailAuto)
+	case item.OfReasoning != nil:
+		n := 0
+		for _, s := range item.OfReasoning.Summary {
+			n += e.CountText(s.Text)
+		}
+		return n
+	default:
+		return tokensPerToolCall + e.countJSON(item)
+	}
+}
+
+func (e Estimator) countInputContent(content responses.ResponseInputMessageContentListParam) int {
+	n := 0
+	for _, c := range content {
+		switch {
+		case c.OfInputText != nil:
+			n += e.CountText(c.OfInputText.Text)
+		case c.OfInputImage != nil:
+			n += e.countImage(c.OfInputImage.ImageURL.Value, c.OfInputImage.Detail)
+		case c.OfInputFile != nil:
+			n += tokensPerFile
+		}
+	}
+	return n
+}
+
+func (e Estimator) countImage(url string, detail responses.ResponseInputImageDetail) int {
+	width, height := 1024, 1024
+	if w, h, ok := dataURLImageSize(url); ok {
+		width, height = w, h
+	}
+	return ImageTokens(width, height, string(detail))
+}
+
+// ImageTokens returns the number of tokens of an image with the given size
+// and detail level ("low", "high" or "auto"), following the rules of the
+// OpenAI vision models: low-detail images have a fixed cost, while the other
+// ones are scaled and divided in tiles of 512x512 pixels.
+func ImageTokens(width, height int, detail string) int {
+	if detail == "low" {
+		return 85
+	}
+	if width <= 0 || height <= 0 {
+		return 85
+	}
+
+	w, h := float64(width), float64(height)
+	if scale := 2048 / max(w, h); scale < 1 {
+		w, h = w*scale, h*scale
+	}
+	if scale := 768 / min(w, h); scale < 1 {
+		w, h = w*scale, h*scale
+	}
+	tiles := int(math.Ceil(w/512) * math.Ceil(h/512))
+	return 170*tiles + 85
+}
+
+// dataURLImageSize returns the size of an image encoded in a base64 data URL.
+func dataURLImageSize(url string) (int, int, bool) {
+	if !strings.HasPrefix(url, "data:") {
+		return 0, 0, false
+	}
+	_, data, ok := strings.Cut(url, ";base64,")
+	if !ok {
+		return 0, 0, false
+	}
+	// Decoding the header is enough to read the size.
+	if len(data) > 4096 {
+		data = data[:4096]
+	}
+	decoded, err := base64.StdEncoding.DecodeString(data[:len(data)/4*4])
+	if err != nil {
+		return 0, 0, false
+	}
+	config, _, err := image.DecodeConfig(bytes.NewReader(decoded))
+	if err != nil {
+		return 0, 0, false
+	}
+	return config.Width, config.Height, true
+}
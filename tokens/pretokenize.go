@@ -0,0 +1,281 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokens
+
+import (
+	"unicode"
+)
+
+// Pretokenizer splits a text into pieces which are then encoded
+// independently with byte-pair encoding.
+type Pretokenizer func(text string) []string
+
+// The regular expressions used by the OpenAI encodings rely on look-ahead
+// assertions, which the standard regexp package does not support: the
+// pretokenizers below are hand-written equivalents. Each alternative of the
+// original expression is a matcher returning the end of its match starting
+// at the given position, or -1; the first matching alternative wins.
+type matcher func(s []rune, i int) int
+
+func pretokenize(text string, matchers []matcher) []string {
+	s := []rune(text)
+	var pieces []string
+	for i := 0; i < len(s); {
+		end := -1
+		for _, m := range matchers {
+			if end = m(s, i); end > i {
+				break
+			}
+		}
+		if end <= i {
+			// Not reachable with complete sets of matchers, but it
+			// guarantees progress.
+			end = i + 1
+		}
+		pieces = append(pieces, string(s[i:end]))
+		i = end
+	}
+	return pieces
+}
+
+// PretokenizeCL100K splits text like the "cl100k_base" encoding:
+//
+//	(?i:'s|'t|'re|'ve|'m|'ll|'d)|[^\r\n\p{L}\p{N}]?\p{L}+|\p{N}{1,3}| ?[^\s\p{L}\p{N}]+[\r\n]*|\s*[\r\n]+|\s+(?!\S)|\s+
+func PretokenizeCL100K(text string) []string {
+	return pretokenize(text, cl100kMatchers)
+}
+
+// PretokenizeO200K splits text like the "o200k_base" encoding:
+//
+//	[^\r\n\p{L}\p{N}]?[\p{Lu}\p{Lt}\p{Lm}\p{Lo}\p{M}]*[\p{Ll}\p{Lm}\p{Lo}\p{M}]+(?i:'s|'t|'re|'ve|'m|'ll|'d)?
+//	|[^\r\n\p{L}\p{N}]?[\p{Lu}\p{Lt}\p{Lm}\p{Lo}\p{M}]+[\p{Ll}\p{Lm}\p{Lo}\p{M}]*(?i:'s|'t|'re|'ve|'m|'ll|'d)?
+//	|\p{N}{1,3}| ?[^\s\p{L}\p{N}]+[\r\n/]*|\s*[\r\n]+|\s+(?!\S)|\s+
+func PretokenizeO200K(text string) []string {
+	return pretokenize(text, o200kMatchers)
+}
+
+var cl100kMatchers = []matcher{
+	matchContraction,
+	matchCL100KWord,
+	matchNumber,
+	matchPunctuation(false),
+	matchNewlines,
+	matchTrailingSpaces,
+	matchSpaces,
+}
+
+var o200kMatchers = []matcher{
+	matchO200KLowerWord,
+	matchO200KUpperWord,
+	matchNumber,
+	matchPunctuation(true),
+	matchNewlines,
+	matchTrailingSpaces,
+	matchSpaces,
+}
+
+func isNewline(r rune) bool { return r == '\r' || r == '\n' }
+
+// isWordPrefix reports whether r matches [^\r\n\p{L}\p{N}].
+func isWordPrefix(r rune) bool {
+	return !isNewline(r) && !unicode.IsLetter(r) && !unicode.IsNumber(r)
+}
+
+// isPunctuation reports whether r matches [^\s\p{L}\p{N}].
+func isPunctuation(r rune) bool {
+	return !unicode.IsSpace(r) && !unicode.IsLetter(r) && !unicode.IsNumber(r)
+}
+
+// isUpperClass reports whether r matches [\p{Lu}\p{Lt}\p{Lm}\p{Lo}\p{M}].
+func isUpperClass(r rune) bool {
+	return unicode.IsUpper(r) || unicode.IsTitle(r) ||
+		unicode.In(r, unicode.Lm, unicode.Lo, unicode.M)
+}
+
+// isLowerClass reports whether r matches [\p{Ll}\p{Lm}\p{Lo}\p{M}].
+func isLowerClass(r rune) bool {
+	return unicode.IsLower(r) || unicode.In(r, unicode.Lm, unicode.Lo, unicode.M)
+}
+
+// runEnd returns the end of the run of runes satisfying the predicate,
+// starting at i.
+func runEnd(s []rune, i int, pred func(rune) bool) int {
+	for i < len(s) && pred(s[i]) {
+		i++
+	}
+	return i
+}
+
+// matchContraction matches (?i:'s|'t|'re|'ve|'m|'ll|'d).
+func matchContraction(s []rune, i int) int {
+	if i+1 >= len(s) || s[i] != '\'' {
+		return -1
+	}
+	switch unicode.ToLower(s[i+1]) {
+	case 's', 't', 'm', 'd':
+		return i + 2
+	}
+	if i+2 < len(s) {
+		switch string([]rune{unicode.ToLower(s[i+1]), unicode.ToLower(s[i+2])}) {
+		case "re", "ve", "ll":
+			return i + 3
+		}
+	}
+	return -1
+}
+
+// matchCL100KWord matches [^\r\n\p{L}\p{N}]?\p{L}+.
+func matchCL100KWord(s []rune, i int) int {
+	if isWordPrefix(s[i]) && i+1 < len(s) && unicode.IsLetter(s[i+1]) {
+		return runEnd(s, i+1, unicode.IsLetter)
+	}
+	if unicode.IsLetter(s[i]) {
+		return runEnd(s, i, unicode.IsLetter)
+	}
+	return -1
+}
+
+// matchNumber matches \p{N}{1,3}.
+func matchNumber(s []rune, i int) int {
+	end := i
+	for end < len(s) && end-i < 3 && unicode.IsNumber(s[end]) {
+		end++
+	}
+	if end == i {
+		return -1
+	}
+	return end
+}
+
+// matchPunctuation matches ` ?[^\s\p{L}\p{N}]+[\r\n]*`, also accepting
+// slashes in the trailing part for o200k.
+func matchPunctuation(o200k bool) matcher {
+	isTrailing := isNewline
+	if o200k {
+		isTrailing = func(r rune) bool { return isNewline(r) || r == '/' }
+	}
+	return func(s []rune, i int) int {
+		start := i
+		if s[i] == ' ' && i+1 < len(s) && isPunctuation(s[i+1]) {
+			start = i + 1
+		}
+		if !isPunctuation(s[start]) {
+			return -1
+		}
+		end := runEnd(s, start, isPunctuation)
+		return runEnd(s, end, isTrailing)
+	}
+}
+
+// matchNewlines matches \s*[\r\n]+.
+func matchNewlines(s []rune, i int) int {
+	end := runEnd(s, i, unicode.IsSpace)
+	// The greedy \s* gives back characters until [\r\n]+ can match: the
+	// match ends right after the last newline of the whitespace run.
+	for k := end - 1; k >= i; k-- {
+		if isNewline(s[k]) {
+			return k + 1
+		}
+	}
+	return -1
+}
+
+// matchTrailingSpaces matches \s+(?!\S).
+func matchTrailingSpaces(s []rune, i int) int {
+	end := runEnd(s, i, unicode.IsSpace)
+	switch {
+	case end == i:
+		return -1
+	case end == len(s):
+		return end
+	case end-1 > i:
+		// Leave the last whitespace to the following piece.
+		return end - 1
+	default:
+		return -1
+	}
+}
+
+// matchSpaces matches \s+.
+func matchSpaces(s []rune, i int) int {
+	end := runEnd(s, i, unicode.IsSpace)
+	if end == i {
+		return -1
+	}
+	return end
+}
+
+// matchO200KLowerWord matches
+// [^\r\n\p{L}\p{N}]?[\p{Lu}\p{Lt}\p{Lm}\p{Lo}\p{M}]*[\p{Ll}\p{Lm}\p{Lo}\p{M}]+(?i:'s|'t|'re|'ve|'m|'ll|'d)?
+func matchO200KLowerWord(s []rune, i int) int {
+	match := func(p int) int {
+		if p >= len(s) {
+			return -1
+		}
+		upperEnd := runEnd(s, p, isUpperClass)
+		if upperEnd < len(s) && isLowerClass(s[upperEnd]) {
+			return runEnd(s, upperEnd, isLowerClass)
+		}
+		// Backtrack: the last rune of the upper run which also belongs to
+		// the lower class becomes the (single rune) lower part.
+		for k := upperEnd - 1; k >= p; k-- {
+			if isLowerClass(s[k]) {
+				return k + 1
+			}
+		}
+		return -1
+	}
+
+	end := -1
+	if isWordPrefix(s[i]) {
+		end = match(i + 1)
+	}
+	if end < 0 {
+		end = match(i)
+	}
+	if end < 0 {
+		return -1
+	}
+	if c := matchContraction(s, end); c > 0 {
+		return c
+	}
+	return end
+}
+
+// matchO200KUpperWord matches
+// [^\r\n\p{L}\p{N}]?[\p{Lu}\p{Lt}\p{Lm}\p{Lo}\p{M}]+[\p{Ll}\p{Lm}\p{Lo}\p{M}]*(?i:'s|'t|'re|'ve|'m|'ll|'d)?
+func matchO200KUpperWord(s []rune, i int) int {
+	match := func(p int) int {
+		if p >= len(s) || !isUpperClass(s[p]) {
+			return -1
+		}
+		return runEnd(s, runEnd(s, p, isUpperClass), isLowerClass)
+	}
+
+	end := -1
+	if isWordPrefix(s[i]) {
+		end = match(i + 1)
+	}
+	if end < 0 {
+		end = match(i)
+	}
+	if end < 0 {
+		return -1
+	}
+	if c := matchContraction(s, end); c > 0 {
+		return c
+	}
+	return end
+}
@@ -0,0 +1,45 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tokens counts and estimates tokens offline, to predict the size of
+// a request before sending it to the model.
+//
+// An exact count requires an Encoding, loaded from an OpenAI vocabulary file
+// in tiktoken format (e.g. "o200k_base.tiktoken"). Without vocabulary files,
+// the Heuristic counter provides a reasonable approximation.
+//
+// An Estimator, backed by any Counter, estimates the size of complete model
+// requests, including messages, tool calls, tool schemas and images.
+package tokens
+
+// Counter counts the tokens of a text.
+type Counter interface {
+	CountTokens(text string) int
+}
+
+// Heuristic is a Counter approximating the OpenAI encodings without a
+// vocabulary: the text is split like o200k_base, and each piece counts as
+// one token every six bytes (rounding up), since most short pieces are
+// single tokens of the vocabulary.
+type Heuristic struct{}
+
+var _ Counter = Heuristic{}
+
+func (Heuristic) CountTokens(text string) int {
+	count := 0
+	for _, piece := range PretokenizeO200K(text) {
+		count += (len(piece) + 5) / 6
+	}
+	return count
+}
@@ -0,0 +1,216 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokens_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/tokens"
+	"github.com/openai/openai-go/packages/param"
+	"github.com/openai/openai-go/responses"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPretokenizeCL100K(t *testing.T) {
+	testCases := []struct {
+		text string
+		want []string
+	}{
+		{"Hello world", []string{"Hello", " world"}},
+		{"don't", []string{"don", "'t"}},
+		{"I'LL go", []string{"I", "'LL", " go"}},
+		{"123456", []string{"123", "456"}},
+		{"  hi", []string{" ", " hi"}},
+		{"hi  ", []string{"hi", "  "}},
+		{"a\n\nb", []string{"a", "\n\n", "b"}},
+		{"x = 1;", []string{"x", " =", " ", "1", ";"}},
+	}
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%q", tc.text), func(t *testing.T) {
+			assert.Equal(t, tc.want, tokens.PretokenizeCL100K(tc.text))
+		})
+	}
+}
+
+func TestPretokenizeO200K(t *testing.T) {
+	testCases := []struct {
+		text string
+		want []string
+	}{
+		{"Hello world", []string{"Hello", " world"}},
+		{"HelloWorld", []string{"Hello", "World"}},
+		{"don't", []string{"don't"}},
+		{"HTTPServer", []string{"HTTPServer"}},
+		{"a/b//\n", []string{"a", "/b", "//\n"}},
+		{"1234", []string{"123", "4"}},
+	}
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%q", tc.text), func(t *testing.T) {
+			assert.Equal(t, tc.want, tokens.PretokenizeO200K(tc.text))
+		})
+	}
+}
+
+// tinyVocabulary returns a vocabulary in tiktoken format with all single
+// bytes and a few merges.
+func tinyVocabulary() string {
+	var sb strings.Builder
+	rank := 0
+	add := func(token string) {
+		_, _ = fmt.Fprintf(&sb, "%s %d\n", base64.StdEncoding.EncodeToString([]byte(token)), rank)
+		rank++
+	}
+	for b := 0; b < 256; b++ {
+		add(string([]byte{byte(b)}))
+	}
+	add("he")     // 256
+	add("ll")     // 257
+	add("hell")   // 258
+	add(" w")     // 259
+	add(" wo")    // 260
+	add("rld")    // 261
+	add("rl")     // 262
+	add(" world") // 263
+	return sb.String()
+}
+
+func TestEncoding(t *testing.T) {
+	enc, err := tokens.LoadEncoding(tokens.O200KBase, strings.NewReader(tinyVocabulary()))
+	require.NoError(t, err)
+	assert.Equal(t, "o200k_base", enc.Name())
+
+	ids := enc.Encode("hello world")
+	assert.Equal(t, []int{258, 'o', 263}, ids)
+	assert.Equal(t, "hello world", enc.Decode(ids))
+	assert.Equal(t, 3, enc.CountTokens("hello world"))
+
+	text := "Ünïcode, 123 and\nnewlines!"
+	assert.Equal(t, text, enc.Decode(enc.Encode(text)))
+}
+
+func TestParseTiktokenRanksErrors(t *testing.T) {
+	_, err := tokens.ParseTiktokenRanks(strings.NewReader("aGk=\n"))
+	assert.ErrorContains(t, err, "line 1")
+
+	_, err = tokens.ParseTiktokenRanks(strings.NewReader("aGk= 1\n!!! 2\n"))
+	assert.ErrorContains(t, err, "line 2")
+
+	_, err = tokens.LoadEncoding("p50k_base", strings.NewReader(""))
+	assert.Error(t, err)
+}
+
+func TestEncodingForModel(t *testing.T) {
+	assert.Equal(t, tokens.CL100KBase, tokens.EncodingForModel("gpt-4"))
+	assert.Equal(t, tokens.CL100KBase, tokens.EncodingForModel("gpt-3.5-turbo"))
+	assert.Equal(t, tokens.CL100KBase, tokens.EncodingForModel("gpt-4-turbo"))
+	assert.Equal(t, tokens.O200KBase, tokens.EncodingForModel("gpt-4o"))
+	assert.Equal(t, tokens.O200KBase, tokens.EncodingForModel("gpt-4.1"))
+	assert.Equal(t, tokens.O200KBase, tokens.EncodingForModel("o3"))
+}
+
+func TestHeuristic(t *testing.T) {
+	h := tokens.Heuristic{}
+	assert.Equal(t, 0, h.CountTokens(""))
+	assert.Equal(t, 2, h.CountTokens("Hello world"))
+	assert.Equal(t, 3, h.CountTokens("Extraordinary"))
+}
+
+func TestImageTokens(t *testing.T) {
+	assert.Equal(t, 85, tokens.ImageTokens(4096, 4096, "low"))
+	// 1024x1024 is scaled to 768x768: 4 tiles.
+	assert.Equal(t, 765, tokens.ImageTokens(1024, 1024, "high"))
+	// 2048x4096 is scaled to 1024x2048, then to 768x1536: 6 tiles.
+	assert.Equal(t, 1105, tokens.ImageTokens(2048, 4096, "auto"))
+	// Small images fit in one tile.
+	assert.Equal(t, 255, tokens.ImageTokens(100, 100, "high"))
+}
+
+func TestEstimator(t *testing.T) {
+	est := tokens.Estimator{}
+
+	t.Run("message", func(t *testing.T) {
+		item := agents.TResponseInputItem{
+			OfMessage: &responses.EasyInputMessageParam{
+				Role:    responses.EasyInputMessageRoleUser,
+				Content: responses.EasyInputMessageContentUnionParam{OfString: param.NewOpt("Hello world")},
+			},
+		}
+		assert.Equal(t, 3+1+est.CountText("Hello world"), est.CountInputItem(item))
+	})
+
+	t.Run("data URL image", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, png.Encode(&buf, image.NewGray(image.Rect(0, 0, 100, 100))))
+		url := "data:image/png;base64," + base64.StdEncoding.EncodeToString(buf.Bytes())
+
+		item := agents.TResponseInputItem{
+			OfInputMessage: &responses.ResponseInputItemMessageParam{
+				Role: "user",
+				Content: responses.ResponseInputMessageContentListParam{
+					{OfInputImage: &responses.ResponseInputImageParam{
+						Detail:   responses.ResponseInputImageDetailHigh,
+						ImageURL: param.NewOpt(url),
+					}},
+				},
+			},
+		}
+		assert.Equal(t, 3+1+255, est.CountInputItem(item))
+	})
+
+	t.Run("params", func(t *testing.T) {
+		tool := agents.FunctionTool{
+			Name:        "get_weather",
+			Description: "Get the weather",
+			ParamsJSONSchema: map[string]any{
+				"type":       "object",
+				"properties": map[string]any{"city": map[string]any{"type": "string"}},
+			},
+		}
+		params := agents.ModelResponseParams{
+			SystemInstructions: param.NewOpt("You are helpful"),
+			Input:              agents.InputString("What's the weather in Rome?"),
+			Tools:              []agents.Tool{tool},
+		}
+
+		e := est.EstimateParams(params)
+		assert.Equal(t, 3+est.CountText("You are helpful"), e.Instructions)
+		assert.Equal(t, 3+est.CountText("What's the weather in Rome?"), e.Input)
+		assert.Equal(t, est.CountTool(tool), e.Tools)
+		assert.Greater(t, e.Tools, 8)
+		assert.Zero(t, e.OutputSchema)
+		assert.Equal(t, e.Instructions+e.Input+e.Tools+3, e.Total)
+
+		remaining, ok := est.RemainingContext("gpt-4o", params)
+		assert.True(t, ok)
+		assert.Equal(t, int64(128_000-e.Total), remaining)
+
+		_, ok = est.RemainingContext("unknown-model", params)
+		assert.False(t, ok)
+	})
+
+	t.Run("custom counter", func(t *testing.T) {
+		enc, err := tokens.LoadEncoding(tokens.O200KBase, strings.NewReader(tinyVocabulary()))
+		require.NoError(t, err)
+		assert.Equal(t, 3, tokens.NewEstimator(enc).CountText("hello world"))
+	})
+}
@@ -0,0 +1,146 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workflow
+
+import (
+	"context"
+	"sync"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+)
+
+// Event is a streaming event from a workflow run.
+//
+// Node paths identify nodes of nested graphs too (see NodePath).
+type Event interface {
+	isEvent()
+}
+
+// NodeStartedEvent notifies that a node started running.
+type NodeStartedEvent struct {
+	Node string
+}
+
+// NodeCompletedEvent notifies that a node completed successfully.
+type NodeCompletedEvent struct {
+	Node string
+}
+
+// NodeSkippedEvent notifies that a node was skipped, because none of its
+// dependencies activated it.
+type NodeSkippedEvent struct {
+	Node string
+}
+
+// NodeFailedEvent notifies that a node failed.
+type NodeFailedEvent struct {
+	Node string
+	Err  error
+}
+
+// AgentStreamEvent wraps a streaming event from the agent run of a node.
+type AgentStreamEvent struct {
+	Node  string
+	Event agents.StreamEvent
+}
+
+func (NodeStartedEvent) isEvent()   {}
+func (NodeCompletedEvent) isEvent() {}
+func (NodeSkippedEvent) isEvent()   {}
+func (NodeFailedEvent) isEvent()    {}
+func (AgentStreamEvent) isEvent()   {}
+
+// Hooks is implemented by an object that receives callbacks when the nodes
+// of a workflow start and end, for example to trace their execution.
+//
+// Callbacks are called from the goroutines running the nodes. OnNodeStart
+// can return a derived context, which is used to run the node.
+type Hooks interface {
+	OnNodeStart(ctx context.Context, node string) (context.Context, error)
+	OnNodeEnd(ctx context.Context, node string, err error) error
+}
+
+type NoOpHooks struct{}
+
+func (NoOpHooks) OnNodeStart(ctx context.Context, _ string) (context.Context, error) {
+	return ctx, nil
+}
+func (NoOpHooks) OnNodeEnd(context.Context, string, error) error {
+	return nil
+}
+
+// execution holds the settings of a workflow run, shared with nested
+// graphs through the context.
+type execution struct {
+	hooks Hooks
+
+	mu      sync.Mutex
+	handler func(Event) error
+	err     error
+	cancel  context.CancelFunc
+}
+
+// emit delivers an event to the handler, one at a time. If the handler
+// fails, the run is canceled.
+func (e *execution) emit(event Event) {
+	if e == nil || e.handler == nil {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.err != nil {
+		return
+	}
+	if err := e.handler(event); err != nil {
+		e.err = err
+		e.cancel()
+	}
+}
+
+func (e *execution) handlerError() error {
+	if e == nil {
+		return nil
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.err
+}
+
+type executionContextKey struct{}
+type nodePathContextKey struct{}
+
+func contextWithExecution(ctx context.Context, e *execution) context.Context {
+	return context.WithValue(ctx, executionContextKey{}, e)
+}
+
+func executionFromContext(ctx context.Context) *execution {
+	e, _ := ctx.Value(executionContextKey{}).(*execution)
+	return e
+}
+
+// NodePath returns the path of the node running with the given context,
+// with the names of enclosing graph nodes separated by "/".
+// It returns an empty string outside of workflow nodes.
+func NodePath(ctx context.Context) string {
+	p, _ := ctx.Value(nodePathContextKey{}).(string)
+	return p
+}
+
+func contextWithNodePath(ctx context.Context, name string) context.Context {
+	if parent := NodePath(ctx); parent != "" {
+		name = parent + "/" + name
+	}
+	return context.WithValue(ctx, nodePathContextKey{}, name)
+}
@@ -0,0 +1,309 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"sync"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+)
+
+// NodeFunc is a Node implemented by a function.
+type NodeFunc[S any] func(ctx context.Context, state *State[S]) error
+
+func (f NodeFunc[S]) Run(ctx context.Context, state *State[S]) error {
+	return f(ctx, state)
+}
+
+// Func creates a Node running the given function.
+func Func[S any](fn func(ctx context.Context, state *State[S]) error) NodeFunc[S] {
+	return fn
+}
+
+// BranchNode is a Router selecting one of its dependents from the state.
+type BranchNode[S any] struct {
+	// Returns the name of the dependent to run next, or an empty string to
+	// skip all of them.
+	Select func(ctx context.Context, state S) (string, error)
+}
+
+var _ Router[any] = BranchNode[any]{}
+
+// Branch creates a BranchNode with the given selection function.
+func Branch[S any](fn func(ctx context.Context, state S) (string, error)) BranchNode[S] {
+	return BranchNode[S]{Select: fn}
+}
+
+// Run evaluates the selection function, ignoring its choice. It is only
+// called when the node is not part of a Graph.
+func (b BranchNode[S]) Run(ctx context.Context, state *State[S]) error {
+	_, err := b.Route(ctx, state)
+	return err
+}
+
+func (b BranchNode[S]) Route(ctx context.Context, state *State[S]) ([]string, error) {
+	next, err := b.Select(ctx, state.Get())
+	if err != nil || next == "" {
+		return nil, err
+	}
+	return []string{next}, nil
+}
+
+// ParallelNode runs a group of nodes concurrently, and completes when all
+// of them complete. If one fails, the others are canceled.
+type ParallelNode[S any] struct {
+	Nodes []Node[S]
+}
+
+// Parallel creates a ParallelNode running the given nodes.
+//
+// Inside each node, NodePath ends with the index of the node in the group.
+func Parallel[S any](nodes ...Node[S]) ParallelNode[S] {
+	return ParallelNode[S]{Nodes: nodes}
+}
+
+func (p ParallelNode[S]) Run(ctx context.Context, state *State[S]) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	for i, node := range p.Nodes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := node.Run(contextWithNodePath(ctx, strconv.Itoa(i)), state)
+			if err == nil {
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if firstErr == nil {
+				firstErr = err
+				cancel()
+			}
+		}()
+	}
+	wg.Wait()
+	return firstErr
+}
+
+// DefaultMaxIterations is the default maximum number of iterations of a Loop.
+const DefaultMaxIterations = 10
+
+// LoopNode runs a node repeatedly, until the exit condition is satisfied.
+type LoopNode[S any] struct {
+	// The node to run at each iteration.
+	Body Node[S]
+
+	// Exit condition, evaluated after each iteration.
+	Until func(state S) bool
+
+	// Maximum number of iterations. If it is reached without satisfying
+	// the exit condition, the node fails with ErrMaxIterationsExceeded.
+	// Default: DefaultMaxIterations.
+	MaxIterations int
+}
+
+// Loop creates a LoopNode running body until the exit condition is satisfied,
+// with DefaultMaxIterations.
+func Loop[S any](body Node[S], until func(state S) bool) LoopNode[S] {
+	return LoopNode[S]{Body: body, Until: until}
+}
+
+// WithMaxIterations returns a copy of the LoopNode with the given maximum
+// number of iterations.
+func (l LoopNode[S]) WithMaxIterations(n int) LoopNode[S] {
+	l.MaxIterations = n
+	return l
+}
+
+func (l LoopNode[S]) Run(ctx context.Context, state *State[S]) error {
+	maxIterations := l.MaxIterations
+	if maxIterations <= 0 {
+		maxIterations = DefaultMaxIterations
+	}
+	for range maxIterations {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := l.Body.Run(ctx, state); err != nil {
+			return err
+		}
+		if l.Until(state.Get()) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w (%d)", ErrMaxIterationsExceeded, maxIterations)
+}
+
+// AgentResult is the outcome of the agent run of an AgentNode.
+type AgentResult struct {
+	// The input of the run.
+	Input agents.Input
+
+	// The new items generated during the run.
+	NewItems []agents.RunItem
+
+	// The output of the last agent.
+	FinalOutput any
+
+	// The last agent that was run.
+	LastAgent *agents.Agent
+
+	inputList []agents.TResponseInputItem
+}
+
+// ToInputList creates a new input list, merging the original input with all
+// the new items generated, to continue the conversation in another node.
+func (r AgentResult) ToInputList() []agents.TResponseInputItem {
+	return slices.Clone(r.inputList)
+}
+
+// Text returns the concatenated text of all the message outputs of the run.
+func (r AgentResult) Text() string {
+	return agents.ItemHelpers().TextMessageOutputs(r.NewItems)
+}
+
+// AgentNode runs an agent, with an input built from the state, and stores
+// its result into the state.
+//
+// When the workflow is run with streaming, the agent is run in streaming
+// mode too, and its events are forwarded as AgentStreamEvent.
+type AgentNode[S any] struct {
+	// The agent to run.
+	Agent *agents.Agent
+
+	// The runner used to run the agent. Default: agents.DefaultRunner.
+	Runner *agents.Runner
+
+	// Builds the input of the agent from the current state.
+	Input func(state S) agents.Input
+
+	// Optional function storing the result into the state. It is called
+	// while holding the state lock.
+	Output func(state *S, result AgentResult) error
+}
+
+// Agent creates an AgentNode.
+func Agent[S any](
+	agent *agents.Agent,
+	input func(state S) agents.Input,
+	output func(state *S, result AgentResult) error,
+) AgentNode[S] {
+	return AgentNode[S]{
+		Agent:  agent,
+		Input:  input,
+		Output: output,
+	}
+}
+
+// WithRunner returns a copy of the AgentNode using the given Runner.
+func (a AgentNode[S]) WithRunner(runner agents.Runner) AgentNode[S] {
+	a.Runner = &runner
+	return a
+}
+
+func (a AgentNode[S]) Run(ctx context.Context, state *State[S]) error {
+	runner := agents.DefaultRunner
+	if a.Runner != nil {
+		runner = *a.Runner
+	}
+
+	input := a.Input(state.Get())
+	var (
+		result AgentResult
+		err    error
+	)
+	if exec := executionFromContext(ctx); exec != nil && exec.handler != nil {
+		result, err = a.runStreamed(ctx, runner, input, exec)
+	} else {
+		result, err = a.run(ctx, runner, input)
+	}
+	if err != nil {
+		return err
+	}
+
+	if a.Output != nil {
+		state.Update(func(s *S) { err = a.Output(s, result) })
+	}
+	return err
+}
+
+func (a AgentNode[S]) run(ctx context.Context, runner agents.Runner, input agents.Input) (AgentResult, error) {
+	var (
+		result *agents.RunResult
+		err    error
+	)
+	switch v := input.(type) {
+	case agents.InputString:
+		result, err = runner.Run(ctx, a.Agent, string(v))
+	case agents.InputItems:
+		result, err = runner.RunResponseInputs(ctx, a.Agent, slices.Clone(v))
+	default:
+		return AgentResult{}, fmt.Errorf("unexpected agent input type %T", input)
+	}
+	if err != nil {
+		return AgentResult{}, err
+	}
+	return AgentResult{
+		Input:       result.Input,
+		NewItems:    result.NewItems,
+		FinalOutput: result.FinalOutput,
+		LastAgent:   result.LastAgent,
+		inputList:   result.ToInputList(),
+	}, nil
+}
+
+func (a AgentNode[S]) runStreamed(ctx context.Context, runner agents.Runner, input agents.Input, exec *execution) (AgentResult, error) {
+	var (
+		result *agents.RunResultStreaming
+		err    error
+	)
+	switch v := input.(type) {
+	case agents.InputString:
+		result, err = runner.RunStreamed(ctx, a.Agent, string(v))
+	case agents.InputItems:
+		result, err = runner.RunResponseInputsStreamed(ctx, a.Agent, slices.Clone(v))
+	default:
+		return AgentResult{}, fmt.Errorf("unexpected agent input type %T", input)
+	}
+	if err != nil {
+		return AgentResult{}, err
+	}
+
+	path := NodePath(ctx)
+	err = result.StreamEvents(func(event agents.StreamEvent) error {
+		exec.emit(AgentStreamEvent{Node: path, Event: event})
+		return nil
+	})
+	if err != nil {
+		return AgentResult{}, err
+	}
+	return AgentResult{
+		Input:       result.Input(),
+		NewItems:    result.NewItems(),
+		FinalOutput: result.FinalOutput(),
+		LastAgent:   result.LastAgent(),
+		inputList:   result.ToInputList(),
+	}, nil
+}
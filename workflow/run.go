@@ -0,0 +1,375 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+)
+
+// Runner runs workflow graphs.
+type Runner[S any] struct {
+	Config RunConfig[S]
+}
+
+// RunConfig configures settings for a workflow run.
+type RunConfig[S any] struct {
+	// Optional object receiving callbacks when nodes start and end,
+	// including the nodes of nested graphs.
+	Hooks Hooks
+
+	// Optional object saving a Checkpoint of the top-level graph after
+	// each node completes, so that an interrupted run can be resumed.
+	Checkpointer Checkpointer[S]
+}
+
+// Run runs the graph with the given initial state, using a default Runner.
+func Run[S any](ctx context.Context, g *Graph[S], initial S) (S, error) {
+	return Runner[S]{}.Run(ctx, g, initial)
+}
+
+// RunStreamed runs the graph with the given initial state, using a default
+// Runner, and calls handler for each event (see Runner.RunStreamed).
+func RunStreamed[S any](ctx context.Context, g *Graph[S], initial S, handler func(Event) error) (S, error) {
+	return Runner[S]{}.RunStreamed(ctx, g, initial, handler)
+}
+
+// Run runs the graph with the given initial state, and returns the final
+// state.
+//
+// On failure, the returned state reflects the updates made before the error.
+func (r Runner[S]) Run(ctx context.Context, g *Graph[S], initial S) (S, error) {
+	return r.run(ctx, g, initial, nil, nil)
+}
+
+// RunStreamed runs the graph like Run, calling handler for each event.
+//
+// Agent nodes run in streaming mode, and their events are forwarded as well.
+// The handler is never called concurrently. If it returns an error, the run
+// is canceled and the error is returned.
+func (r Runner[S]) RunStreamed(ctx context.Context, g *Graph[S], initial S, handler func(Event) error) (S, error) {
+	return r.run(ctx, g, initial, nil, handler)
+}
+
+// Resume continues the run of the graph from a checkpoint: the nodes which
+// were completed or skipped are not run again. An optional handler receives
+// the events, as in RunStreamed.
+func (r Runner[S]) Resume(ctx context.Context, g *Graph[S], checkpoint Checkpoint[S], handler func(Event) error) (S, error) {
+	return r.run(ctx, g, checkpoint.State, &checkpoint, handler)
+}
+
+func (r Runner[S]) run(
+	ctx context.Context,
+	g *Graph[S],
+	initial S,
+	resume *Checkpoint[S],
+	handler func(Event) error,
+) (S, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	hooks := r.Config.Hooks
+	if hooks == nil {
+		hooks = NoOpHooks{}
+	}
+	exec := &execution{
+		hooks:   hooks,
+		handler: handler,
+		cancel:  cancel,
+	}
+	ctx = contextWithExecution(ctx, exec)
+
+	state := NewState(initial)
+	err := g.execute(ctx, state, resume, r.Config.Checkpointer)
+	if handlerErr := exec.handlerError(); handlerErr != nil {
+		err = handlerErr
+	}
+	return state.Get(), err
+}
+
+type nodeStatus uint8
+
+const (
+	statusPending nodeStatus = iota
+	statusRunning
+	statusCompleted
+	statusSkipped
+	statusFailed
+)
+
+type nodeOutcome struct {
+	name   string
+	routes []string
+	err    error
+}
+
+// execute runs the nodes of the graph as soon as their dependencies are
+// done, saving checkpoints if a Checkpointer is given.
+func (g *Graph[S]) execute(
+	ctx context.Context,
+	state *State[S],
+	resume *Checkpoint[S],
+	checkpointer Checkpointer[S],
+) error {
+	if err := g.Validate(); err != nil {
+		return err
+	}
+
+	exec := executionFromContext(ctx)
+	hooks := Hooks(NoOpHooks{})
+	if exec != nil {
+		hooks = exec.hooks
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	status := make(map[string]nodeStatus, len(g.nodes))
+	routes := make(map[string][]string)
+	if resume != nil {
+		for _, name := range resume.Completed {
+			status[name] = statusCompleted
+		}
+		for _, name := range resume.Skipped {
+			status[name] = statusSkipped
+		}
+		maps.Copy(routes, resume.Routes)
+	}
+
+	outcomes := make(chan nodeOutcome)
+	running := 0
+	var firstErr error
+
+	fail := func(err error) {
+		if firstErr == nil {
+			firstErr = err
+			cancel()
+		}
+	}
+
+	for {
+		if firstErr == nil {
+			if err := ctx.Err(); err != nil {
+				fail(err)
+			}
+		}
+		if firstErr == nil {
+			running += g.schedule(ctx, state, status, routes, running, hooks, exec, outcomes)
+		}
+		if running == 0 {
+			break
+		}
+
+		outcome := <-outcomes
+		running--
+		if outcome.err != nil {
+			status[outcome.name] = statusFailed
+			fail(outcome.err)
+			continue
+		}
+		status[outcome.name] = statusCompleted
+		if _, ok := g.nodes[outcome.name].node.(Router[S]); ok {
+			routes[outcome.name] = outcome.routes
+		}
+
+		if checkpointer != nil && firstErr == nil {
+			if err := checkpointer.Save(ctx, g.checkpoint(state, status, routes)); err != nil {
+				fail(fmt.Errorf("failed to save workflow checkpoint: %w", err))
+			}
+		}
+	}
+	return firstErr
+}
+
+// schedule skips the nodes which can no longer be activated, and starts the
+// ready ones within the concurrency limit. It returns the number of started
+// nodes.
+func (g *Graph[S]) schedule(
+	ctx context.Context,
+	state *State[S],
+	status map[string]nodeStatus,
+	routes map[string][]string,
+	running int,
+	hooks Hooks,
+	exec *execution,
+	outcomes chan<- nodeOutcome,
+) int {
+	started := 0
+	for changed := true; changed; {
+		changed = false
+		for _, name := range g.order {
+			if status[name] != statusPending {
+				continue
+			}
+			n := g.nodes[name]
+			ready, active := g.readiness(n, status, routes)
+			if !ready {
+				continue
+			}
+			if !active {
+				status[name] = statusSkipped
+				exec.emit(NodeSkippedEvent{Node: NodePath(contextWithNodePath(ctx, name))})
+				changed = true
+				continue
+			}
+			if g.MaxConcurrency > 0 && running+started >= g.MaxConcurrency {
+				return started
+			}
+			status[name] = statusRunning
+			started++
+			go func() {
+				outcomes <- g.runNode(ctx, n, state, hooks, exec)
+			}()
+		}
+	}
+	return started
+}
+
+// readiness reports whether all the dependencies of the node are done, and
+// whether at least one of them activated it.
+func (g *Graph[S]) readiness(n *graphNode[S], status map[string]nodeStatus, routes map[string][]string) (ready, active bool) {
+	if len(n.deps) == 0 {
+		return true, true
+	}
+	for _, dep := range n.deps {
+		switch status[dep] {
+		case statusCompleted:
+			if _, isRouter := g.nodes[dep].node.(Router[S]); !isRouter || slices.Contains(routes[dep], n.name) {
+				active = true
+			}
+		case statusSkipped:
+		default:
+			return false, false
+		}
+	}
+	return true, active
+}
+
+func (g *Graph[S]) runNode(
+	ctx context.Context,
+	n *graphNode[S],
+	state *State[S],
+	hooks Hooks,
+	exec *execution,
+) nodeOutcome {
+	ctx = contextWithNodePath(ctx, n.name)
+	path := NodePath(ctx)
+
+	ctx, err := hooks.OnNodeStart(ctx, path)
+	if err != nil {
+		return nodeOutcome{name: n.name, err: &NodeError{Node: path, Err: err}}
+	}
+	exec.emit(NodeStartedEvent{Node: path})
+
+	var routes []string
+	if router, ok := n.node.(Router[S]); ok {
+		routes, err = router.Route(ctx, state)
+		if err == nil {
+			for _, r := range routes {
+				if !slices.Contains(n.dependents, r) {
+					err = fmt.Errorf("%w: router selected %q, which is not one of its dependents", ErrInvalidGraph, r)
+					break
+				}
+			}
+		}
+	} else {
+		err = n.node.Run(ctx, state)
+	}
+
+	if hookErr := hooks.OnNodeEnd(ctx, path, err); err == nil {
+		err = hookErr
+	}
+
+	if err != nil {
+		exec.emit(NodeFailedEvent{Node: path, Err: err})
+		if nodeErr := (*NodeError)(nil); !errors.As(err, &nodeErr) {
+			err = &NodeError{Node: path, Err: err}
+		}
+		return nodeOutcome{name: n.name, err: err}
+	}
+	exec.emit(NodeCompletedEvent{Node: path})
+	return nodeOutcome{name: n.name, routes: routes}
+}
+
+func (g *Graph[S]) checkpoint(state *State[S], status map[string]nodeStatus, routes map[string][]string) Checkpoint[S] {
+	c := Checkpoint[S]{
+		State:  state.Get(),
+		Routes: make(map[string][]string, len(routes)),
+	}
+	for _, name := range g.order {
+		switch status[name] {
+		case statusCompleted:
+			c.Completed = append(c.Completed, name)
+		case statusSkipped:
+			c.Skipped = append(c.Skipped, name)
+		}
+	}
+	for k, v := range routes {
+		c.Routes[k] = slices.Clone(v)
+	}
+	return c
+}
+
+// Checkpoint is a snapshot of the progress of a workflow run, from which
+// the run can be resumed with Runner.Resume.
+//
+// It can be serialized to JSON if the state can.
+type Checkpoint[S any] struct {
+	// The state after the last completed node.
+	State S `json:"state"`
+
+	// The names of the completed nodes.
+	Completed []string `json:"completed"`
+
+	// The names of the skipped nodes.
+	Skipped []string `json:"skipped"`
+
+	// The dependents selected by each completed router node.
+	Routes map[string][]string `json:"routes,omitempty"`
+}
+
+// Checkpointer is implemented by an object that persists workflow
+// checkpoints.
+type Checkpointer[S any] interface {
+	Save(ctx context.Context, checkpoint Checkpoint[S]) error
+}
+
+// MemoryCheckpointer is a Checkpointer keeping the latest checkpoint in
+// memory.
+type MemoryCheckpointer[S any] struct {
+	mu     sync.Mutex
+	latest *Checkpoint[S]
+}
+
+func (m *MemoryCheckpointer[S]) Save(_ context.Context, checkpoint Checkpoint[S]) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.latest = &checkpoint
+	return nil
+}
+
+// Latest returns the latest saved checkpoint, if any.
+func (m *MemoryCheckpointer[S]) Latest() (Checkpoint[S], bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.latest == nil {
+		return Checkpoint[S]{}, false
+	}
+	return *m.latest, true
+}
@@ -0,0 +1,202 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package workflow orchestrates agents and functions as a graph of nodes
+// sharing a typed state.
+//
+// A Graph is a directed acyclic graph of named nodes: each node runs once
+// all of its dependencies are done, and independent nodes run concurrently.
+// Nodes can be agent runs (Agent), plain functions (Func), routers choosing
+// which dependents to activate (Branch), concurrent groups (Parallel),
+// loops with an exit condition (Loop), or other graphs.
+//
+// A node runs when all its dependencies are done and at least one of them
+// activated it: a dependency activates its dependents when it completes,
+// unless it is a router, which only activates the ones it selects. Nodes
+// which are not activated are skipped, and so are, transitively, their own
+// dependents. A node without dependencies is always activated.
+package workflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// Node is a unit of work of a workflow.
+type Node[S any] interface {
+	Run(ctx context.Context, state *State[S]) error
+}
+
+// Router is a Node choosing which of its dependents run next.
+//
+// When a Router is part of a Graph, Route is called in place of Run.
+type Router[S any] interface {
+	Node[S]
+	Route(ctx context.Context, state *State[S]) ([]string, error)
+}
+
+var (
+	// ErrInvalidGraph is returned when a Graph is not well-formed.
+	ErrInvalidGraph = errors.New("invalid workflow graph")
+
+	// ErrMaxIterationsExceeded is returned when a Loop reaches its maximum
+	// number of iterations without satisfying its exit condition.
+	ErrMaxIterationsExceeded = errors.New("workflow loop exceeded max iterations")
+)
+
+// NodeError reports the failure of a node.
+type NodeError struct {
+	// The path of the failed node (see NodePath).
+	Node string
+	Err  error
+}
+
+func (e *NodeError) Error() string {
+	return fmt.Sprintf("workflow node %q failed: %v", e.Node, e.Err)
+}
+
+func (e *NodeError) Unwrap() error { return e.Err }
+
+// State holds the state shared by all the nodes of a workflow run.
+//
+// It is safe for concurrent use. Get returns a shallow copy of the value:
+// state types holding maps or slices should only be modified with Update.
+type State[S any] struct {
+	mu    sync.RWMutex
+	value S
+}
+
+// NewState creates a new State with the given initial value.
+func NewState[S any](value S) *State[S] {
+	return &State[S]{value: value}
+}
+
+// Get returns the current value of the state.
+func (s *State[S]) Get() S {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.value
+}
+
+// Update modifies the state, holding an exclusive lock.
+func (s *State[S]) Update(fn func(*S)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fn(&s.value)
+}
+
+// Set replaces the value of the state.
+func (s *State[S]) Set(value S) {
+	s.Update(func(v *S) { *v = value })
+}
+
+// Graph is a directed acyclic graph of named nodes.
+//
+// A Graph is itself a Node, so it can be nested into other graphs or used
+// as the body of a Loop. It must not be modified while running.
+type Graph[S any] struct {
+	nodes map[string]*graphNode[S]
+	order []string
+	err   error
+
+	// Optional maximum number of nodes of this graph running at the same
+	// time. Zero means no limit.
+	MaxConcurrency int
+}
+
+type graphNode[S any] struct {
+	name       string
+	node       Node[S]
+	deps       []string
+	dependents []string
+}
+
+// New creates a new empty Graph.
+func New[S any]() *Graph[S] {
+	return &Graph[S]{nodes: make(map[string]*graphNode[S])}
+}
+
+// Add adds a named node, running after the given dependencies.
+// Dependencies must be added before the nodes depending on them, which
+// guarantees that the graph is acyclic.
+//
+// Errors are reported by Validate, and when the graph is run.
+func (g *Graph[S]) Add(name string, node Node[S], dependsOn ...string) *Graph[S] {
+	switch {
+	case g.err != nil:
+		return g
+	case name == "":
+		g.err = fmt.Errorf("%w: empty node name", ErrInvalidGraph)
+		return g
+	case node == nil:
+		g.err = fmt.Errorf("%w: node %q is nil", ErrInvalidGraph, name)
+		return g
+	case g.nodes[name] != nil:
+		g.err = fmt.Errorf("%w: duplicate node %q", ErrInvalidGraph, name)
+		return g
+	}
+
+	for _, dep := range dependsOn {
+		d, ok := g.nodes[dep]
+		if !ok {
+			g.err = fmt.Errorf("%w: node %q depends on unknown node %q", ErrInvalidGraph, name, dep)
+			return g
+		}
+		if slices.Contains(d.dependents, name) {
+			g.err = fmt.Errorf("%w: node %q depends twice on %q", ErrInvalidGraph, name, dep)
+			return g
+		}
+		d.dependents = append(d.dependents, name)
+	}
+
+	g.nodes[name] = &graphNode[S]{
+		name: name,
+		node: node,
+		deps: slices.Clone(dependsOn),
+	}
+	g.order = append(g.order, name)
+	return g
+}
+
+// Validate reports the first error encountered while building the graph.
+func (g *Graph[S]) Validate() error {
+	if g.err != nil {
+		return g.err
+	}
+	if len(g.nodes) == 0 {
+		return fmt.Errorf("%w: no nodes", ErrInvalidGraph)
+	}
+	return nil
+}
+
+// Nodes returns the names of the nodes, in insertion order.
+func (g *Graph[S]) Nodes() []string {
+	return slices.Clone(g.order)
+}
+
+// Dependencies returns the names of the dependencies of a node.
+func (g *Graph[S]) Dependencies(name string) []string {
+	if n, ok := g.nodes[name]; ok {
+		return slices.Clone(n.deps)
+	}
+	return nil
+}
+
+// Run runs the graph as a node of an enclosing workflow.
+func (g *Graph[S]) Run(ctx context.Context, state *State[S]) error {
+	return g.execute(ctx, state, nil, nil)
+}
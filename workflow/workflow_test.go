@@ -0,0 +1,369 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workflow_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/agentstesting"
+	"github.com/nlpodyssey/openai-agents-go/workflow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type state struct {
+	Log          []string
+	Translations []string
+	Best         string
+	Route        string
+	Score        int
+}
+
+// logNode appends its name to the state log.
+func logNode(name string) workflow.NodeFunc[state] {
+	return workflow.Func(func(_ context.Context, s *workflow.State[state]) error {
+		s.Update(func(v *state) { v.Log = append(v.Log, name) })
+		return nil
+	})
+}
+
+func textAgent(name, output string) *agents.Agent {
+	model := agentstesting.NewFakeModel(&agentstesting.FakeModelTurnOutput{
+		Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage(output)},
+	})
+	return agents.New(name).WithModelInstance(model)
+}
+
+func TestParallelizationPattern(t *testing.T) {
+	translate := func(i int) workflow.AgentNode[state] {
+		return workflow.Agent(
+			textAgent(fmt.Sprintf("spanish_%d", i), fmt.Sprintf("hola %d", i)),
+			func(state) agents.Input { return agents.InputString("hello") },
+			func(s *state, r workflow.AgentResult) error {
+				s.Translations = append(s.Translations, r.Text())
+				return nil
+			},
+		)
+	}
+	picker := workflow.Agent(
+		textAgent("picker", "hola 1"),
+		func(s state) agents.Input {
+			slices.Sort(s.Translations)
+			return agents.InputString(strings.Join(s.Translations, "\n"))
+		},
+		func(s *state, r workflow.AgentResult) error {
+			s.Best = r.FinalOutput.(string)
+			return nil
+		},
+	)
+
+	g := workflow.New[state]().
+		Add("t0", translate(0)).
+		Add("t1", translate(1)).
+		Add("t2", translate(2)).
+		Add("pick", picker, "t0", "t1", "t2")
+
+	result, err := workflow.Run(t.Context(), g, state{})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"hola 0", "hola 1", "hola 2"}, result.Translations)
+	assert.Equal(t, "hola 1", result.Best)
+}
+
+func TestIndependentNodesRunConcurrently(t *testing.T) {
+	var barrier sync.WaitGroup
+	barrier.Add(2)
+	waitBoth := workflow.Func(func(ctx context.Context, _ *workflow.State[state]) error {
+		barrier.Done()
+		done := make(chan struct{})
+		go func() { barrier.Wait(); close(done) }()
+		select {
+		case <-done:
+			return nil
+		case <-time.After(5 * time.Second):
+			return errors.New("nodes did not run concurrently")
+		}
+	})
+
+	g := workflow.New[state]().Add("a", waitBoth).Add("b", waitBoth)
+	_, err := workflow.Run(t.Context(), g, state{})
+	require.NoError(t, err)
+}
+
+func TestMaxConcurrency(t *testing.T) {
+	var current, peak atomic.Int32
+	node := workflow.Func(func(context.Context, *workflow.State[state]) error {
+		n := current.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		current.Add(-1)
+		return nil
+	})
+
+	g := workflow.New[state]()
+	for i := range 6 {
+		g.Add(fmt.Sprintf("n%d", i), node)
+	}
+	g.MaxConcurrency = 2
+
+	_, err := workflow.Run(t.Context(), g, state{})
+	require.NoError(t, err)
+	assert.LessOrEqual(t, peak.Load(), int32(2))
+}
+
+func TestBranch(t *testing.T) {
+	router := workflow.Branch(func(_ context.Context, s state) (string, error) {
+		return s.Route, nil
+	})
+	newGraph := func() *workflow.Graph[state] {
+		return workflow.New[state]().
+			Add("route", router).
+			Add("spanish", logNode("spanish"), "route").
+			Add("french", logNode("french"), "route").
+			Add("french_review", logNode("french_review"), "french").
+			Add("done", logNode("done"), "spanish", "french_review")
+	}
+
+	var events []workflow.Event
+	result, err := workflow.RunStreamed(t.Context(), newGraph(), state{Route: "spanish"}, func(e workflow.Event) error {
+		events = append(events, e)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"spanish", "done"}, result.Log)
+	assert.Contains(t, events, workflow.Event(workflow.NodeSkippedEvent{Node: "french"}))
+	assert.Contains(t, events, workflow.Event(workflow.NodeSkippedEvent{Node: "french_review"}))
+
+	result, err = workflow.Run(t.Context(), newGraph(), state{Route: "french"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"french", "french_review", "done"}, result.Log)
+
+	result, err = workflow.Run(t.Context(), newGraph(), state{Route: ""})
+	require.NoError(t, err)
+	assert.Empty(t, result.Log)
+
+	_, err = workflow.Run(t.Context(), newGraph(), state{Route: "done"})
+	assert.ErrorIs(t, err, workflow.ErrInvalidGraph)
+}
+
+func TestLoop(t *testing.T) {
+	improve := workflow.Func(func(_ context.Context, s *workflow.State[state]) error {
+		s.Update(func(v *state) { v.Score++ })
+		return nil
+	})
+
+	g := workflow.New[state]().
+		Add("loop", workflow.Loop(improve, func(s state) bool { return s.Score >= 3 }))
+	result, err := workflow.Run(t.Context(), g, state{})
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.Score)
+
+	g = workflow.New[state]().
+		Add("loop", workflow.Loop(improve, func(state) bool { return false }).WithMaxIterations(4))
+	result, err = workflow.Run(t.Context(), g, state{})
+	assert.ErrorIs(t, err, workflow.ErrMaxIterationsExceeded)
+	assert.Equal(t, 4, result.Score)
+
+	var nodeErr *workflow.NodeError
+	require.ErrorAs(t, err, &nodeErr)
+	assert.Equal(t, "loop", nodeErr.Node)
+}
+
+func TestParallelNode(t *testing.T) {
+	var paths sync.Map
+	record := workflow.Func(func(ctx context.Context, _ *workflow.State[state]) error {
+		paths.Store(workflow.NodePath(ctx), true)
+		return nil
+	})
+
+	g := workflow.New[state]().Add("fan_out", workflow.Parallel(record, record, record))
+	_, err := workflow.Run(t.Context(), g, state{})
+	require.NoError(t, err)
+	for _, p := range []string{"fan_out/0", "fan_out/1", "fan_out/2"} {
+		_, ok := paths.Load(p)
+		assert.True(t, ok, p)
+	}
+
+	boom := errors.New("boom")
+	g = workflow.New[state]().Add("fan_out", workflow.Parallel(
+		workflow.Func(func(context.Context, *workflow.State[state]) error { return boom }),
+		workflow.Func(func(ctx context.Context, _ *workflow.State[state]) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}),
+	))
+	_, err = workflow.Run(t.Context(), g, state{})
+	assert.ErrorIs(t, err, boom)
+}
+
+func TestFailureStopsDependents(t *testing.T) {
+	boom := errors.New("boom")
+	g := workflow.New[state]().
+		Add("inner", workflow.New[state]().
+			Add("ok", logNode("ok")).
+			Add("fail", workflow.Func(func(context.Context, *workflow.State[state]) error { return boom }), "ok")).
+		Add("after", logNode("after"), "inner")
+
+	var failed []string
+	result, err := workflow.RunStreamed(t.Context(), g, state{}, func(e workflow.Event) error {
+		if e, ok := e.(workflow.NodeFailedEvent); ok {
+			failed = append(failed, e.Node)
+		}
+		return nil
+	})
+	assert.ErrorIs(t, err, boom)
+	assert.Equal(t, []string{"ok"}, result.Log)
+	assert.Equal(t, []string{"inner/fail", "inner"}, failed)
+
+	var nodeErr *workflow.NodeError
+	require.ErrorAs(t, err, &nodeErr)
+	assert.Equal(t, "inner/fail", nodeErr.Node)
+}
+
+func TestInvalidGraph(t *testing.T) {
+	for name, g := range map[string]*workflow.Graph[state]{
+		"empty":          workflow.New[state](),
+		"duplicate":      workflow.New[state]().Add("a", logNode("a")).Add("a", logNode("a")),
+		"unknown dep":    workflow.New[state]().Add("a", logNode("a"), "b"),
+		"empty name":     workflow.New[state]().Add("", logNode("a")),
+		"nil node":       workflow.New[state]().Add("a", nil),
+		"repeated dep":   workflow.New[state]().Add("a", logNode("a")).Add("b", logNode("b"), "a", "a"),
+		"nested invalid": workflow.New[state]().Add("a", workflow.New[state]()),
+	} {
+		_, err := workflow.Run(t.Context(), g, state{})
+		assert.ErrorIs(t, err, workflow.ErrInvalidGraph, name)
+	}
+}
+
+func TestStreamingAgentEvents(t *testing.T) {
+	g := workflow.New[state]().
+		Add("greet", workflow.Agent(
+			textAgent("greeter", "hi"),
+			func(state) agents.Input { return agents.InputString("hello") },
+			func(s *state, r workflow.AgentResult) error {
+				s.Best = r.Text()
+				return nil
+			},
+		))
+
+	var kinds []string
+	result, err := workflow.RunStreamed(t.Context(), g, state{}, func(e workflow.Event) error {
+		switch e := e.(type) {
+		case workflow.NodeStartedEvent:
+			kinds = append(kinds, "started:"+e.Node)
+		case workflow.NodeCompletedEvent:
+			kinds = append(kinds, "completed:"+e.Node)
+		case workflow.AgentStreamEvent:
+			if _, ok := e.Event.(agents.RunItemStreamEvent); ok {
+				kinds = append(kinds, "item:"+e.Node)
+			}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "hi", result.Best)
+	assert.Equal(t, []string{"started:greet", "item:greet", "completed:greet"}, kinds)
+
+	stop := errors.New("stop")
+	_, err = workflow.RunStreamed(t.Context(), workflow.New[state]().Add("a", logNode("a")), state{}, func(workflow.Event) error {
+		return stop
+	})
+	assert.ErrorIs(t, err, stop)
+}
+
+type recordingHooks struct {
+	mu    sync.Mutex
+	calls []string
+}
+
+func (h *recordingHooks) OnNodeStart(ctx context.Context, node string) (context.Context, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.calls = append(h.calls, "start:"+node)
+	return ctx, nil
+}
+
+func (h *recordingHooks) OnNodeEnd(_ context.Context, node string, err error) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.calls = append(h.calls, fmt.Sprintf("end:%s:%v", node, err))
+	return nil
+}
+
+func TestHooks(t *testing.T) {
+	hooks := &recordingHooks{}
+	g := workflow.New[state]().
+		Add("a", logNode("a")).
+		Add("sub", workflow.New[state]().Add("b", logNode("b")), "a")
+
+	_, err := workflow.Runner[state]{Config: workflow.RunConfig[state]{Hooks: hooks}}.Run(t.Context(), g, state{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"start:a", "end:a:<nil>",
+		"start:sub", "start:sub/b", "end:sub/b:<nil>", "end:sub:<nil>",
+	}, hooks.calls)
+}
+
+func TestCheckpointAndResume(t *testing.T) {
+	var failOnce atomic.Bool
+	failOnce.Store(true)
+	flaky := workflow.Func(func(_ context.Context, s *workflow.State[state]) error {
+		if failOnce.CompareAndSwap(true, false) {
+			return errors.New("transient")
+		}
+		s.Update(func(v *state) { v.Log = append(v.Log, "flaky") })
+		return nil
+	})
+
+	g := workflow.New[state]().
+		Add("route", workflow.Branch(func(context.Context, state) (string, error) { return "a", nil })).
+		Add("a", logNode("a"), "route").
+		Add("b", logNode("b"), "route").
+		Add("flaky", flaky, "a", "b").
+		Add("last", logNode("last"), "flaky")
+
+	checkpointer := &workflow.MemoryCheckpointer[state]{}
+	runner := workflow.Runner[state]{Config: workflow.RunConfig[state]{Checkpointer: checkpointer}}
+
+	_, err := runner.Run(t.Context(), g, state{})
+	require.Error(t, err)
+
+	checkpoint, ok := checkpointer.Latest()
+	require.True(t, ok)
+	assert.Equal(t, []string{"route", "a"}, checkpoint.Completed)
+	assert.Equal(t, []string{"b"}, checkpoint.Skipped)
+	assert.Equal(t, map[string][]string{"route": {"a"}}, checkpoint.Routes)
+	assert.Equal(t, []string{"a"}, checkpoint.State.Log)
+
+	result, err := runner.Resume(t.Context(), g, checkpoint, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "flaky", "last"}, result.Log)
+
+	checkpoint, _ = checkpointer.Latest()
+	assert.Equal(t, []string{"route", "a", "flaky", "last"}, checkpoint.Completed)
+}